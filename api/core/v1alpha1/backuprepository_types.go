@@ -0,0 +1,113 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// BackupRepositoryConditionTypeSynced is the condition of the last scan of the repository
+	BackupRepositoryConditionTypeSynced = "Synced"
+
+	defaultBackupRepositoryInterval = time.Hour
+)
+
+type BackupRepositorySpec struct {
+	// source is the backup location to scan, the backups published to the catalog of the
+	// location by the BackupJobs are imported as Backups
+	Source SharedStorageProvider `json:"source"`
+
+	// interval is the interval between two scans of the source, default to 1h
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// overlay allows customizing the scan job
+	// +optional
+	Overlay *Overlay `json:"overlay,omitempty"`
+}
+
+type BackupRepositoryStatus struct {
+	ConditionalStatus `json:",inline"`
+
+	// observedGeneration is the generation of the spec that is scanned last time
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// lastScanTime is the completion time of the last successful scan
+	// +optional
+	LastScanTime *metav1.Time `json:"lastScanTime,omitempty"`
+
+	// backups is the number of the backups found in the source by the last scan
+	// +optional
+	Backups int32 `json:"backups,omitempty"`
+
+	// imported is the number of the Backups imported by the last scan
+	// +optional
+	Imported int32 `json:"imported,omitempty"`
+}
+
+// A BackupRepository scans a backup location periodically and imports the backups found in the
+// location as Backups, so that the backups taken by another operator instance or in another
+// Kubernetes cluster can be restored declaratively. The imported Backups are kept when the
+// BackupRepository is deleted and their data is retained by default.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope="Namespaced"
+// +kubebuilder:printcolumn:name="Backups",type="integer",JSONPath=".status.backups"
+// +kubebuilder:printcolumn:name="Last Scan",type="date",JSONPath=".status.lastScanTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+type BackupRepository struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BackupRepositorySpec `json:"spec"`
+
+	Status BackupRepositoryStatus `json:"status,omitempty"`
+}
+
+func (r *BackupRepository) GetInterval() time.Duration {
+	if r.Spec.Interval == nil {
+		return defaultBackupRepositoryInterval
+	}
+	return r.Spec.Interval.Duration
+}
+
+// GetSourceRef returns the sourceRef of the Backups imported by the repository
+func (r *BackupRepository) GetSourceRef() string {
+	return "backuprepository/" + r.Namespace + "/" + r.Name
+}
+
+func (r *BackupRepository) SetCondition(condition metav1.Condition) {
+	r.Status.SetCondition(condition)
+}
+
+func (r *BackupRepository) GetConditions() []metav1.Condition {
+	return r.Status.GetConditions()
+}
+
+// BackupRepositoryList contains a list of BackupRepository
+// +kubebuilder:object:root=true
+type BackupRepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BackupRepository `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupRepository{}, &BackupRepositoryList{})
+}
@@ -0,0 +1,186 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConcurrencyPolicy describes how the scheduled backup job will be handled when
+// the previous one is still running
+// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+type ConcurrencyPolicy string
+
+const (
+	// ConcurrencyPolicyAllow allows the backup jobs to run concurrently
+	ConcurrencyPolicyAllow ConcurrencyPolicy = "Allow"
+	// ConcurrencyPolicyForbid skips the new backup job if the previous one is still running
+	ConcurrencyPolicyForbid ConcurrencyPolicy = "Forbid"
+	// ConcurrencyPolicyReplace cancels the running backup job and replaces it with the new one
+	ConcurrencyPolicyReplace ConcurrencyPolicy = "Replace"
+)
+
+type BackupScheduleSpec struct {
+	// schedule is the cron expression of the backup schedule, e.g. "0 2 * * *"
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// timeZone is the IANA time zone name that the schedule and the retention rules are
+	// interpreted in, default to UTC
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// template is the spec of the backup jobs created by the schedule, an incremental template
+	// without parentBackup is based on the latest backup of the schedule
+	Template BackupJobSpec `json:"template"`
+
+	// concurrencyPolicy specifies how to treat the concurrent executions of the backup job,
+	// default to Forbid
+	// +kubebuilder:default=Forbid
+	// +optional
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// maxIncrementalChain limits the number of incremental backups chained to a full backup when the
+	// template is incremental, a full backup is taken once the limit is reached. Unlimited if not set
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxIncrementalChain *int32 `json:"maxIncrementalChain,omitempty"`
+
+	// suspend stops creating new backup jobs, the running jobs and the retention are not affected
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// retention specifies the backups to keep, the backups of the schedule that are
+	// not kept by any rule are pruned. All the backups are kept if not set
+	// +optional
+	Retention *BackupRetention `json:"retention,omitempty"`
+}
+
+// BackupRetention specifies the backups to keep, a backup is kept if it is kept by any of the rules.
+// The daily, weekly and monthly rules keep the latest backup of each period.
+type BackupRetention struct {
+	// keepLast keeps the last N backups
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepLast *int32 `json:"keepLast,omitempty"`
+
+	// keepDaily keeps the latest backup of each of the last N days that have backups
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepDaily *int32 `json:"keepDaily,omitempty"`
+
+	// keepWeekly keeps the latest backup of each of the last N weeks that have backups
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepWeekly *int32 `json:"keepWeekly,omitempty"`
+
+	// keepMonthly keeps the latest backup of each of the last N months that have backups
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepMonthly *int32 `json:"keepMonthly,omitempty"`
+}
+
+type BackupScheduleStatus struct {
+	ConditionalStatus `json:",inline"`
+
+	// active is the list of the running backup jobs
+	// +optional
+	Active []string `json:"active,omitempty"`
+
+	// lastScheduleTime is the last time that a backup job is scheduled
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// nextScheduleTime is the next time that a backup job will be scheduled
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// lastSuccessfulTime is the last time that a backup job completed
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// lastBackup is the backup produced by the last completed backup job
+	// +optional
+	LastBackup string `json:"lastBackup,omitempty"`
+
+	// lastFailureTime is the last time that a backup job failed
+	// +optional
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+
+	// lastFailedJob is the last failed backup job
+	// +optional
+	LastFailedJob string `json:"lastFailedJob,omitempty"`
+
+	// lastFailureMessage is the failure message of the last failed backup job
+	// +optional
+	LastFailureMessage string `json:"lastFailureMessage,omitempty"`
+}
+
+// A BackupSchedule creates BackupJobs periodically and prunes the backups by the retention rules
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope="Namespaced"
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="Suspend",type="boolean",JSONPath=".spec.suspend"
+// +kubebuilder:printcolumn:name="Last Schedule",type="date",JSONPath=".status.lastScheduleTime"
+// +kubebuilder:printcolumn:name="Last Backup",type="string",JSONPath=".status.lastBackup"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+type BackupSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BackupScheduleSpec `json:"spec"`
+
+	Status BackupScheduleStatus `json:"status,omitempty"`
+}
+
+func (s *BackupSchedule) GetConcurrencyPolicy() ConcurrencyPolicy {
+	if s.Spec.ConcurrencyPolicy == "" {
+		return ConcurrencyPolicyForbid
+	}
+	return s.Spec.ConcurrencyPolicy
+}
+
+func (s *BackupSchedule) GetTimeZone() string {
+	if s.Spec.TimeZone == nil || *s.Spec.TimeZone == "" {
+		return "UTC"
+	}
+	return *s.Spec.TimeZone
+}
+
+func (s *BackupSchedule) SetCondition(condition metav1.Condition) {
+	s.Status.SetCondition(condition)
+}
+
+func (s *BackupSchedule) GetConditions() []metav1.Condition {
+	return s.Status.GetConditions()
+}
+
+// Enabled returns whether any of the retention rules is set
+func (r *BackupRetention) Enabled() bool {
+	return r != nil && (r.KeepLast != nil || r.KeepDaily != nil || r.KeepWeekly != nil || r.KeepMonthly != nil)
+}
+
+// BackupScheduleList contains a list of BackupSchedule
+// +kubebuilder:object:root=true
+type BackupScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BackupSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupSchedule{}, &BackupScheduleList{})
+}
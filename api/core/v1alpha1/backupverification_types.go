@@ -0,0 +1,153 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultVerificationTimeout = 1 * time.Hour
+)
+
+type BackupVerificationSpec struct {
+	// backupName is the backup to verify
+	// +kubebuilder:validation:MinLength=1
+	BackupName string `json:"backupName"`
+
+	// namespace is the namespace that the temporary cluster is created in, must be an isolated namespace
+	// other than the namespace of the BackupVerification so that the temporary cluster never runs beside
+	// the production clusters. The namespace must contain the secrets required by the cluster and the
+	// backup storage
+	// +kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+
+	// clusterTemplate is the spec of the temporary cluster that the backup is restored to.
+	// The shared storage path of the template is suffixed with <namespace>-<name> of the verification
+	// so that each verification is restored to a clean location, the s3RetentionPolicy defaults to
+	// Delete so that the restored data is deleted along with the temporary cluster
+	ClusterTemplate MatrixOneClusterSpec `json:"clusterTemplate"`
+
+	// assertions are the SQL assertions that are run against the restored cluster,
+	// the verification passes if the cluster is ready and all the assertions pass
+	// +optional
+	Assertions []SQLAssertion `json:"assertions,omitempty"`
+
+	// timeout is the maximum time to wait the temporary cluster to be ready, default to 1h
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// SQLAssertion is a SQL query whose result is compared with the expected value
+type SQLAssertion struct {
+	// name of the assertion
+	Name string `json:"name"`
+
+	// query is a SQL query that returns a single value, e.g. "SELECT COUNT(*) FROM db.t"
+	Query string `json:"query"`
+
+	// expected is the expected value of the query result, compared as string, NULL is
+	// represented as "NULL"
+	Expected string `json:"expected"`
+}
+
+type SQLAssertionResult struct {
+	// name of the assertion
+	Name string `json:"name"`
+
+	// passed is whether the query result equals to the expected value
+	Passed bool `json:"passed"`
+
+	// actual is the query result
+	// +optional
+	Actual string `json:"actual,omitempty"`
+
+	// message is the error message of the query
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+type BackupVerificationStatus struct {
+	ConditionalStatus `json:",inline"`
+
+	// phase is the phase of the verification, one of Pending, Running, Completed and Failed.
+	// Completed means the backup is verified, Failed means the backup failed the verification
+	// or the verification cannot be run
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// clusterRef is the temporary cluster in <namespace>/<name> format
+	// +optional
+	ClusterRef string `json:"clusterRef,omitempty"`
+
+	// startTime is the time that the temporary cluster is created
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// completionTime is the time that the verification is completed or failed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// results are the results of the SQL assertions
+	// +optional
+	Results []SQLAssertionResult `json:"results,omitempty"`
+}
+
+// A BackupVerification verifies a Backup by restoring it to a temporary MatrixOneCluster and running
+// SQL assertions against the cluster, the result is recorded on the Backup and the temporary cluster
+// is deleted after the verification
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope="Namespaced"
+// +kubebuilder:printcolumn:name="Backup",type="string",JSONPath=".spec.backupName"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+type BackupVerification struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BackupVerificationSpec `json:"spec"`
+
+	Status BackupVerificationStatus `json:"status,omitempty"`
+}
+
+func (v *BackupVerification) GetTimeout() time.Duration {
+	if v.Spec.Timeout != nil {
+		return v.Spec.Timeout.Duration
+	}
+	return defaultVerificationTimeout
+}
+
+func (v *BackupVerification) SetCondition(condition metav1.Condition) {
+	v.Status.SetCondition(condition)
+}
+
+func (v *BackupVerification) GetConditions() []metav1.Condition {
+	return v.Status.GetConditions()
+}
+
+// BackupVerificationList contains a list of BackupVerification
+// +kubebuilder:object:root=true
+type BackupVerificationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BackupVerification `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BackupVerification{}, &BackupVerificationList{})
+}
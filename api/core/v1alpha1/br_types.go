@@ -16,14 +16,18 @@ package v1alpha1
 
 import (
 	fmt "fmt"
+	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
-	JobPhasePending   = "Pending"
+	JobPhasePending = "Pending"
+	// JobPhaseQueued means the backup job waits for the other backup jobs of the same source to complete
+	JobPhaseQueued    = "Queued"
 	JobPhaseRunning   = "Running"
 	JobPhaseCompleted = "Completed"
 	JobPhaseFailed    = "Failed"
@@ -31,10 +35,100 @@ const (
 
 const (
 	JobConditionTypeEnded = "Ended"
+
+	// BackupConditionTypeCleanup reports the cleanup of the backup data when the backup is deleted
+	BackupConditionTypeCleanup = "Cleanup"
+	// BackupJobConditionTypeCatalog reports whether the backup is published to the catalog of the backup location
+	BackupJobConditionTypeCatalog = "Catalog"
+	// BackupConditionTypeVerified reports the result of the last BackupVerification of the backup
+	BackupConditionTypeVerified = "Verified"
 )
 
 const (
 	defaultTTL = 1 * time.Hour
+
+	defaultRetryBackoff = 30 * time.Second
+	maxRetryBackoff     = 1 * time.Hour
+)
+
+// RetryPolicy specifies how the failed attempts of a backup or restore job are retried. The failures of
+// the br job are retried with the same spec, so a retried backup is written to the same target
+type RetryPolicy struct {
+	// maxAttempts is the max number of the attempts of the job, including the first one, default to 1
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
+
+	// backoff is the delay before the first retry, the delay doubles for each subsequent retry and
+	// is capped at 1h. Default to 30s
+	// +optional
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+}
+
+func (p *RetryPolicy) GetMaxAttempts() int32 {
+	if p == nil || p.MaxAttempts == nil {
+		return 1
+	}
+	return *p.MaxAttempts
+}
+
+// GetBackoff returns the delay before the n-th retry, n starts from 1
+func (p *RetryPolicy) GetBackoff(n int32) time.Duration {
+	backoff := defaultRetryBackoff
+	if p != nil && p.Backoff != nil {
+		backoff = p.Backoff.Duration
+	}
+	for i := int32(1); i < n && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		return maxRetryBackoff
+	}
+	return backoff
+}
+
+// BackupEncryption specifies the key of the client-side encryption of the backup data
+type BackupEncryption struct {
+	// secretRef is the Secret that holds the data keys, each key of the Secret is a key ID and the value
+	// is the data key. The Secret is looked up in the namespace of the backup or restore job. Keys are
+	// rotated by adding a new key ID, the old key IDs must be kept to restore the existing backups
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+
+	// keyID is the key of the data key in the Secret
+	// +kubebuilder:validation:MinLength=1
+	KeyID string `json:"keyID"`
+}
+
+// JobAttemptStatus is the status of the attempts of a backup or restore job
+type JobAttemptStatus struct {
+	// attempts is the number of the started attempts
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// startTime is the time that the first attempt started, the activeDeadline is counted from it
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// nextRetryTime is the time that the failed attempt will be retried
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+}
+
+// DeadlineExceeded returns whether the job has been active for longer than the deadline
+func (s *JobAttemptStatus) DeadlineExceeded(deadline *metav1.Duration, now time.Time) bool {
+	return deadline != nil && s.StartTime != nil && now.Sub(s.StartTime.Time) >= deadline.Duration
+}
+
+// BackupType is the type of the backup
+// +kubebuilder:validation:Enum=Full;Incremental
+type BackupType string
+
+const (
+	// BackupTypeFull is a self-contained backup
+	BackupTypeFull BackupType = "Full"
+	// BackupTypeIncremental only contains the changes since the parent backup, it can only
+	// be restored along with the chain of its parents
+	BackupTypeIncremental BackupType = "Incremental"
 )
 
 // BackupJobSpec specifies the backup job
@@ -47,6 +141,39 @@ type BackupJobSpec struct {
 
 	Target SharedStorageProvider `json:"target"`
 
+	// type is the type of the backup, default to Full
+	// +kubebuilder:default=Full
+	// +optional
+	Type BackupType `json:"type,omitempty"`
+
+	// parentBackup is the name of the Backup that the incremental backup is based on,
+	// the parent must be stored in the same target. Required for incremental backup
+	// unless the job is created by a BackupSchedule, which uses its latest backup as the parent.
+	// +optional
+	ParentBackup string `json:"parentBackup,omitempty"`
+
+	// deletionPolicy is the deletion policy of the backup produced by the job, default to Retain
+	// +kubebuilder:default=Retain
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +optional
+	DeletionPolicy *PVCRetentionPolicy `json:"deletionPolicy,omitempty"`
+
+	// encryption encrypts the backup data with the data key before it is written to the target, the
+	// key is recorded in the meta of the Backup so that the backup can be decrypted on restore. An
+	// incremental backup must be encrypted with the same key as its parent. Not available yet: no released
+	// mo_br version is verified to support the encryption, the job fails with EncryptionUnsupported
+	// +optional
+	Encryption *BackupEncryption `json:"encryption,omitempty"`
+
+	// retryPolicy specifies how the failed attempts of the backup are retried, no retry by default
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+
+	// activeDeadline is the duration that the job may be active for, counted from the start of the first
+	// attempt and excluding the time queued. The job is failed with DeadlineExceeded once exceeded
+	// +optional
+	ActiveDeadline *metav1.Duration `json:"activeDeadline,omitempty"`
+
 	Overlay *Overlay `json:"overlay,omitempty"`
 }
 
@@ -74,17 +201,30 @@ func (r *BackupJob) GetSourceRef() string {
 
 type BackupJobStatus struct {
 	ConditionalStatus `json:",inline"`
+	JobAttemptStatus  `json:",inline"`
 
 	Phase string `json:"phase,omitempty"`
 
 	Backup string `json:"backup,omitempty"`
+
+	// progress is the progress of the backup job, the last reported progress is kept after the job ends
+	// +optional
+	Progress *BackupProgress `json:"progress,omitempty"`
+}
+
+type BackupProgress struct {
+	// elapsed is the time elapsed since the backup started
+	// +optional
+	Elapsed *metav1.Duration `json:"elapsed,omitempty"`
 }
 
 // A BackupJob is a resource that represents an MO backup job
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:scope="Namespaced"
 // +kubebuilder:printcolumn:name="phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Attempts",type="integer",JSONPath=".status.attempts",priority=1
 // +kubebuilder:printcolumn:name="Backup",type="string",JSONPath=".status.backup"
+// +kubebuilder:printcolumn:name="Elapsed",type="string",JSONPath=".status.progress.elapsed"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
 type BackupJob struct {
@@ -98,6 +238,13 @@ type BackupJob struct {
 	Status BackupJobStatus `json:"status,omitempty"`
 }
 
+func (r *BackupJob) GetBackupType() BackupType {
+	if r.Spec.Type == "" {
+		return BackupTypeFull
+	}
+	return r.Spec.Type
+}
+
 func (r *BackupJob) GetTTL() time.Duration {
 	if r.Spec.TTL != nil {
 		return r.Spec.TTL.Duration
@@ -125,21 +272,103 @@ type BackupMeta struct {
 	// clusterRef is the reference to the cluster that produce this backup
 	SourceRef string `json:"sourceRef"`
 
+	// version is the MO version of the source when the backup is taken
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// type is the type of the backup, empty means Full
+	// +optional
+	Type BackupType `json:"type,omitempty"`
+
+	// encryption is the key that encrypts the backup data, nil if the backup is not encrypted
+	// +optional
+	Encryption *BackupEncryption `json:"encryption,omitempty"`
+
+	// chain is the names of the backups that the incremental backup depends on,
+	// ordered from the full base backup to the direct parent
+	// +optional
+	Chain []string `json:"chain,omitempty"`
+
 	Raw string `json:"raw"`
 }
 
+type BackupStatus struct {
+	// conditions of the backup, the cleanup failures of the backup data and the verification
+	// results are reported here
+	ConditionalStatus `json:",inline"`
+
+	// lastVerification is the last BackupVerification of the backup
+	// +optional
+	LastVerification *BackupVerificationRecord `json:"lastVerification,omitempty"`
+}
+
+type BackupVerificationRecord struct {
+	// verification is the BackupVerification in <namespace>/<name> format
+	Verification string `json:"verification"`
+
+	// passed is whether the backup passed the verification
+	Passed bool `json:"passed"`
+
+	// completionTime is the time that the verification is completed
+	CompletionTime metav1.Time `json:"completionTime"`
+}
+
 // A Backup is a resource that represents an MO physical backup
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:scope="Cluster"
 // +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".meta.id"
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".meta.type"
+// +kubebuilder:printcolumn:name="Size",type="string",JSONPath=".meta.size"
 // +kubebuilder:printcolumn:name="At",type="string",format="date-time",JSONPath=".meta.atTime"
 // +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".meta.sourceRef"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".meta.version",priority=1
+// +kubebuilder:printcolumn:name="Key",type="string",JSONPath=".meta.encryption.keyID",priority=1
+// +kubebuilder:printcolumn:name="Deletion",type="string",JSONPath=".deletionPolicy"
+// +kubebuilder:printcolumn:name="Verified",type="boolean",JSONPath=".status.lastVerification.passed"
+// +kubebuilder:subresource:status
 type Backup struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	// Meta is the backupMeta
 	Meta BackupMeta `json:"meta"`
+
+	// deletionPolicy specifies whether the backup data in the storage is deleted
+	// when the Backup is deleted, default to Retain
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +optional
+	DeletionPolicy *PVCRetentionPolicy `json:"deletionPolicy,omitempty"`
+
+	Status BackupStatus `json:"status,omitempty"`
+}
+
+// IsIncremental returns whether the backup depends on other backups
+func (b *Backup) IsIncremental() bool {
+	return b.Meta.Type == BackupTypeIncremental
+}
+
+func (b *Backup) GetDeletionPolicy() PVCRetentionPolicy {
+	if b.DeletionPolicy != nil {
+		return *b.DeletionPolicy
+	}
+	return PVCRetentionPolicyRetain
+}
+
+// GetSourceNamespace returns the namespace of the backup source, which is parsed from the sourceRef
+func (b *Backup) GetSourceNamespace() string {
+	parts := strings.Split(b.Meta.SourceRef, "/")
+	if len(parts) != 3 {
+		return ""
+	}
+	return parts[1]
+}
+
+func (b *Backup) SetCondition(condition metav1.Condition) {
+	b.Status.SetCondition(condition)
+}
+
+func (b *Backup) GetConditions() []metav1.Condition {
+	return b.Status.GetConditions()
 }
 
 type RestoreJobSpec struct {
@@ -149,17 +378,51 @@ type RestoreJobSpec struct {
 	// backupName specifies the backup to restore, must be set UNLESS externalSource is set
 	BackupName string `json:"backupName,omitempty"`
 
-	// optional, restore from an external source, mutual exclusive with backupName
+	// optional, restore from an external source, mutual exclusive with backupName. The backup is looked up
+	// in the catalog of the source that is published by the BackupJobs, so backups taken by another
+	// operator instance can be restored without the Backup objects
 	ExternalSource *SharedStorageProvider `json:"externalSource,omitempty"`
 
-	// target specifies the restore location
-	Target SharedStorageProvider `json:"target"`
+	// externalBackupID is the id of the backup to restore from the externalSource. The latest backup
+	// published to the catalog of a fileSystem source is restored if not set, it is required for an S3
+	// source since the modification times of the catalog entries are lost when the catalog is fetched
+	// +optional
+	ExternalBackupID string `json:"externalBackupID,omitempty"`
+
+	// optional, recover a cluster in place with its PITR to any time in its recoverable window,
+	// mutual exclusive with backupName. A point in time cannot be restored to a new target: replaying
+	// the logs after a backup onto another cluster is not supported by MO, restore a backup with
+	// backupName instead
+	PointInTime *PointInTimeRestore `json:"pointInTime,omitempty"`
+
+	// target specifies the restore location, must be set UNLESS the cluster is recovered in place
+	// with pointInTime, in which case it must not be set
+	Target SharedStorageProvider `json:"target,omitempty"`
+
+	// decryption is the key to decrypt the backup, default to the encryption recorded in the Backup.
+	// Required to restore an encrypted backup from an externalSource. Not available yet: no released
+	// mo_br version is verified to support the encryption, the job fails with DecryptionUnsupported
+	// +optional
+	Decryption *BackupEncryption `json:"decryption,omitempty"`
+
+	// retryPolicy specifies how the failed attempts of the restore are retried, no retry by default
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+
+	// activeDeadline is the duration that the job may be active for, counted from the start of the first
+	// attempt. The job is failed with DeadlineExceeded once exceeded
+	// +optional
+	ActiveDeadline *metav1.Duration `json:"activeDeadline,omitempty"`
 }
 
 type RestoreJobStatus struct {
 	ConditionalStatus `json:",inline"`
+	JobAttemptStatus  `json:",inline"`
 
 	Phase string `json:"phase"`
+
+	// backup is the backup restored by the job
+	Backup string `json:"backup,omitempty"`
 }
 
 // A RestoreJob is a resource that represents an MO restore job
@@ -188,6 +451,11 @@ func (r *RestoreJob) GetTTL() time.Duration {
 	return defaultTTL
 }
 
+// IsInPlacePITR returns whether the job recovers a cluster in place with its PITR
+func (r *RestoreJob) IsInPlacePITR() bool {
+	return r.Spec.PointInTime != nil
+}
+
 func (r *RestoreJob) GetOverlay() *Overlay {
 	return r.Overlay
 }
@@ -88,20 +88,19 @@ func AddBucketFinalizer(ctx context.Context, c client.Client, lsMeta metav1.Obje
 		}
 		return err
 	}
-	s3 := ls.Spec.SharedStorage.S3
-	if s3 == nil {
-		return nil
-	}
-	bucket, err := ClaimedBucket(c, s3)
-	if err != nil {
-		return err
-	}
-	if bucket == nil {
-		return nil
-	}
-	updated := controllerutil.AddFinalizer(bucket, finalizer)
-	if updated {
-		return c.Update(ctx, bucket)
+	for _, s3 := range ls.Spec.S3Storages() {
+		bucket, err := ClaimedBucket(c, s3)
+		if err != nil {
+			return err
+		}
+		if bucket == nil {
+			continue
+		}
+		if controllerutil.AddFinalizer(bucket, finalizer) {
+			if err := c.Update(ctx, bucket); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -119,20 +118,19 @@ func RemoveBucketFinalizer(ctx context.Context, c client.Client, lsMeta metav1.O
 		}
 		return err
 	}
-	s3 := ls.Spec.SharedStorage.S3
-	if s3 == nil {
-		return nil
-	}
-	bucket, err := ClaimedBucket(c, s3)
-	if err != nil {
-		return err
-	}
-	if bucket == nil {
-		return nil
-	}
-	updated := controllerutil.RemoveFinalizer(bucket, finalizer)
-	if updated {
-		return c.Update(ctx, bucket)
+	for _, s3 := range ls.Spec.S3Storages() {
+		bucket, err := ClaimedBucket(c, s3)
+		if err != nil {
+			return err
+		}
+		if bucket == nil {
+			continue
+		}
+		if controllerutil.RemoveFinalizer(bucket, finalizer) {
+			if err := c.Update(ctx, bucket); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -158,16 +156,20 @@ func SyncBucketEverRunningAnn(ctx context.Context, c client.Client, lsMeta metav
 		}
 		return err
 	}
-	s3 := ls.Spec.SharedStorage.S3
-	if s3 == nil {
-		return nil
-	}
-	bucket, err := ClaimedBucket(c, s3)
-	if err != nil || bucket == nil {
-		// skip set annotation if bucket not exist
-		return err
+	for _, s3 := range ls.Spec.S3Storages() {
+		bucket, err := ClaimedBucket(c, s3)
+		if err != nil {
+			return err
+		}
+		if bucket == nil {
+			// skip set annotation if bucket not exist
+			continue
+		}
+		if err := SetBucketEverRunningAnn(ctx, c, bucket); err != nil {
+			return err
+		}
 	}
-	return SetBucketEverRunningAnn(ctx, c, bucket)
+	return nil
 }
 
 func SetBucketEverRunningAnn(ctx context.Context, c client.Client, bucket *BucketClaim) error {
@@ -34,6 +34,8 @@ const (
 // +kubebuilder:resource:shortName=bucket
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.state"
 // +kubebuilder:printcolumn:name="Bind",type="string",JSONPath=".status.bindTo"
+// +kubebuilder:printcolumn:name="Objects",type="integer",JSONPath=".status.usage.objects"
+// +kubebuilder:printcolumn:name="Bytes",type="integer",JSONPath=".status.usage.bytes"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // A BucketClaim is a resource that represents the object storage bucket resource used by a mo cluster
@@ -57,6 +59,14 @@ type BucketClaimSpec struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:Schemaless
 	LogSetTemplate corev1.PodTemplateSpec `json:"logSetSpec"`
+
+	// DryRunCleanup makes the cleanup of a deleting BucketClaim list the objects that would be deleted
+	// into the ConfigMap referred by status.cleanupPreview instead of deleting them. The BucketClaim is
+	// kept until this field is unset, then the data is deleted as usual.
+	// The listing is read from the termination message of the list job, so only the objects that fit in
+	// 4KB are listed, while the summary always counts all the objects.
+	// +optional
+	DryRunCleanup bool `json:"dryRunCleanup,omitempty"`
 }
 
 type BucketClaimStatus struct {
@@ -66,10 +76,36 @@ type BucketClaimStatus struct {
 	// +kubebuilder:validation:Enum=InUse;Released;Deleting
 	State State `json:"state,omitempty"`
 
+	// Usage is the last reported usage of the claimed path, only reported when the bucket is in use
+	// +optional
+	Usage *BucketUsage `json:"usage,omitempty"`
+
+	// UsageAttemptTime is the time when the usage is last attempted to be reported, a failed attempt
+	// is retried in the next report
+	// +optional
+	UsageAttemptTime *metav1.Time `json:"usageAttemptTime,omitempty"`
+
+	// CleanupPreview is the name of the ConfigMap that lists the objects to be deleted, set by the dry-run cleanup.
+	// The listing is truncated to 4KB, which is marked in the summary
+	// +optional
+	CleanupPreview string `json:"cleanupPreview,omitempty"`
+
 	// ConditionalStatus includes condition of deleting s3 resource progress
 	ConditionalStatus `json:",inline"`
 }
 
+// BucketUsage is the usage of the objects under the claimed path
+type BucketUsage struct {
+	// Objects is the number of objects under the claimed path
+	Objects int64 `json:"objects"`
+
+	// Bytes is the total size of the objects under the claimed path
+	Bytes int64 `json:"bytes"`
+
+	// LastUpdateTime is the time when the usage is reported
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+}
+
 //+kubebuilder:object:root=true
 
 // BucketClaimList contains a list of BucketClaim
@@ -69,6 +69,10 @@ type CNSetSpec struct {
 	// +optional
 	NodePort *int32 `json:"nodePort,omitempty"`
 
+	// Exposure exposes the MySQL endpoint of the CNSet through Gateway API routes
+	// +optional
+	Exposure *SQLExposure `json:"exposure,omitempty"`
+
 	// [TP, AP], default to TP
 	// +optional
 	// Deprecated: use labels instead
@@ -291,6 +291,15 @@ func (p *PodSet) GetSemVer() (*semver.Version, bool) {
 	} else {
 		s = getImageTag(p.Image)
 	}
+	return parseSemVer(s)
+}
+
+// GetImageSemVer returns the semantic version in the tag of the image
+func GetImageSemVer(image string) (*semver.Version, bool) {
+	return parseSemVer(getImageTag(image))
+}
+
+func parseSemVer(s string) (*semver.Version, bool) {
 	v, err := semver.ParseTolerant(s)
 	if err != nil {
 		return nil, false
@@ -15,6 +15,8 @@
 package v1alpha1
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,6 +36,10 @@ type S3ProviderType string
 const (
 	S3ProviderTypeAWS   S3ProviderType = "aws"
 	S3ProviderTypeMinIO S3ProviderType = "minio"
+	// S3ProviderTypeGCS accesses Google Cloud Storage through its S3 compatible XML API with HMAC keys
+	S3ProviderTypeGCS S3ProviderType = "gcs"
+	// S3ProviderTypeOSS accesses Aliyun Object Storage Service
+	S3ProviderTypeOSS S3ProviderType = "oss"
 )
 
 const (
@@ -284,6 +290,9 @@ type Volume struct {
 	MemoryCacheSize *resource.Quantity `json:"memoryCacheSize,omitempty"`
 }
 
+// SharedStorageProvider is the storage that the MO fileservice reads and writes. Azure Blob and the
+// S3 compatible services that require a signature other than SigV4 are not supported, since the
+// MO fileservice has no Azure backend and always signs the requests with SigV4
 type SharedStorageProvider struct {
 	// S3 specifies an S3 bucket as the shared storage provider,
 	// mutual-exclusive with other providers.
@@ -315,28 +324,57 @@ type FileSystemProvider struct {
 	// Path the path that the shared fileSystem mounted to
 	// +required
 	Path string `json:"path"`
+
+	// Volume is the volume that provides the shared fileSystem, it is mounted to the path
+	// in the backup and restore jobs. The mo cluster should mount the same fileSystem to
+	// the path as well.
+	// +optional
+	Volume *FileSystemVolume `json:"volume,omitempty"`
+}
+
+// FileSystemVolume is the volume source of a shared fileSystem, exactly one of the sources must be set
+type FileSystemVolume struct {
+	// PersistentVolumeClaim mounts a ReadWriteMany PVC
+	// +optional
+	PersistentVolumeClaim *corev1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+
+	// NFS mounts an NFS export
+	// +optional
+	NFS *corev1.NFSVolumeSource `json:"nfs,omitempty"`
 }
 
 type S3Provider struct {
 	// Path is the s3 storage path in <bucket-name>/<folder> format, e.g. "my-bucket/my-folder"
 	// +required
 	Path string `json:"path"`
-	// S3ProviderType is type of this s3 provider, options: [aws, minio]
-	// default to aws
+	// S3ProviderType is type of this s3 provider, options: [aws, minio, gcs, oss]
+	// default to aws, other S3 compatible services can be accessed as aws with a custom endpoint
 	// +optional
 	Type *S3ProviderType `json:"type,omitempty"`
 	// Region of the bucket
-	// the default region will be inferred from the deployment environment
+	// the default region will be inferred from the deployment environment, required for oss if
+	// the endpoint is not specified
 	// +optional
 	Region string `json:"region,omitempty"`
 	// Endpoint is the endpoint of the S3 compatible service
-	// default to aws S3 well known endpoint
+	// default to the well known endpoint of the provider type
 	// +optional
 	Endpoint string `json:"endpoint,omitempty"`
+	// ForcePathStyle addresses the bucket in the path of the URL instead of the host name, which is
+	// required by many S3 compatible services and the local emulators. Always enabled for minio
+	// +optional
+	ForcePathStyle bool `json:"forcePathStyle,omitempty"`
 	// Credentials for s3, the client will automatically discover credential sources
-	// from the environment if not specified
+	// from the environment if not specified.
+	// The keys of the secret are AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY for aws and minio,
+	// GCS_ACCESS_KEY_ID and GCS_SECRET_ACCESS_KEY (the HMAC key) for gcs,
+	// OSS_ACCESS_KEY_ID and OSS_ACCESS_KEY_SECRET for oss
 	// +optional
 	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+	// WebIdentity grants the access with short-lived credentials exchanged from a service account token,
+	// mutual exclusive with SecretRef, only supported by aws
+	// +optional
+	WebIdentity *S3WebIdentity `json:"webIdentity,omitempty"`
 	// CertificateRef allow specifies custom CA certificate for the object storage
 	CertificateRef *CertificateRef `json:"certificateRef,omitempty"`
 	// +optional
@@ -345,6 +383,36 @@ type S3Provider struct {
 	S3RetentionPolicy *PVCRetentionPolicy `json:"s3RetentionPolicy,omitempty"`
 }
 
+// S3WebIdentity exchanges the projected token of a service account for the short-lived credentials of
+// an IAM role, which is known as IRSA on EKS. The token file is refreshed by the kubelet before it expires
+// and the AWS SDKs and cli reload the credentials from it, so the rotation requires no restart. A container
+// accesses all its S3 storages with one role, the role must be granted access to all of them
+type S3WebIdentity struct {
+	// RoleARN is the ARN of the role to assume
+	// +required
+	RoleARN string `json:"roleARN"`
+	// ServiceAccountName is the service account that the pods accessing the storage run as, the role must
+	// trust the tokens of the service account. Default to the service account of the pods
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// Audience is the audience of the token, default to sts.amazonaws.com
+	// +optional
+	Audience string `json:"audience,omitempty"`
+	// ExpirationSeconds is the lifetime of the token, default to 3600
+	// +kubebuilder:validation:Minimum=600
+	// +optional
+	ExpirationSeconds *int64 `json:"expirationSeconds,omitempty"`
+}
+
+const defaultWebIdentityAudience = "sts.amazonaws.com"
+
+func (w *S3WebIdentity) GetAudience() string {
+	if w.Audience == "" {
+		return defaultWebIdentityAudience
+	}
+	return w.Audience
+}
+
 type CertificateRef struct {
 	// secret name
 	// +required
@@ -362,6 +430,28 @@ func (p *S3Provider) GetProviderType() S3ProviderType {
 	return *p.Type
 }
 
+// GetEndpoint returns the endpoint of the provider, empty if the endpoint should be
+// discovered by the client
+func (p *S3Provider) GetEndpoint() string {
+	if p.Endpoint != "" {
+		return p.Endpoint
+	}
+	switch p.GetProviderType() {
+	case S3ProviderTypeGCS:
+		return "https://storage.googleapis.com"
+	case S3ProviderTypeOSS:
+		if p.Region != "" {
+			return fmt.Sprintf("https://oss-%s.aliyuncs.com", p.Region)
+		}
+	}
+	return ""
+}
+
+// UsePathStyle returns whether the bucket is addressed in the path of the URL
+func (p *S3Provider) UsePathStyle() bool {
+	return p.ForcePathStyle || p.GetProviderType() == S3ProviderTypeMinIO
+}
+
 // LogSetRef reference to an LogSet, either internal or external
 type LogSetRef struct {
 	// The LogSet it depends on, mutual exclusive with ExternalLogSet
@@ -28,6 +28,29 @@ type DNSetSpec struct {
 	CacheVolume *Volume `json:"cacheVolume,omitempty"`
 
 	SharedStorageCache SharedStorageCache `json:"sharedStorageCache,omitempty"`
+
+	// StoreFailureTimeout is the timeout to fail-over the TN Pod after a failure of it is observed
+	// in both kubernetes and HAKeeper
+	// +optional
+	StoreFailureTimeout *metav1.Duration `json:"storeFailureTimeout,omitempty"`
+
+	// FailedPodStrategy controls how to handle failed pod when failover happens, default to Delete
+	// +optional
+	FailedPodStrategy *FailedPodStrategy `json:"failedPodStrategy,omitempty"`
+}
+
+func (d *DNSetSpec) GetFailedPodStrategy() FailedPodStrategy {
+	if d.FailedPodStrategy == nil {
+		return FailedPodStrategyDelete
+	}
+	return *d.FailedPodStrategy
+}
+
+func (d *DNSetSpec) GetStoreFailureTimeout() metav1.Duration {
+	if d.StoreFailureTimeout == nil {
+		return metav1.Duration{Duration: defaultStoreFailureTimeout}
+	}
+	return *d.StoreFailureTimeout
 }
 
 type DNSetStatus struct {
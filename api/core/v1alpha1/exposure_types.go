@@ -0,0 +1,99 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+// HTTPExposure exposes an HTTP endpoint outside the cluster, the networking objects are
+// owned by the operator and removed when the exposure is unset
+type HTTPExposure struct {
+	// Ingress exposes the endpoint through an Ingress
+	// +optional
+	Ingress *IngressExposure `json:"ingress,omitempty"`
+
+	// HTTPRoute exposes the endpoint through a Gateway API HTTPRoute, TLS is
+	// terminated by the listeners of the parent Gateways
+	// +optional
+	HTTPRoute *GatewayRouteExposure `json:"httpRoute,omitempty"`
+}
+
+// SQLExposure exposes the MySQL endpoint outside the cluster, the networking objects are
+// owned by the operator and removed when the exposure is unset.
+// Only TCPRoute is supported: MySQL negotiates TLS in the protocol after the server greeting,
+// so the client never sends a TLS ClientHello with SNI that a TLSRoute could match on
+type SQLExposure struct {
+	// TCPRoute exposes the endpoint through a Gateway API TCPRoute
+	// +optional
+	TCPRoute *GatewayRouteExposure `json:"tcpRoute,omitempty"`
+}
+
+type IngressExposure struct {
+	// ClassName is the IngressClass of the Ingress
+	// +optional
+	ClassName *string `json:"className,omitempty"`
+
+	// Host is the host of the Ingress rule, all hosts are matched if not set
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Path is the path of the Ingress rule, default to /
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// TLSSecretName is the name of the Secret that holds the TLS certificate of the host,
+	// TLS is not enabled if not set
+	// +optional
+	TLSSecretName *string `json:"tlsSecretName,omitempty"`
+
+	// Annotations are the annotations of the Ingress
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type GatewayRouteExposure struct {
+	// ParentRefs are the Gateways that the route attaches to
+	// +kubebuilder:validation:MinItems=1
+	ParentRefs []GatewayParentRef `json:"parentRefs"`
+
+	// Hostnames are the hostnames of the route, only used by HTTPRoute
+	// +optional
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// Annotations are the annotations of the route
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type GatewayParentRef struct {
+	// Name is the name of the Gateway
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Gateway, default to the namespace of the route
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+
+	// SectionName is the name of the listener of the Gateway
+	// +optional
+	SectionName *string `json:"sectionName,omitempty"`
+
+	// Port is the port of the listener of the Gateway
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+}
+
+func (e *IngressExposure) GetPath() string {
+	if e.Path == "" {
+		return "/"
+	}
+	return e.Path
+}
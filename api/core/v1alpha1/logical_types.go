@@ -0,0 +1,200 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LogicalScope is the objects of a logical dump, exactly one of account, databases and tables must be set
+type LogicalScope struct {
+	// account dumps all the user databases of the account, the job must connect as a user of the account
+	// +optional
+	Account string `json:"account,omitempty"`
+
+	// databases are the databases to dump, the names must consist of letters, digits and underscores
+	// +optional
+	Databases []string `json:"databases,omitempty"`
+
+	// tables are the tables to dump, in the form of <database>.<table>, the names must consist of
+	// letters, digits and underscores
+	// +optional
+	Tables []string `json:"tables,omitempty"`
+}
+
+// LogicalEndpoint is the cluster that a logical job connects to
+type LogicalEndpoint struct {
+	// clusterRef is the name of the MatrixOneCluster in the same namespace
+	ClusterRef string `json:"clusterRef"`
+
+	// secretRef is the Secret that holds the username and password to connect as, default to the
+	// credential of the cluster. A user of the account is required to access the objects of an account
+	// other than sys, the username is in the form of <account>#<user>
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+}
+
+type LogicalBackupJobSpec struct {
+	// ttl defines the time to live of the job after completed or failed
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// source is the cluster to dump
+	Source LogicalEndpoint `json:"source"`
+
+	// scope is the objects to dump
+	Scope LogicalScope `json:"scope"`
+
+	// target is the location that the dump is written to. The dump is written to the <namespace>_<name>
+	// directory under the target, one SQL file per database
+	Target SharedStorageProvider `json:"target"`
+
+	Overlay *Overlay `json:"overlay,omitempty"`
+}
+
+// LogicalJobStatus is the status of the logical backup and restore jobs
+type LogicalJobStatus struct {
+	ConditionalStatus `json:",inline"`
+
+	Phase string `json:"phase,omitempty"`
+
+	// location is the location of the dump, which is set once the dump is written
+	// +optional
+	Location *SharedStorageProvider `json:"location,omitempty"`
+
+	// completionTime is the time that the job is completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// A LogicalBackupJob dumps the SQL of an account, databases or tables of a MatrixOneCluster with mo-dump.
+// The dump is portable across clusters and can be loaded by a LogicalRestoreJob
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope="Namespaced"
+// +kubebuilder:printcolumn:name="phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.source.clusterRef"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+type LogicalBackupJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec LogicalBackupJobSpec `json:"spec"`
+
+	Status LogicalJobStatus `json:"status,omitempty"`
+}
+
+func (r *LogicalBackupJob) GetTTL() time.Duration {
+	if r.Spec.TTL != nil {
+		return r.Spec.TTL.Duration
+	}
+	return defaultTTL
+}
+
+func (r *LogicalBackupJob) GetOverlay() *Overlay {
+	return r.Spec.Overlay
+}
+
+func (r *LogicalBackupJob) GetLogicalStatus() *LogicalJobStatus {
+	return &r.Status
+}
+
+func (r *LogicalBackupJob) SetCondition(condition metav1.Condition) {
+	r.Status.SetCondition(condition)
+}
+
+func (r *LogicalBackupJob) GetConditions() []metav1.Condition {
+	return r.Status.GetConditions()
+}
+
+// LogicalBackupJobList contains a list of LogicalBackupJob
+// +kubebuilder:object:root=true
+type LogicalBackupJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LogicalBackupJob `json:"items"`
+}
+
+type LogicalRestoreJobSpec struct {
+	// ttl defines the time to live of the job after completed or failed
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// target is the cluster to load the dump into
+	Target LogicalEndpoint `json:"target"`
+
+	// backupJobName is the LogicalBackupJob in the same namespace whose dump is loaded, mutual exclusive with source
+	// +optional
+	BackupJobName string `json:"backupJobName,omitempty"`
+
+	// source is the directory of the dump to load, mutual exclusive with backupJobName. Each SQL file in the
+	// directory is loaded into the database named by the file
+	// +optional
+	Source *SharedStorageProvider `json:"source,omitempty"`
+
+	Overlay *Overlay `json:"overlay,omitempty"`
+}
+
+// A LogicalRestoreJob loads a logical dump into a MatrixOneCluster
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope="Namespaced"
+// +kubebuilder:printcolumn:name="phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.target.clusterRef"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+type LogicalRestoreJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec LogicalRestoreJobSpec `json:"spec"`
+
+	Status LogicalJobStatus `json:"status,omitempty"`
+}
+
+func (r *LogicalRestoreJob) GetTTL() time.Duration {
+	if r.Spec.TTL != nil {
+		return r.Spec.TTL.Duration
+	}
+	return defaultTTL
+}
+
+func (r *LogicalRestoreJob) GetOverlay() *Overlay {
+	return r.Spec.Overlay
+}
+
+func (r *LogicalRestoreJob) GetLogicalStatus() *LogicalJobStatus {
+	return &r.Status
+}
+
+func (r *LogicalRestoreJob) SetCondition(condition metav1.Condition) {
+	r.Status.SetCondition(condition)
+}
+
+func (r *LogicalRestoreJob) GetConditions() []metav1.Condition {
+	return r.Status.GetConditions()
+}
+
+// LogicalRestoreJobList contains a list of LogicalRestoreJob
+// +kubebuilder:object:root=true
+type LogicalRestoreJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LogicalRestoreJob `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LogicalBackupJob{}, &LogicalBackupJobList{}, &LogicalRestoreJob{}, &LogicalRestoreJobList{})
+}
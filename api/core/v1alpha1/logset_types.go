@@ -48,6 +48,14 @@ type LogSetSpec struct {
 	// +required
 	SharedStorage SharedStorageProvider `json:"sharedStorage"`
 
+	// ETLStorage is a dedicated storage for the ETL data (metrics, traces and logs of the cluster),
+	// so that the data can go to a cheaper bucket or a filesystem with its own retention.
+	// An S3 ETL storage is claimed separately from the shared storage and must be accessed with the
+	// same credentials and certificates. The S3 ETL storage cannot be added, changed or removed after
+	// the LogSet is created. Default to the etl directory in the shared storage
+	// +optional
+	ETLStorage *SharedStorageProvider `json:"etlStorage,omitempty"`
+
 	// InitialConfig is the initial configuration of HAKeeper
 	// InitialConfig is immutable
 	// +optional
@@ -99,8 +107,13 @@ func (l *LogSetSpec) GetS3RetentionPolicy() *PVCRetentionPolicy {
 	if l.SharedStorage.S3 == nil {
 		return nil
 	}
-	if l.SharedStorage.S3.S3RetentionPolicy != nil {
-		p := *l.SharedStorage.S3.S3RetentionPolicy
+	return l.S3RetentionPolicyOf(l.SharedStorage.S3)
+}
+
+// S3RetentionPolicyOf returns the retention policy of an S3 storage of the LogSet
+func (l *LogSetSpec) S3RetentionPolicyOf(s3 *S3Provider) *PVCRetentionPolicy {
+	if s3.S3RetentionPolicy != nil {
+		p := *s3.S3RetentionPolicy
 		return &p
 	}
 	// inherit from pvc policy if only pvc is set (e.g. old objects without s3RetentionPolicy)
@@ -112,6 +125,26 @@ func (l *LogSetSpec) GetS3RetentionPolicy() *PVCRetentionPolicy {
 	return &defaultPolicy
 }
 
+// GetETLS3 returns the dedicated S3 ETL storage, nil if the ETL data is not stored in a dedicated S3 storage
+func (l *LogSetSpec) GetETLS3() *S3Provider {
+	if l.ETLStorage == nil {
+		return nil
+	}
+	return l.ETLStorage.S3
+}
+
+// S3Storages returns the S3 storages of the LogSet, each of them is claimed by a BucketClaim
+func (l *LogSetSpec) S3Storages() []*S3Provider {
+	var storages []*S3Provider
+	if l.SharedStorage.S3 != nil {
+		storages = append(storages, l.SharedStorage.S3)
+	}
+	if s3 := l.GetETLS3(); s3 != nil {
+		storages = append(storages, s3)
+	}
+	return storages
+}
+
 type InitialConfig struct {
 	// LogShards is the initial number of log shards,
 	// cannot be tuned after cluster creation currently.
@@ -142,16 +175,72 @@ type InitialConfig struct {
 	RestoreFrom *string `json:"restoreFrom,omitempty"`
 }
 
-// TODO: figure out what status should be exposed
 type LogSetStatus struct {
 	ConditionalStatus `json:",inline"`
 	FailoverStatus    `json:",inline"`
 
 	Discovery *LogSetDiscovery `json:"discovery,omitempty"`
-	// TODO(aylei): collect LogShards, DNShards and HAKeeper status from HAKeeper
-	// HAKeeper          *HAKeeperStatus  `json:"haKeeper,omitempty"`
-	// LogShards
-	// DNShards
+
+	// HAKeeper is the cluster state observed from HAKeeper
+	// +optional
+	HAKeeper *HAKeeperStatus `json:"haKeeper,omitempty"`
+}
+
+// HAKeeperStatus is the cluster state reported by HAKeeper
+type HAKeeperStatus struct {
+	// Term is the raft term of the HAKeeper shard
+	Term uint64 `json:"term,omitempty"`
+
+	// LogShards is the log shards known to HAKeeper
+	// +optional
+	LogShards []LogShardStatus `json:"logShards,omitempty"`
+
+	// TNStoreCount is the number of TN stores registered in HAKeeper
+	TNStoreCount int32 `json:"tnStoreCount"`
+
+	// CNStoreCount is the number of CN stores registered in HAKeeper
+	CNStoreCount int32 `json:"cnStoreCount"`
+
+	// DownStores is the stores that HAKeeper considers down
+	// +optional
+	DownStores []HAKeeperStore `json:"downStores,omitempty"`
+}
+
+type LogShardStatus struct {
+	ShardID uint64 `json:"shardID"`
+
+	// Replicas is the voting replicas of the shard at current epoch
+	// +optional
+	Replicas []LogReplicaStatus `json:"replicas,omitempty"`
+
+	// LeaderID is the ReplicaID of the leader replica, 0 means the leader is unknown
+	LeaderID uint64 `json:"leaderID,omitempty"`
+
+	// Epoch is the epoch of the shard, replicas of the shard can change across epochs
+	Epoch uint64 `json:"epoch,omitempty"`
+
+	// Term is the raft term of the shard
+	Term uint64 `json:"term,omitempty"`
+}
+
+type LogReplicaStatus struct {
+	ReplicaID uint64 `json:"replicaID"`
+	// StoreID is the UUID of the log store that hosts the replica
+	StoreID string `json:"storeID"`
+}
+
+type HAKeeperStoreType string
+
+const (
+	HAKeeperStoreTypeLog HAKeeperStoreType = "Log"
+	HAKeeperStoreTypeTN  HAKeeperStoreType = "TN"
+	HAKeeperStoreTypeCN  HAKeeperStoreType = "CN"
+)
+
+type HAKeeperStore struct {
+	UUID           string            `json:"uuid"`
+	Type           HAKeeperStoreType `json:"type"`
+	ServiceAddress string            `json:"serviceAddress,omitempty"`
 }
 
 type LogSetDiscovery struct {
@@ -86,6 +86,11 @@ type MatrixOneClusterSpec struct {
 	// +immutable
 	RestoreFrom *string `json:"restoreFrom,omitempty"`
 
+	// pitr enables the point-in-time recovery of the cluster, the cluster can be restored in place
+	// to any time within the range by a RestoreJob
+	// +optional
+	PITR *PITRSpec `json:"pitr,omitempty"`
+
 	// +optional
 	// MetricReaderEnabled enables metric reader for operator and other apps to query
 	// metric from MO cluster
@@ -105,6 +110,11 @@ type MatrixOneClusterSpec struct {
 	MemoryFsSize *resource.Quantity `json:"memoryFsSize,omitempty"`
 }
 
+// IsRestoring returns whether the cluster is initialized from a backup
+func (m *MatrixOneCluster) IsRestoring() bool {
+	return m.Spec.RestoreFrom != nil
+}
+
 func (m *MatrixOneCluster) GetMetricReaderEnabled() bool {
 	if m.Spec.MetricReaderEnabled == nil {
 		return false
@@ -159,6 +169,9 @@ type MatrixOneClusterStatus struct {
 
 	Host string `json:"host,omitempty"`
 	Port int    `json:"port,omitempty"`
+
+	// PITR is the status of the point-in-time recovery of the cluster
+	PITR *PITRStatus `json:"pitr,omitempty"`
 }
 
 type ClusterMetrics struct {
@@ -0,0 +1,111 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PITRUnit is the time unit of the PITR range
+// +kubebuilder:validation:Enum=h;d;mo;y
+type PITRUnit string
+
+const (
+	PITRUnitHour  PITRUnit = "h"
+	PITRUnitDay   PITRUnit = "d"
+	PITRUnitMonth PITRUnit = "mo"
+	PITRUnitYear  PITRUnit = "y"
+)
+
+// PITRSpec configures the point-in-time recovery (PITR) of the cluster, MO retains the
+// history data within the range so that the cluster can be restored to any time in it
+type PITRSpec struct {
+	// range is the length of the retained history in the unit
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Range int32 `json:"range"`
+
+	// unit is the time unit of the range, default to d
+	// +kubebuilder:default=d
+	// +optional
+	Unit PITRUnit `json:"unit,omitempty"`
+}
+
+func (p *PITRSpec) GetUnit() PITRUnit {
+	if p.Unit == "" {
+		return PITRUnitDay
+	}
+	return p.Unit
+}
+
+type PITRStatus struct {
+	// name is the name of the PITR created in the cluster
+	Name string `json:"name"`
+
+	// range is the applied range of the PITR
+	Range int32 `json:"range"`
+
+	// unit is the applied unit of the PITR range
+	Unit PITRUnit `json:"unit"`
+
+	// createdTime is the time that the PITR is created, the cluster cannot be recovered
+	// to a time before it
+	CreatedTime metav1.Time `json:"createdTime"`
+
+	// recoverableFrom is the earliest time that the cluster can be recovered to
+	// +optional
+	RecoverableFrom *metav1.Time `json:"recoverableFrom,omitempty"`
+
+	// recoverableTo is the latest time that the cluster can be recovered to when the status is
+	// refreshed, the cluster can always be recovered to a time before now
+	// +optional
+	RecoverableTo *metav1.Time `json:"recoverableTo,omitempty"`
+}
+
+// EarliestRecoverableTime returns the earliest time that the cluster can be recovered to at now
+func (s *PITRStatus) EarliestRecoverableTime(now time.Time) time.Time {
+	earliest := now.Add(-PITRDuration(s.Range, s.Unit))
+	if earliest.Before(s.CreatedTime.Time) {
+		return s.CreatedTime.Time
+	}
+	return earliest
+}
+
+// PITRDuration returns the duration of the PITR range, a month is counted as 30 days and a year
+// as 365 days
+func PITRDuration(r int32, unit PITRUnit) time.Duration {
+	day := 24 * time.Hour
+	switch unit {
+	case PITRUnitHour:
+		return time.Duration(r) * time.Hour
+	case PITRUnitMonth:
+		return time.Duration(r) * 30 * day
+	case PITRUnitYear:
+		return time.Duration(r) * 365 * day
+	default:
+		return time.Duration(r) * day
+	}
+}
+
+// PointInTimeRestore specifies a point in time of a cluster to restore
+type PointInTimeRestore struct {
+	// clusterRef is the name of the MatrixOneCluster in the same namespace whose data is restored
+	ClusterRef string `json:"clusterRef"`
+
+	// time is the point in time to restore the data to
+	Time metav1.Time `json:"time"`
+}
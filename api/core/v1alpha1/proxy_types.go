@@ -15,11 +15,19 @@
 package v1alpha1
 
 import (
+	"time"
+
 	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	defaultProxyDrainTimeout      = 10 * time.Minute
+	defaultProxyScaleDownCooldown = 5 * time.Minute
+	defaultProxyScaleTolerance    = 10
+)
+
 type ProxySetSpec struct {
 	PodSet `json:",inline"`
 
@@ -38,11 +46,94 @@ type ProxySetSpec struct {
 	// +optional
 	NodePort *int32 `json:"nodePort,omitempty"`
 
+	// Exposure exposes the MySQL endpoint of the proxy through Gateway API routes
+	// +optional
+	Exposure *SQLExposure `json:"exposure,omitempty"`
+
 	MinReadySeconds int32 `json:"minReadySeconds,omitempty"`
 
-	// WaitPluginAddr is the address of the plugin to wait for
+	// WaitPluginAddr is the address of the plugin to wait for, prefer Plugin
+	// which also manages the plugin container. Mutual exclusive with Plugin.
 	// +optional
 	WaitPluginAddr *string `json:"waitPluginAddr,omitempty"`
+
+	// Plugin is the proxy plugin that runs as a sidecar container of the proxy,
+	// the proxy connects to the plugin through the local loopback address and the
+	// plugin readiness is part of the proxy pod readiness.
+	// +optional
+	Plugin *ProxyPlugin `json:"plugin,omitempty"`
+
+	// DrainTimeout enables connection draining before a proxy pod is restarted or deleted.
+	// The pod is removed from the service endpoints first, and then the restart or deletion
+	// waits until the connections on the pod reach zero or the timeout expires. The proxy
+	// stops at once on SIGTERM, so a preStop hook holds the SIGTERM until the connections
+	// are drained as well, which also covers the pods evicted or deleted directly.
+	// Draining is disabled if not set.
+	// +optional
+	DrainTimeout *metav1.Duration `json:"drainTimeout,omitempty"`
+
+	// Autoscaling scales the proxy replicas on the client connections, the replicas
+	// in spec is used as the initial replicas if autoscaling is enabled.
+	// Scale-in always drains the connections of the removed pods, DrainTimeout defaults to
+	// 10 minutes if autoscaling is enabled.
+	// +optional
+	Autoscaling *ProxyAutoscaling `json:"autoscaling,omitempty"`
+}
+
+type ProxyPlugin struct {
+	// Image is the image of the plugin container
+	Image string `json:"image"`
+
+	// Port is the port that the plugin listens on
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Resources is the resource requirement of the plugin container
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Config is the config of the plugin, the config file is mounted to the plugin
+	// container and the path is exposed by the PLUGIN_CONFIG_FILE env
+	// +optional
+	Config *TomlConfig `json:"config,omitempty"`
+
+	// Env is the extra environment variables of the plugin container
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Timeout is the rpc timeout when the proxy calls the plugin
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+type ProxyAutoscaling struct {
+	// MinReplicas is the lower limit of the replicas
+	// +kubebuilder:validation:Minimum=1
+	MinReplicas int32 `json:"minReplicas"`
+
+	// MaxReplicas is the upper limit of the replicas
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// TargetConnectionsPerReplica is the target number of client connections per proxy replica
+	// +kubebuilder:validation:Minimum=1
+	TargetConnectionsPerReplica int32 `json:"targetConnectionsPerReplica"`
+
+	// Tolerance is the percentage that the connections per replica can deviate from the target
+	// before scaling, default to 10
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Tolerance *int32 `json:"tolerance,omitempty"`
+
+	// ScaleUpCooldown is the minimum interval between the last scaling and a scale-up, default to 0
+	// +optional
+	ScaleUpCooldown *metav1.Duration `json:"scaleUpCooldown,omitempty"`
+
+	// ScaleDownCooldown is the minimum interval between the last scaling and a scale-in, default to 5 minutes
+	// +optional
+	ScaleDownCooldown *metav1.Duration `json:"scaleDownCooldown,omitempty"`
 }
 
 type ProxySetStatus struct {
@@ -50,6 +141,35 @@ type ProxySetStatus struct {
 
 	Host string `json:"host,omitempty"`
 	Port int    `json:"port,omitempty"`
+
+	// Draining is the proxy pods that are draining connections
+	// +optional
+	Draining []ProxyDrainingStatus `json:"draining,omitempty"`
+
+	// Autoscaling is the status of the autoscaling
+	// +optional
+	Autoscaling *ProxyAutoscalingStatus `json:"autoscaling,omitempty"`
+}
+
+type ProxyAutoscalingStatus struct {
+	// Replicas is the replicas decided by the autoscaling
+	Replicas int32 `json:"replicas"`
+	// Connections is the total client connections of the proxy pods observed in the last evaluation
+	Connections int32 `json:"connections"`
+	// LastScaleTime is the last time the replicas is changed by the autoscaling
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+}
+
+type ProxyDrainingStatus struct {
+	PodName   string      `json:"podName"`
+	StartTime metav1.Time `json:"startTime"`
+	// InitialConnections is the connections on the pod when the draining started
+	InitialConnections int32 `json:"initialConnections"`
+	// Connections is the remaining connections on the pod, -1 if the connections cannot be counted
+	Connections int32 `json:"connections"`
+	// DrainedConnections is the connections that have been drained from the pod
+	DrainedConnections int32 `json:"drainedConnections"`
 }
 
 type ProxySetDeps struct {
@@ -75,6 +195,57 @@ type ProxySet struct {
 	Status ProxySetStatus `json:"status,omitempty"`
 }
 
+func (s *ProxySetSpec) DrainEnabled() bool {
+	return s.DrainTimeout != nil || s.Autoscaling != nil
+}
+
+func (s *ProxySetSpec) GetDrainTimeout() time.Duration {
+	if s.DrainTimeout == nil {
+		return defaultProxyDrainTimeout
+	}
+	return s.DrainTimeout.Duration
+}
+
+// GetReplicas returns the desired replicas of the proxy, which is decided by the autoscaling if enabled
+func (s *ProxySet) GetReplicas() int32 {
+	as := s.Spec.Autoscaling
+	if as == nil {
+		return s.Spec.Replicas
+	}
+	replicas := s.Spec.Replicas
+	if s.Status.Autoscaling != nil {
+		replicas = s.Status.Autoscaling.Replicas
+	}
+	if replicas > as.MaxReplicas {
+		replicas = as.MaxReplicas
+	}
+	if replicas < as.MinReplicas {
+		replicas = as.MinReplicas
+	}
+	return replicas
+}
+
+func (a *ProxyAutoscaling) GetTolerance() int32 {
+	if a.Tolerance == nil {
+		return defaultProxyScaleTolerance
+	}
+	return *a.Tolerance
+}
+
+func (a *ProxyAutoscaling) GetScaleUpCooldown() time.Duration {
+	if a.ScaleUpCooldown == nil {
+		return 0
+	}
+	return a.ScaleUpCooldown.Duration
+}
+
+func (a *ProxyAutoscaling) GetScaleDownCooldown() time.Duration {
+	if a.ScaleDownCooldown == nil {
+		return defaultProxyScaleDownCooldown
+	}
+	return a.ScaleDownCooldown.Duration
+}
+
 func (s *ProxySet) GetServiceType() corev1.ServiceType {
 	if s.Spec.ServiceType == "" {
 		return corev1.ServiceTypeClusterIP
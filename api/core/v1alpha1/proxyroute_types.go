@@ -0,0 +1,178 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// RouteLabelAccount is the CN store label that the proxy matches against the account of the session
+	RouteLabelAccount = "account"
+	// RouteLabelUser is the CN store label that the proxy matches against the user of the session
+	RouteLabelUser = "user"
+
+	defaultRouteBackendWeight int32 = 100
+)
+
+type ProxyRouteSpec struct {
+	// Rules are the routing rules, the labels of all matched rules are merged if a CN store
+	// is selected by multiple rules
+	Rules []ProxyRouteRule `json:"rules"`
+}
+
+type ProxyRouteRule struct {
+	// Name is the name of the rule, must be unique in the ProxyRoute
+	Name string `json:"name"`
+
+	// Match selects the sessions that the rule applies to
+	Match ProxyRouteMatch `json:"match"`
+
+	// Backends are the CN groups that serve the matched sessions
+	Backends []ProxyRouteBackend `json:"backends"`
+
+	// Fallbacks are the CN groups that serve the matched sessions when no CN store
+	// of the backends is available
+	// +optional
+	Fallbacks []ProxyRouteBackend `json:"fallbacks,omitempty"`
+}
+
+type ProxyRouteMatch struct {
+	// Accounts are the accounts of the sessions
+	// +optional
+	Accounts []string `json:"accounts,omitempty"`
+
+	// Users are the users of the sessions
+	// +optional
+	Users []string `json:"users,omitempty"`
+
+	// Attributes are the connection attributes of the sessions
+	// +optional
+	Attributes []CNLabel `json:"attributes,omitempty"`
+}
+
+type ProxyRouteBackend struct {
+	// Selector selects the CN groups (CNSets) by the CN labels in CNSet spec,
+	// a CNSet is selected if it has all the labels in the selector
+	Selector []CNLabel `json:"selector"`
+
+	// Weight is the percentage of the CN stores in each selected CN group that
+	// serve the matched sessions, default to 100
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+}
+
+func (b *ProxyRouteBackend) GetWeight() int32 {
+	if b.Weight == nil {
+		return defaultRouteBackendWeight
+	}
+	return *b.Weight
+}
+
+// Labels returns the CN store labels that route the matched sessions
+func (m *ProxyRouteMatch) Labels() []CNLabel {
+	var labels []CNLabel
+	if len(m.Accounts) > 0 {
+		labels = append(labels, CNLabel{Key: RouteLabelAccount, Values: m.Accounts})
+	}
+	if len(m.Users) > 0 {
+		labels = append(labels, CNLabel{Key: RouteLabelUser, Values: m.Users})
+	}
+	return append(labels, m.Attributes...)
+}
+
+type ProxyRouteStatus struct {
+	ConditionalStatus `json:",inline"`
+
+	// Rules is the status of each rule
+	// +optional
+	Rules []ProxyRouteRuleStatus `json:"rules,omitempty"`
+}
+
+type ProxyRouteRuleStatus struct {
+	Name string `json:"name"`
+
+	// Fallback is true if the sessions are served by the fallback CN groups
+	Fallback bool `json:"fallback,omitempty"`
+
+	// Stores are the CN stores that currently match the rule
+	// +optional
+	Stores []ProxyRouteStore `json:"stores,omitempty"`
+}
+
+type ProxyRouteStore struct {
+	UUID    string `json:"uuid"`
+	PodName string `json:"podName"`
+	CNSet   string `json:"cnSet"`
+}
+
+type ProxyRouteDeps struct {
+	LogSetRef `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// A ProxyRoute routes the sessions of the proxy to CN groups by pushing the route labels to the CN stores
+// +kubebuilder:subresource:status
+type ProxyRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec is the desired state of ProxyRoute
+	Spec ProxyRouteSpec `json:"spec"`
+	// Deps is the dependencies of ProxyRoute
+	Deps ProxyRouteDeps `json:"deps,omitempty"`
+
+	Status ProxyRouteStatus `json:"status,omitempty"`
+}
+
+func (r *ProxyRoute) GetDependencies() []recon.Dependency {
+	var deps []recon.Dependency
+	if r.Deps.LogSet != nil {
+		deps = append(deps, &recon.ObjectDependency[*LogSet]{
+			ObjectRef: r.Deps.LogSet,
+			ReadyFunc: func(l *LogSet) bool {
+				return recon.IsReady(&l.Status)
+			},
+		})
+	}
+	return deps
+}
+
+func (r *ProxyRoute) SetCondition(condition metav1.Condition) {
+	r.Status.SetCondition(condition)
+}
+
+func (r *ProxyRoute) GetConditions() []metav1.Condition {
+	return r.Status.GetConditions()
+}
+
+//+kubebuilder:object:root=true
+
+// ProxyRouteList contains a list of ProxyRoute
+type ProxyRouteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProxyRoute `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ProxyRoute{}, &ProxyRouteList{})
+}
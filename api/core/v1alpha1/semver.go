@@ -25,6 +25,14 @@ const (
 
 	MOFeatureDiscoveryFixed    MOFeature = "DiscoveryFixed"
 	MOFeatureShardingMigration MOFeature = "ShardingMigration"
+
+	// MOFeatureMultiTN is running multiple TN stores in a cluster, no released MO version is verified
+	// to support it yet, so it has no entry in featureVersions
+	MOFeatureMultiTN MOFeature = "MultiTN"
+
+	// MOFeatureBackupEncryption is the client-side encryption of mo_br, which reads the data key from a file.
+	// No released mo_br version is verified to support it yet, so it has no entry in featureVersions
+	MOFeatureBackupEncryption MOFeature = "BackupEncryption"
 )
 
 var (
@@ -49,6 +49,9 @@ func TestHasMOFeature(t *testing.T) {
 	g.Expect(HasMOFeature(mustParse("v1.2.0-alpha.1"), MOFeatureLockMigration)).To(BeTrue())
 	g.Expect(HasMOFeature(mustParse("v1.2.2-woraround-something-else"), MOFeatureLockMigration)).To(BeTrue())
 	g.Expect(HasMOFeature(mustParse("2.0.1"), MOFeatureLockMigration)).To(BeTrue())
+	g.Expect(HasMOFeature(mustParse("2.0.1"), MOFeatureMultiTN)).To(BeFalse())
+	g.Expect(HasMOFeature(mustParse("2.1.0"), MOFeatureMultiTN)).To(BeFalse())
+	g.Expect(HasMOFeature(mustParse("2.2.0"), MOFeatureBackupEncryption)).To(BeFalse())
 	featureVersions["dummy"] = []semver.Version{mustParse("1.2.3")}
 	t.Cleanup(func() { delete(featureVersions, "dummy") })
 	g.Expect(HasMOFeature(mustParse("v1.2.3"), "dummy")).To(BeTrue())
@@ -90,6 +93,8 @@ func TestHasMOFeature_OtherFeaturesNotExtendedTo3x(t *testing.T) {
 		MOFeatureSessionSource,
 		MOFeatureLockMigration,
 		MOFeatureShardingMigration,
+		MOFeatureMultiTN,
+		MOFeatureBackupEncryption,
 	}
 	for _, f := range unverifiedOn3x {
 		g.Expect(HasMOFeature(mustParse("3.0.0"), f)).To(BeFalse(), "feature %s should not yet be enabled on MO 3.x", f)
@@ -0,0 +1,221 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SnapshotLevel is the level of the objects that a snapshot is taken for
+// +kubebuilder:validation:Enum=cluster;account;database;table
+type SnapshotLevel string
+
+const (
+	SnapshotLevelCluster  SnapshotLevel = "cluster"
+	SnapshotLevelAccount  SnapshotLevel = "account"
+	SnapshotLevelDatabase SnapshotLevel = "database"
+	SnapshotLevelTable    SnapshotLevel = "table"
+)
+
+// SnapshotObject is the object that a snapshot is taken for. The database and table level
+// objects are in the sys account, which is the account the operator connects as
+type SnapshotObject struct {
+	// level is the level of the object
+	Level SnapshotLevel `json:"level"`
+
+	// objectName is the name of the object, which is the account name for account level, the database
+	// name for database level and <database>.<table> for table level. Must be empty for cluster level.
+	// The names consist of at most 64 letters, digits and underscores
+	// +optional
+	ObjectName string `json:"objectName,omitempty"`
+}
+
+// Database returns the database of the database or table level object
+func (o *SnapshotObject) Database() string {
+	db, _, _ := strings.Cut(o.ObjectName, ".")
+	return db
+}
+
+// Table returns the table of the table level object
+func (o *SnapshotObject) Table() string {
+	_, table, _ := strings.Cut(o.ObjectName, ".")
+	return table
+}
+
+type SnapshotScheduleSpec struct {
+	// clusterRef is the name of the MatrixOneCluster in the same namespace to take snapshots of
+	ClusterRef string `json:"clusterRef"`
+
+	SnapshotObject `json:",inline"`
+
+	// schedule is the cron expression of the snapshot schedule, e.g. "0 * * * *"
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// timeZone is the IANA time zone name that the schedule is interpreted in, default to UTC
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// retention is the number of the latest snapshots to keep, the older snapshots are dropped
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=7
+	// +optional
+	Retention int32 `json:"retention,omitempty"`
+
+	// suspend stops taking new snapshots, the retention is not affected
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+type SnapshotScheduleStatus struct {
+	ConditionalStatus `json:",inline"`
+
+	// lastScheduleTime is the last time that a snapshot is scheduled
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// nextScheduleTime is the next time that a snapshot will be scheduled
+	// +optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+
+	// snapshots are the live snapshots taken by the schedule, ordered from the oldest to the latest
+	// +optional
+	Snapshots []SnapshotRecord `json:"snapshots,omitempty"`
+}
+
+type SnapshotRecord struct {
+	// name is the name of the snapshot in the cluster
+	Name string `json:"name"`
+
+	// createdTime is the time that the snapshot is taken
+	CreatedTime metav1.Time `json:"createdTime"`
+}
+
+// A SnapshotSchedule takes SQL snapshots of a MatrixOneCluster periodically and keeps the latest ones.
+// The snapshots are dropped when the SnapshotSchedule is deleted.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope="Namespaced"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterRef"
+// +kubebuilder:printcolumn:name="Level",type="string",JSONPath=".spec.level"
+// +kubebuilder:printcolumn:name="Object",type="string",JSONPath=".spec.objectName"
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="Last Schedule",type="date",JSONPath=".status.lastScheduleTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+type SnapshotSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SnapshotScheduleSpec `json:"spec"`
+
+	Status SnapshotScheduleStatus `json:"status,omitempty"`
+}
+
+func (s *SnapshotSchedule) GetTimeZone() string {
+	if s.Spec.TimeZone == nil || *s.Spec.TimeZone == "" {
+		return "UTC"
+	}
+	return *s.Spec.TimeZone
+}
+
+func (s *SnapshotSchedule) GetRetention() int {
+	if s.Spec.Retention <= 0 {
+		return 7
+	}
+	return int(s.Spec.Retention)
+}
+
+func (s *SnapshotSchedule) SetCondition(condition metav1.Condition) {
+	s.Status.SetCondition(condition)
+}
+
+func (s *SnapshotSchedule) GetConditions() []metav1.Condition {
+	return s.Status.GetConditions()
+}
+
+// SnapshotScheduleList contains a list of SnapshotSchedule
+// +kubebuilder:object:root=true
+type SnapshotScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SnapshotSchedule `json:"items"`
+}
+
+type SnapshotRestoreSpec struct {
+	// clusterRef is the name of the MatrixOneCluster in the same namespace to restore
+	ClusterRef string `json:"clusterRef"`
+
+	// snapshot is the name of the snapshot in the cluster to restore from, which consists of at most
+	// 64 letters, digits and underscores
+	// +kubebuilder:validation:MinLength=1
+	Snapshot string `json:"snapshot"`
+
+	// the object to restore, which must be covered by the snapshot
+	SnapshotObject `json:",inline"`
+
+	// toAccount restores the object to another account instead of the original one, not
+	// supported for cluster level
+	// +optional
+	ToAccount string `json:"toAccount,omitempty"`
+}
+
+type SnapshotRestoreStatus struct {
+	ConditionalStatus `json:",inline"`
+
+	Phase string `json:"phase,omitempty"`
+
+	// completionTime is the time that the restore is completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// A SnapshotRestore restores the objects of a MatrixOneCluster from a SQL snapshot in place
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope="Namespaced"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterRef"
+// +kubebuilder:printcolumn:name="Snapshot",type="string",JSONPath=".spec.snapshot"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+type SnapshotRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec SnapshotRestoreSpec `json:"spec"`
+
+	Status SnapshotRestoreStatus `json:"status,omitempty"`
+}
+
+func (r *SnapshotRestore) SetCondition(condition metav1.Condition) {
+	r.Status.SetCondition(condition)
+}
+
+func (r *SnapshotRestore) GetConditions() []metav1.Condition {
+	return r.Status.GetConditions()
+}
+
+// SnapshotRestoreList contains a list of SnapshotRestore
+// +kubebuilder:object:root=true
+type SnapshotRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SnapshotRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SnapshotSchedule{}, &SnapshotScheduleList{}, &SnapshotRestore{}, &SnapshotRestoreList{})
+}
@@ -0,0 +1,220 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ClusterQuiesceAnno stops the writes of a MatrixOneCluster by scaling its CN and TN in, the CNs are
+	// drained if the value is QuiesceCN, and the TN is paused as well if the value is QuiesceAll
+	ClusterQuiesceAnno = "matrixorigin.io/quiesce"
+
+	QuiesceCN  = "cn"
+	QuiesceAll = "all"
+)
+
+const (
+	// StorageMigrationPhasePreCopying means the objects are copied while the cluster keeps serving
+	StorageMigrationPhasePreCopying = "PreCopying"
+	// StorageMigrationPhaseQuiescing means the CNs are being drained and the TN is being paused
+	StorageMigrationPhaseQuiescing = "Quiescing"
+	// StorageMigrationPhaseSyncing means the objects written after the pre-copy are copied
+	StorageMigrationPhaseSyncing = "Syncing"
+	// StorageMigrationPhaseVerifying means the checksums of the objects in the target are verified
+	StorageMigrationPhaseVerifying = "Verifying"
+	// StorageMigrationPhaseSwitching means the cluster is rolling to the target storage
+	StorageMigrationPhaseSwitching = "Switching"
+)
+
+type StorageMigrationSpec struct {
+	// clusterRef is the name of the MatrixOneCluster in the same namespace whose shared storage is migrated
+	ClusterRef string `json:"clusterRef"`
+
+	// target is the storage that the shared storage is migrated to, only S3 compatible storages are supported.
+	// The objects under the path of the shared storage are copied to the path of the target, and the
+	// cluster accesses the target with the credentials configured here after the migration
+	Target SharedStorageProvider `json:"target"`
+
+	// parallelism is the number of objects that are copied or checksummed concurrently, default to 8
+	// +optional
+	Parallelism *int32 `json:"parallelism,omitempty"`
+
+	Overlay *Overlay `json:"overlay,omitempty"`
+}
+
+// StorageMigrationCopyStatus is the result of a copy pass
+type StorageMigrationCopyStatus struct {
+	// objects is the number of objects in the source when the pass started
+	Objects int64 `json:"objects"`
+
+	// bytes is the total size of the objects in the source when the pass started
+	Bytes int64 `json:"bytes"`
+
+	// copied is the number of objects that are missing in the target and copied by the pass
+	Copied int64 `json:"copied"`
+
+	// removed is the number of objects that are removed from the source after the pre-copy, and thus
+	// removed from the target by the final sync
+	// +optional
+	Removed int64 `json:"removed,omitempty"`
+
+	// checksummed is the number of objects whose ETags cannot be compared and thus whose contents are
+	// checksummed by the pre-copy, these objects are not checksummed again by the verification unless
+	// they are changed. Only the objects that fit in a ConfigMap are kept for the verification
+	// +optional
+	Checksummed int64 `json:"checksummed,omitempty"`
+
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// StorageMigrationProgress is the progress of the running stage
+type StorageMigrationProgress struct {
+	// stage is the running stage, one of precopy, sync and verify
+	Stage string `json:"stage"`
+
+	// step is the running step of the stage, one of copy, remove and checksum
+	Step string `json:"step"`
+
+	// done is the number of objects that are processed by the step
+	Done int64 `json:"done"`
+
+	// total is the number of objects to be processed by the step
+	Total int64 `json:"total"`
+
+	// +optional
+	UpdateTime *metav1.Time `json:"updateTime,omitempty"`
+}
+
+// StorageMigrationVerification is the result of verifying the target against the source
+type StorageMigrationVerification struct {
+	// objects is the number of objects that are verified
+	Objects int64 `json:"objects"`
+
+	// bytes is the total size of the objects that are verified
+	Bytes int64 `json:"bytes"`
+
+	// etagMatched is the number of objects whose ETags are identical in the source and the target
+	ETagMatched int64 `json:"etagMatched"`
+
+	// preverified is the number of objects whose ETags cannot be compared but whose contents are
+	// checksummed by the pre-copy and not changed since then
+	// +optional
+	Preverified int64 `json:"preverified,omitempty"`
+
+	// checksummed is the number of objects whose ETags cannot be compared, e.g. the objects uploaded in
+	// multiple parts, and thus the MD5 checksums of the contents are compared
+	Checksummed int64 `json:"checksummed"`
+
+	// mismatched is the number of objects that are missing, extra, or different in the target
+	Mismatched int64 `json:"mismatched"`
+
+	// mismatchedKeys are the first keys of the mismatched objects relative to the path
+	// +optional
+	MismatchedKeys []string `json:"mismatchedKeys,omitempty"`
+
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+type StorageMigrationStatus struct {
+	ConditionalStatus `json:",inline"`
+
+	Phase string `json:"phase,omitempty"`
+
+	// source is the shared storage of the cluster before the migration
+	// +optional
+	Source *SharedStorageProvider `json:"source,omitempty"`
+
+	// preCopy is the result of copying the objects while the cluster is serving
+	// +optional
+	PreCopy *StorageMigrationCopyStatus `json:"preCopy,omitempty"`
+
+	// finalSync is the result of copying the objects after the writes are quiesced
+	// +optional
+	FinalSync *StorageMigrationCopyStatus `json:"finalSync,omitempty"`
+
+	// verification is the result of verifying the checksums of the objects in the target
+	// +optional
+	Verification *StorageMigrationVerification `json:"verification,omitempty"`
+
+	// progress is the progress of the running stage, reported periodically
+	// +optional
+	Progress *StorageMigrationProgress `json:"progress,omitempty"`
+
+	// quiesceTime is the time that the cluster starts quiescing, the writes resume when the cluster
+	// is rolled to the target or the migration fails
+	// +optional
+	QuiesceTime *metav1.Time `json:"quiesceTime,omitempty"`
+
+	// releasedBucketClaim is the BucketClaim of the source that is released after the migration
+	// +optional
+	ReleasedBucketClaim string `json:"releasedBucketClaim,omitempty"`
+
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// A StorageMigration moves the shared storage of a MatrixOneCluster to another bucket, region or provider.
+// The objects are copied while the cluster is serving, then the writes are quiesced briefly to copy the
+// remaining objects and verify the checksums, and the cluster is rolled to the new storage at last
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope="Namespaced"
+// +kubebuilder:printcolumn:name="phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterRef"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+type StorageMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec StorageMigrationSpec `json:"spec"`
+
+	Status StorageMigrationStatus `json:"status,omitempty"`
+}
+
+func (m *StorageMigration) GetParallelism() int32 {
+	if m.Spec.Parallelism != nil {
+		return *m.Spec.Parallelism
+	}
+	return 8
+}
+
+// Ended returns whether the migration is completed or failed
+func (m *StorageMigration) Ended() bool {
+	return m.Status.Phase == JobPhaseCompleted || m.Status.Phase == JobPhaseFailed
+}
+
+func (m *StorageMigration) SetCondition(condition metav1.Condition) {
+	m.Status.SetCondition(condition)
+}
+
+func (m *StorageMigration) GetConditions() []metav1.Condition {
+	return m.Status.GetConditions()
+}
+
+// StorageMigrationList contains a list of StorageMigration
+// +kubebuilder:object:root=true
+type StorageMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StorageMigration `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&StorageMigration{}, &StorageMigrationList{})
+}
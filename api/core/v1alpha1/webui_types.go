@@ -29,6 +29,10 @@ type WebUISpec struct {
 	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
 	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
 
+	// Exposure exposes the WebUI through an Ingress or a Gateway API HTTPRoute
+	// +optional
+	Exposure *HTTPExposure `json:"exposure,omitempty"`
+
 	// UpdateStrategy rolling update strategy
 	// +optional
 	UpdateStrategy *RollingUpdateStrategy `json:"updateStrategy,omitempty"`
@@ -31,6 +31,12 @@ func (in *Backup) DeepCopyInto(out *Backup) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Meta.DeepCopyInto(&out.Meta)
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(PVCRetentionPolicy)
+		**out = **in
+	}
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Backup.
@@ -51,6 +57,22 @@ func (in *Backup) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupEncryption) DeepCopyInto(out *BackupEncryption) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupEncryption.
+func (in *BackupEncryption) DeepCopy() *BackupEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupJob) DeepCopyInto(out *BackupJob) {
 	*out = *in
@@ -120,6 +142,26 @@ func (in *BackupJobSpec) DeepCopyInto(out *BackupJobSpec) {
 	}
 	in.Source.DeepCopyInto(&out.Source)
 	in.Target.DeepCopyInto(&out.Target)
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(PVCRetentionPolicy)
+		**out = **in
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(BackupEncryption)
+		**out = **in
+	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ActiveDeadline != nil {
+		in, out := &in.ActiveDeadline, &out.ActiveDeadline
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	if in.Overlay != nil {
 		in, out := &in.Overlay, &out.Overlay
 		*out = new(Overlay)
@@ -141,6 +183,12 @@ func (in *BackupJobSpec) DeepCopy() *BackupJobSpec {
 func (in *BackupJobStatus) DeepCopyInto(out *BackupJobStatus) {
 	*out = *in
 	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
+	in.JobAttemptStatus.DeepCopyInto(&out.JobAttemptStatus)
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(BackupProgress)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupJobStatus.
@@ -196,6 +244,16 @@ func (in *BackupMeta) DeepCopyInto(out *BackupMeta) {
 	}
 	in.AtTime.DeepCopyInto(&out.AtTime)
 	in.CompleteTime.DeepCopyInto(&out.CompleteTime)
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(BackupEncryption)
+		**out = **in
+	}
+	if in.Chain != nil {
+		in, out := &in.Chain, &out.Chain
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupMeta.
@@ -209,37 +267,27 @@ func (in *BackupMeta) DeepCopy() *BackupMeta {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BackupSource) DeepCopyInto(out *BackupSource) {
+func (in *BackupProgress) DeepCopyInto(out *BackupProgress) {
 	*out = *in
-	if in.ClusterRef != nil {
-		in, out := &in.ClusterRef, &out.ClusterRef
-		*out = new(string)
-		**out = **in
-	}
-	if in.CNSetRef != nil {
-		in, out := &in.CNSetRef, &out.CNSetRef
-		*out = new(string)
-		**out = **in
-	}
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(string)
+	if in.Elapsed != nil {
+		in, out := &in.Elapsed, &out.Elapsed
+		*out = new(v1.Duration)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSource.
-func (in *BackupSource) DeepCopy() *BackupSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupProgress.
+func (in *BackupProgress) DeepCopy() *BackupProgress {
 	if in == nil {
 		return nil
 	}
-	out := new(BackupSource)
+	out := new(BackupProgress)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BucketClaim) DeepCopyInto(out *BucketClaim) {
+func (in *BackupRepository) DeepCopyInto(out *BackupRepository) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -247,18 +295,18 @@ func (in *BucketClaim) DeepCopyInto(out *BucketClaim) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketClaim.
-func (in *BucketClaim) DeepCopy() *BucketClaim {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRepository.
+func (in *BackupRepository) DeepCopy() *BackupRepository {
 	if in == nil {
 		return nil
 	}
-	out := new(BucketClaim)
+	out := new(BackupRepository)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *BucketClaim) DeepCopyObject() runtime.Object {
+func (in *BackupRepository) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -266,31 +314,31 @@ func (in *BucketClaim) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BucketClaimList) DeepCopyInto(out *BucketClaimList) {
+func (in *BackupRepositoryList) DeepCopyInto(out *BackupRepositoryList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]BucketClaim, len(*in))
+		*out = make([]BackupRepository, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketClaimList.
-func (in *BucketClaimList) DeepCopy() *BucketClaimList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRepositoryList.
+func (in *BackupRepositoryList) DeepCopy() *BackupRepositoryList {
 	if in == nil {
 		return nil
 	}
-	out := new(BucketClaimList)
+	out := new(BackupRepositoryList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *BucketClaimList) DeepCopyObject() runtime.Object {
+func (in *BackupRepositoryList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -298,103 +346,88 @@ func (in *BucketClaimList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BucketClaimSpec) DeepCopyInto(out *BucketClaimSpec) {
+func (in *BackupRepositorySpec) DeepCopyInto(out *BackupRepositorySpec) {
 	*out = *in
-	if in.S3 != nil {
-		in, out := &in.S3, &out.S3
-		*out = new(S3Provider)
+	in.Source.DeepCopyInto(&out.Source)
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Overlay != nil {
+		in, out := &in.Overlay, &out.Overlay
+		*out = new(Overlay)
 		(*in).DeepCopyInto(*out)
 	}
-	in.LogSetTemplate.DeepCopyInto(&out.LogSetTemplate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketClaimSpec.
-func (in *BucketClaimSpec) DeepCopy() *BucketClaimSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRepositorySpec.
+func (in *BackupRepositorySpec) DeepCopy() *BackupRepositorySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(BucketClaimSpec)
+	out := new(BackupRepositorySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BucketClaimStatus) DeepCopyInto(out *BucketClaimStatus) {
+func (in *BackupRepositoryStatus) DeepCopyInto(out *BackupRepositoryStatus) {
 	*out = *in
 	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
+	if in.LastScanTime != nil {
+		in, out := &in.LastScanTime, &out.LastScanTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketClaimStatus.
-func (in *BucketClaimStatus) DeepCopy() *BucketClaimStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRepositoryStatus.
+func (in *BackupRepositoryStatus) DeepCopy() *BackupRepositoryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(BucketClaimStatus)
+	out := new(BackupRepositoryStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNClaim) DeepCopyInto(out *CNClaim) {
+func (in *BackupRetention) DeepCopyInto(out *BackupRetention) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNClaim.
-func (in *CNClaim) DeepCopy() *CNClaim {
-	if in == nil {
-		return nil
+	if in.KeepLast != nil {
+		in, out := &in.KeepLast, &out.KeepLast
+		*out = new(int32)
+		**out = **in
 	}
-	out := new(CNClaim)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CNClaim) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+	if in.KeepDaily != nil {
+		in, out := &in.KeepDaily, &out.KeepDaily
+		*out = new(int32)
+		**out = **in
 	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNClaimList) DeepCopyInto(out *CNClaimList) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]CNClaim, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.KeepWeekly != nil {
+		in, out := &in.KeepWeekly, &out.KeepWeekly
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeepMonthly != nil {
+		in, out := &in.KeepMonthly, &out.KeepMonthly
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNClaimList.
-func (in *CNClaimList) DeepCopy() *CNClaimList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupRetention.
+func (in *BackupRetention) DeepCopy() *BackupRetention {
 	if in == nil {
 		return nil
 	}
-	out := new(CNClaimList)
+	out := new(BackupRetention)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CNClaimList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNClaimSet) DeepCopyInto(out *CNClaimSet) {
+func (in *BackupSchedule) DeepCopyInto(out *BackupSchedule) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -402,18 +435,18 @@ func (in *CNClaimSet) DeepCopyInto(out *CNClaimSet) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNClaimSet.
-func (in *CNClaimSet) DeepCopy() *CNClaimSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSchedule.
+func (in *BackupSchedule) DeepCopy() *BackupSchedule {
 	if in == nil {
 		return nil
 	}
-	out := new(CNClaimSet)
+	out := new(BackupSchedule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CNClaimSet) DeepCopyObject() runtime.Object {
+func (in *BackupSchedule) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -421,31 +454,31 @@ func (in *CNClaimSet) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNClaimSetList) DeepCopyInto(out *CNClaimSetList) {
+func (in *BackupScheduleList) DeepCopyInto(out *BackupScheduleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CNClaimSet, len(*in))
+		*out = make([]BackupSchedule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNClaimSetList.
-func (in *CNClaimSetList) DeepCopy() *CNClaimSetList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupScheduleList.
+func (in *BackupScheduleList) DeepCopy() *BackupScheduleList {
 	if in == nil {
 		return nil
 	}
-	out := new(CNClaimSetList)
+	out := new(BackupScheduleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CNClaimSetList) DeepCopyObject() runtime.Object {
+func (in *BackupScheduleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -453,227 +486,275 @@ func (in *CNClaimSetList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNClaimSetSpec) DeepCopyInto(out *CNClaimSetSpec) {
+func (in *BackupScheduleSpec) DeepCopyInto(out *BackupScheduleSpec) {
 	*out = *in
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
 	in.Template.DeepCopyInto(&out.Template)
-	if in.Selector != nil {
-		in, out := &in.Selector, &out.Selector
-		*out = new(v1.LabelSelector)
+	if in.MaxIncrementalChain != nil {
+		in, out := &in.MaxIncrementalChain, &out.MaxIncrementalChain
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(BackupRetention)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNClaimSetSpec.
-func (in *CNClaimSetSpec) DeepCopy() *CNClaimSetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupScheduleSpec.
+func (in *BackupScheduleSpec) DeepCopy() *BackupScheduleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CNClaimSetSpec)
+	out := new(BackupScheduleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNClaimSetStatus) DeepCopyInto(out *CNClaimSetStatus) {
+func (in *BackupScheduleStatus) DeepCopyInto(out *BackupScheduleStatus) {
 	*out = *in
-	if in.Claims != nil {
-		in, out := &in.Claims, &out.Claims
-		*out = make([]CNClaimStatus, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNClaimSetStatus.
-func (in *CNClaimSetStatus) DeepCopy() *CNClaimSetStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupScheduleStatus.
+func (in *BackupScheduleStatus) DeepCopy() *BackupScheduleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CNClaimSetStatus)
+	out := new(BackupScheduleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNClaimSpec) DeepCopyInto(out *CNClaimSpec) {
+func (in *BackupSource) DeepCopyInto(out *BackupSource) {
 	*out = *in
-	out.ClaimPodRef = in.ClaimPodRef
-	if in.SourcePod != nil {
-		in, out := &in.SourcePod, &out.SourcePod
-		*out = new(ClaimPodRef)
+	if in.ClusterRef != nil {
+		in, out := &in.ClusterRef, &out.ClusterRef
+		*out = new(string)
 		**out = **in
 	}
-	if in.Selector != nil {
-		in, out := &in.Selector, &out.Selector
-		*out = new(v1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.CNLabels != nil {
-		in, out := &in.CNLabels, &out.CNLabels
-		*out = make([]CNLabel, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.OwnerName != nil {
-		in, out := &in.OwnerName, &out.OwnerName
+	if in.CNSetRef != nil {
+		in, out := &in.CNSetRef, &out.CNSetRef
 		*out = new(string)
 		**out = **in
 	}
-	if in.AdditionalPodLabels != nil {
-		in, out := &in.AdditionalPodLabels, &out.AdditionalPodLabels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(string)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNClaimSpec.
-func (in *CNClaimSpec) DeepCopy() *CNClaimSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSource.
+func (in *BackupSource) DeepCopy() *BackupSource {
 	if in == nil {
 		return nil
 	}
-	out := new(CNClaimSpec)
+	out := new(BackupSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNClaimStatus) DeepCopyInto(out *CNClaimStatus) {
+func (in *BackupStatus) DeepCopyInto(out *BackupStatus) {
 	*out = *in
-	in.Store.DeepCopyInto(&out.Store)
-	if in.BoundTime != nil {
-		in, out := &in.BoundTime, &out.BoundTime
-		*out = (*in).DeepCopy()
-	}
-	if in.Migrate != nil {
-		in, out := &in.Migrate, &out.Migrate
-		*out = new(MigrateStatus)
-		**out = **in
+	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
+	if in.LastVerification != nil {
+		in, out := &in.LastVerification, &out.LastVerification
+		*out = new(BackupVerificationRecord)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNClaimStatus.
-func (in *CNClaimStatus) DeepCopy() *CNClaimStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStatus.
+func (in *BackupStatus) DeepCopy() *BackupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CNClaimStatus)
+	out := new(BackupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNClaimTemplate) DeepCopyInto(out *CNClaimTemplate) {
+func (in *BackupVerification) DeepCopyInto(out *BackupVerification) {
 	*out = *in
-	in.EmbeddedMetadata.DeepCopyInto(&out.EmbeddedMetadata)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNClaimTemplate.
-func (in *CNClaimTemplate) DeepCopy() *CNClaimTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerification.
+func (in *BackupVerification) DeepCopy() *BackupVerification {
 	if in == nil {
 		return nil
 	}
-	out := new(CNClaimTemplate)
+	out := new(BackupVerification)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNGroup) DeepCopyInto(out *CNGroup) {
-	*out = *in
-	in.CNSetSpec.DeepCopyInto(&out.CNSetSpec)
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupVerification) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNGroup.
-func (in *CNGroup) DeepCopy() *CNGroup {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupVerificationList) DeepCopyInto(out *BackupVerificationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BackupVerification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationList.
+func (in *BackupVerificationList) DeepCopy() *BackupVerificationList {
 	if in == nil {
 		return nil
 	}
-	out := new(CNGroup)
+	out := new(BackupVerificationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupVerificationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNGroupStatus) DeepCopyInto(out *CNGroupStatus) {
+func (in *BackupVerificationRecord) DeepCopyInto(out *BackupVerificationRecord) {
 	*out = *in
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNGroupStatus.
-func (in *CNGroupStatus) DeepCopy() *CNGroupStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationRecord.
+func (in *BackupVerificationRecord) DeepCopy() *BackupVerificationRecord {
 	if in == nil {
 		return nil
 	}
-	out := new(CNGroupStatus)
+	out := new(BackupVerificationRecord)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNGroupsStatus) DeepCopyInto(out *CNGroupsStatus) {
+func (in *BackupVerificationSpec) DeepCopyInto(out *BackupVerificationSpec) {
 	*out = *in
-	if in.Groups != nil {
-		in, out := &in.Groups, &out.Groups
-		*out = make([]CNGroupStatus, len(*in))
+	in.ClusterTemplate.DeepCopyInto(&out.ClusterTemplate)
+	if in.Assertions != nil {
+		in, out := &in.Assertions, &out.Assertions
+		*out = make([]SQLAssertion, len(*in))
 		copy(*out, *in)
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNGroupsStatus.
-func (in *CNGroupsStatus) DeepCopy() *CNGroupsStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationSpec.
+func (in *BackupVerificationSpec) DeepCopy() *BackupVerificationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CNGroupsStatus)
+	out := new(BackupVerificationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNLabel) DeepCopyInto(out *CNLabel) {
+func (in *BackupVerificationStatus) DeepCopyInto(out *BackupVerificationStatus) {
 	*out = *in
-	if in.Values != nil {
-		in, out := &in.Values, &out.Values
-		*out = make([]string, len(*in))
+	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]SQLAssertionResult, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNLabel.
-func (in *CNLabel) DeepCopy() *CNLabel {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupVerificationStatus.
+func (in *BackupVerificationStatus) DeepCopy() *BackupVerificationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CNLabel)
+	out := new(BackupVerificationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNPool) DeepCopyInto(out *CNPool) {
+func (in *BucketClaim) DeepCopyInto(out *BucketClaim) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNPool.
-func (in *CNPool) DeepCopy() *CNPool {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketClaim.
+func (in *BucketClaim) DeepCopy() *BucketClaim {
 	if in == nil {
 		return nil
 	}
-	out := new(CNPool)
+	out := new(BucketClaim)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CNPool) DeepCopyObject() runtime.Object {
+func (in *BucketClaim) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -681,31 +762,31 @@ func (in *CNPool) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNPoolList) DeepCopyInto(out *CNPoolList) {
+func (in *BucketClaimList) DeepCopyInto(out *BucketClaimList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CNPool, len(*in))
+		*out = make([]BucketClaim, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNPoolList.
-func (in *CNPoolList) DeepCopy() *CNPoolList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketClaimList.
+func (in *BucketClaimList) DeepCopy() *BucketClaimList {
 	if in == nil {
 		return nil
 	}
-	out := new(CNPoolList)
+	out := new(BucketClaimList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CNPoolList) DeepCopyObject() runtime.Object {
+func (in *BucketClaimList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -713,67 +794,88 @@ func (in *CNPoolList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNPoolSpec) DeepCopyInto(out *CNPoolSpec) {
+func (in *BucketClaimSpec) DeepCopyInto(out *BucketClaimSpec) {
 	*out = *in
-	in.Template.DeepCopyInto(&out.Template)
-	if in.PodLabels != nil {
-		in, out := &in.PodLabels, &out.PodLabels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3Provider)
+		(*in).DeepCopyInto(*out)
 	}
-	in.Deps.DeepCopyInto(&out.Deps)
-	in.Strategy.DeepCopyInto(&out.Strategy)
+	in.LogSetTemplate.DeepCopyInto(&out.LogSetTemplate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNPoolSpec.
-func (in *CNPoolSpec) DeepCopy() *CNPoolSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketClaimSpec.
+func (in *BucketClaimSpec) DeepCopy() *BucketClaimSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CNPoolSpec)
+	out := new(BucketClaimSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNPoolStatus) DeepCopyInto(out *CNPoolStatus) {
+func (in *BucketClaimStatus) DeepCopyInto(out *BucketClaimStatus) {
+	*out = *in
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(BucketUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UsageAttemptTime != nil {
+		in, out := &in.UsageAttemptTime, &out.UsageAttemptTime
+		*out = (*in).DeepCopy()
+	}
+	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketClaimStatus.
+func (in *BucketClaimStatus) DeepCopy() *BucketClaimStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketClaimStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketUsage) DeepCopyInto(out *BucketUsage) {
 	*out = *in
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNPoolStatus.
-func (in *CNPoolStatus) DeepCopy() *CNPoolStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketUsage.
+func (in *BucketUsage) DeepCopy() *BucketUsage {
 	if in == nil {
 		return nil
 	}
-	out := new(CNPoolStatus)
+	out := new(BucketUsage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNSet) DeepCopyInto(out *CNSet) {
+func (in *CNClaim) DeepCopyInto(out *CNClaim) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Deps.DeepCopyInto(&out.Deps)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNSet.
-func (in *CNSet) DeepCopy() *CNSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNClaim.
+func (in *CNClaim) DeepCopy() *CNClaim {
 	if in == nil {
 		return nil
 	}
-	out := new(CNSet)
+	out := new(CNClaim)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CNSet) DeepCopyObject() runtime.Object {
+func (in *CNClaim) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -781,52 +883,58 @@ func (in *CNSet) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNSetDeps) DeepCopyInto(out *CNSetDeps) {
+func (in *CNClaimList) DeepCopyInto(out *CNClaimList) {
 	*out = *in
-	in.LogSetRef.DeepCopyInto(&out.LogSetRef)
-	if in.DNSet != nil {
-		in, out := &in.DNSet, &out.DNSet
-		*out = new(DNSet)
-		(*in).DeepCopyInto(*out)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CNClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNSetDeps.
-func (in *CNSetDeps) DeepCopy() *CNSetDeps {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNClaimList.
+func (in *CNClaimList) DeepCopy() *CNClaimList {
 	if in == nil {
 		return nil
 	}
-	out := new(CNSetDeps)
+	out := new(CNClaimList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CNClaimList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNSetList) DeepCopyInto(out *CNSetList) {
+func (in *CNClaimSet) DeepCopyInto(out *CNClaimSet) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]CNSet, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNSetList.
-func (in *CNSetList) DeepCopy() *CNSetList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNClaimSet.
+func (in *CNClaimSet) DeepCopy() *CNClaimSet {
 	if in == nil {
 		return nil
 	}
-	out := new(CNSetList)
+	out := new(CNClaimSet)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CNSetList) DeepCopyObject() runtime.Object {
+func (in *CNClaimSet) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -834,246 +942,259 @@ func (in *CNSetList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNSetSpec) DeepCopyInto(out *CNSetSpec) {
+func (in *CNClaimSetList) DeepCopyInto(out *CNClaimSetList) {
 	*out = *in
-	in.PodSet.DeepCopyInto(&out.PodSet)
-	in.ConfigThatChangeCNSpec.DeepCopyInto(&out.ConfigThatChangeCNSpec)
-	if in.ServiceAnnotations != nil {
-		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.NodePort != nil {
-		in, out := &in.NodePort, &out.NodePort
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make([]CNLabel, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CNClaimSet, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	in.ScalingConfig.DeepCopyInto(&out.ScalingConfig)
-	in.UpdateStrategy.DeepCopyInto(&out.UpdateStrategy)
-	if in.TerminationPolicy != nil {
-		in, out := &in.TerminationPolicy, &out.TerminationPolicy
-		*out = new(CNSetTerminationPolicy)
-		**out = **in
-	}
-	if in.PodManagementPolicy != nil {
-		in, out := &in.PodManagementPolicy, &out.PodManagementPolicy
-		*out = new(string)
-		**out = **in
-	}
-	if in.PodsToDelete != nil {
-		in, out := &in.PodsToDelete, &out.PodsToDelete
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.ReusePVC != nil {
-		in, out := &in.ReusePVC, &out.ReusePVC
-		*out = new(bool)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNSetSpec.
-func (in *CNSetSpec) DeepCopy() *CNSetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNClaimSetList.
+func (in *CNClaimSetList) DeepCopy() *CNClaimSetList {
 	if in == nil {
 		return nil
 	}
-	out := new(CNSetSpec)
+	out := new(CNClaimSetList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CNClaimSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNSetStatus) DeepCopyInto(out *CNSetStatus) {
+func (in *CNClaimSetSpec) DeepCopyInto(out *CNClaimSetSpec) {
 	*out = *in
-	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
-	if in.Stores != nil {
-		in, out := &in.Stores, &out.Stores
-		*out = make([]CNStore, len(*in))
-		copy(*out, *in)
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNSetStatus.
-func (in *CNSetStatus) DeepCopy() *CNSetStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNClaimSetSpec.
+func (in *CNClaimSetSpec) DeepCopy() *CNClaimSetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CNSetStatus)
+	out := new(CNClaimSetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNStore) DeepCopyInto(out *CNStore) {
+func (in *CNClaimSetStatus) DeepCopyInto(out *CNClaimSetStatus) {
 	*out = *in
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make([]CNClaimStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNStore.
-func (in *CNStore) DeepCopy() *CNStore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNClaimSetStatus.
+func (in *CNClaimSetStatus) DeepCopy() *CNClaimSetStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CNStore)
+	out := new(CNClaimSetStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CNStoreStatus) DeepCopyInto(out *CNStoreStatus) {
+func (in *CNClaimSpec) DeepCopyInto(out *CNClaimSpec) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
+	out.ClaimPodRef = in.ClaimPodRef
+	if in.SourcePod != nil {
+		in, out := &in.SourcePod, &out.SourcePod
+		*out = new(ClaimPodRef)
+		**out = **in
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CNLabels != nil {
+		in, out := &in.CNLabels, &out.CNLabels
 		*out = make([]CNLabel, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.OwnerName != nil {
+		in, out := &in.OwnerName, &out.OwnerName
+		*out = new(string)
+		**out = **in
+	}
+	if in.AdditionalPodLabels != nil {
+		in, out := &in.AdditionalPodLabels, &out.AdditionalPodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNClaimSpec.
+func (in *CNClaimSpec) DeepCopy() *CNClaimSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CNClaimSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CNClaimStatus) DeepCopyInto(out *CNClaimStatus) {
+	*out = *in
+	in.Store.DeepCopyInto(&out.Store)
 	if in.BoundTime != nil {
 		in, out := &in.BoundTime, &out.BoundTime
 		*out = (*in).DeepCopy()
 	}
+	if in.Migrate != nil {
+		in, out := &in.Migrate, &out.Migrate
+		*out = new(MigrateStatus)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNStoreStatus.
-func (in *CNStoreStatus) DeepCopy() *CNStoreStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNClaimStatus.
+func (in *CNClaimStatus) DeepCopy() *CNClaimStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CNStoreStatus)
+	out := new(CNClaimStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificateRef) DeepCopyInto(out *CertificateRef) {
+func (in *CNClaimTemplate) DeepCopyInto(out *CNClaimTemplate) {
 	*out = *in
-	if in.Files != nil {
-		in, out := &in.Files, &out.Files
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	in.EmbeddedMetadata.DeepCopyInto(&out.EmbeddedMetadata)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRef.
-func (in *CertificateRef) DeepCopy() *CertificateRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNClaimTemplate.
+func (in *CNClaimTemplate) DeepCopy() *CNClaimTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(CertificateRef)
+	out := new(CNClaimTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClaimPodRef) DeepCopyInto(out *ClaimPodRef) {
+func (in *CNGroup) DeepCopyInto(out *CNGroup) {
 	*out = *in
+	in.CNSetSpec.DeepCopyInto(&out.CNSetSpec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClaimPodRef.
-func (in *ClaimPodRef) DeepCopy() *ClaimPodRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNGroup.
+func (in *CNGroup) DeepCopy() *CNGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(ClaimPodRef)
+	out := new(CNGroup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterMetrics) DeepCopyInto(out *ClusterMetrics) {
+func (in *CNGroupStatus) DeepCopyInto(out *CNGroupStatus) {
 	*out = *in
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMetrics.
-func (in *ClusterMetrics) DeepCopy() *ClusterMetrics {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNGroupStatus.
+func (in *CNGroupStatus) DeepCopy() *CNGroupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterMetrics)
+	out := new(CNGroupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConditionalStatus) DeepCopyInto(out *ConditionalStatus) {
+func (in *CNGroupsStatus) DeepCopyInto(out *CNGroupsStatus) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]CNGroupStatus, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionalStatus.
-func (in *ConditionalStatus) DeepCopy() *ConditionalStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNGroupsStatus.
+func (in *CNGroupsStatus) DeepCopy() *CNGroupsStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ConditionalStatus)
+	out := new(CNGroupsStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConfigThatChangeCNSpec) DeepCopyInto(out *ConfigThatChangeCNSpec) {
+func (in *CNLabel) DeepCopyInto(out *CNLabel) {
 	*out = *in
-	if in.CacheVolume != nil {
-		in, out := &in.CacheVolume, &out.CacheVolume
-		*out = new(Volume)
-		(*in).DeepCopyInto(*out)
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	in.SharedStorageCache.DeepCopyInto(&out.SharedStorageCache)
-	in.PythonUdfSidecar.DeepCopyInto(&out.PythonUdfSidecar)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigThatChangeCNSpec.
-func (in *ConfigThatChangeCNSpec) DeepCopy() *ConfigThatChangeCNSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNLabel.
+func (in *CNLabel) DeepCopy() *CNLabel {
 	if in == nil {
 		return nil
 	}
-	out := new(ConfigThatChangeCNSpec)
+	out := new(CNLabel)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSet) DeepCopyInto(out *DNSet) {
+func (in *CNPool) DeepCopyInto(out *CNPool) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Deps.DeepCopyInto(&out.Deps)
-	in.Status.DeepCopyInto(&out.Status)
+	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSet.
-func (in *DNSet) DeepCopy() *DNSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNPool.
+func (in *CNPool) DeepCopy() *CNPool {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSet)
+	out := new(CNPool)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSet) DeepCopyObject() runtime.Object {
+func (in *CNPool) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1081,47 +1202,31 @@ func (in *DNSet) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSetDeps) DeepCopyInto(out *DNSetDeps) {
-	*out = *in
-	in.LogSetRef.DeepCopyInto(&out.LogSetRef)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSetDeps.
-func (in *DNSetDeps) DeepCopy() *DNSetDeps {
-	if in == nil {
-		return nil
-	}
-	out := new(DNSetDeps)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSetList) DeepCopyInto(out *DNSetList) {
+func (in *CNPoolList) DeepCopyInto(out *CNPoolList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DNSet, len(*in))
+		*out = make([]CNPool, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSetList.
-func (in *DNSetList) DeepCopy() *DNSetList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNPoolList.
+func (in *CNPoolList) DeepCopy() *CNPoolList {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSetList)
+	out := new(CNPoolList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DNSetList) DeepCopyObject() runtime.Object {
+func (in *CNPoolList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1129,470 +1234,372 @@ func (in *DNSetList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSetSpec) DeepCopyInto(out *DNSetSpec) {
+func (in *CNPoolSpec) DeepCopyInto(out *CNPoolSpec) {
 	*out = *in
-	in.PodSet.DeepCopyInto(&out.PodSet)
-	if in.CacheVolume != nil {
-		in, out := &in.CacheVolume, &out.CacheVolume
-		*out = new(Volume)
-		(*in).DeepCopyInto(*out)
+	in.Template.DeepCopyInto(&out.Template)
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	in.SharedStorageCache.DeepCopyInto(&out.SharedStorageCache)
+	in.Deps.DeepCopyInto(&out.Deps)
+	in.Strategy.DeepCopyInto(&out.Strategy)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSetSpec.
-func (in *DNSetSpec) DeepCopy() *DNSetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNPoolSpec.
+func (in *CNPoolSpec) DeepCopy() *CNPoolSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSetSpec)
+	out := new(CNPoolSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DNSetStatus) DeepCopyInto(out *DNSetStatus) {
+func (in *CNPoolStatus) DeepCopyInto(out *CNPoolStatus) {
 	*out = *in
-	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
-	in.FailoverStatus.DeepCopyInto(&out.FailoverStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSetStatus.
-func (in *DNSetStatus) DeepCopy() *DNSetStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNPoolStatus.
+func (in *CNPoolStatus) DeepCopy() *CNPoolStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DNSetStatus)
+	out := new(CNPoolStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DefaultArgs) DeepCopyInto(out *DefaultArgs) {
+func (in *CNSet) DeepCopyInto(out *CNSet) {
 	*out = *in
-	if in.LogService != nil {
-		in, out := &in.LogService, &out.LogService
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.DN != nil {
-		in, out := &in.DN, &out.DN
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.CN != nil {
-		in, out := &in.CN, &out.CN
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Proxy != nil {
-		in, out := &in.Proxy, &out.Proxy
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Deps.DeepCopyInto(&out.Deps)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultArgs.
-func (in *DefaultArgs) DeepCopy() *DefaultArgs {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNSet.
+func (in *CNSet) DeepCopy() *CNSet {
 	if in == nil {
 		return nil
 	}
-	out := new(DefaultArgs)
+	out := new(CNSet)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EmbeddedMetadata) DeepCopyInto(out *EmbeddedMetadata) {
-	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedMetadata.
-func (in *EmbeddedMetadata) DeepCopy() *EmbeddedMetadata {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CNSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(EmbeddedMetadata)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalLogSet) DeepCopyInto(out *ExternalLogSet) {
+func (in *CNSetDeps) DeepCopyInto(out *CNSetDeps) {
 	*out = *in
+	in.LogSetRef.DeepCopyInto(&out.LogSetRef)
+	if in.DNSet != nil {
+		in, out := &in.DNSet, &out.DNSet
+		*out = new(DNSet)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalLogSet.
-func (in *ExternalLogSet) DeepCopy() *ExternalLogSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNSetDeps.
+func (in *CNSetDeps) DeepCopy() *CNSetDeps {
 	if in == nil {
 		return nil
 	}
-	out := new(ExternalLogSet)
+	out := new(CNSetDeps)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FailoverStatus) DeepCopyInto(out *FailoverStatus) {
+func (in *CNSetList) DeepCopyInto(out *CNSetList) {
 	*out = *in
-	if in.AvailableStores != nil {
-		in, out := &in.AvailableStores, &out.AvailableStores
-		*out = make([]Store, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.FailedStores != nil {
-		in, out := &in.FailedStores, &out.FailedStores
-		*out = make([]Store, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CNSet, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverStatus.
-func (in *FailoverStatus) DeepCopy() *FailoverStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNSetList.
+func (in *CNSetList) DeepCopy() *CNSetList {
 	if in == nil {
 		return nil
 	}
-	out := new(FailoverStatus)
+	out := new(CNSetList)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FileSystemProvider) DeepCopyInto(out *FileSystemProvider) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileSystemProvider.
-func (in *FileSystemProvider) DeepCopy() *FileSystemProvider {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CNSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(FileSystemProvider)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InitialConfig) DeepCopyInto(out *InitialConfig) {
+func (in *CNSetSpec) DeepCopyInto(out *CNSetSpec) {
 	*out = *in
-	if in.LogShards != nil {
-		in, out := &in.LogShards, &out.LogShards
-		*out = new(int)
-		**out = **in
+	in.PodSet.DeepCopyInto(&out.PodSet)
+	in.ConfigThatChangeCNSpec.DeepCopyInto(&out.ConfigThatChangeCNSpec)
+	if in.ServiceAnnotations != nil {
+		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.DNShards != nil {
-		in, out := &in.DNShards, &out.DNShards
-		*out = new(int)
+	if in.NodePort != nil {
+		in, out := &in.NodePort, &out.NodePort
+		*out = new(int32)
 		**out = **in
 	}
-	if in.LogShardReplicas != nil {
-		in, out := &in.LogShardReplicas, &out.LogShardReplicas
-		*out = new(int)
+	if in.Exposure != nil {
+		in, out := &in.Exposure, &out.Exposure
+		*out = new(SQLExposure)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]CNLabel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.ScalingConfig.DeepCopyInto(&out.ScalingConfig)
+	in.UpdateStrategy.DeepCopyInto(&out.UpdateStrategy)
+	if in.TerminationPolicy != nil {
+		in, out := &in.TerminationPolicy, &out.TerminationPolicy
+		*out = new(CNSetTerminationPolicy)
 		**out = **in
 	}
-	if in.RestoreFrom != nil {
-		in, out := &in.RestoreFrom, &out.RestoreFrom
+	if in.PodManagementPolicy != nil {
+		in, out := &in.PodManagementPolicy, &out.PodManagementPolicy
 		*out = new(string)
 		**out = **in
 	}
+	if in.PodsToDelete != nil {
+		in, out := &in.PodsToDelete, &out.PodsToDelete
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReusePVC != nil {
+		in, out := &in.ReusePVC, &out.ReusePVC
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InitialConfig.
-func (in *InitialConfig) DeepCopy() *InitialConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNSetSpec.
+func (in *CNSetSpec) DeepCopy() *CNSetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InitialConfig)
+	out := new(CNSetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogSet) DeepCopyInto(out *LogSet) {
+func (in *CNSetStatus) DeepCopyInto(out *CNSetStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
+	if in.Stores != nil {
+		in, out := &in.Stores, &out.Stores
+		*out = make([]CNStore, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSet.
-func (in *LogSet) DeepCopy() *LogSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNSetStatus.
+func (in *CNSetStatus) DeepCopy() *CNSetStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(LogSet)
+	out := new(CNSetStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LogSet) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogSetDiscovery) DeepCopyInto(out *LogSetDiscovery) {
+func (in *CNStore) DeepCopyInto(out *CNStore) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSetDiscovery.
-func (in *LogSetDiscovery) DeepCopy() *LogSetDiscovery {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNStore.
+func (in *CNStore) DeepCopy() *CNStore {
 	if in == nil {
 		return nil
 	}
-	out := new(LogSetDiscovery)
+	out := new(CNStore)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogSetList) DeepCopyInto(out *LogSetList) {
+func (in *CNStoreStatus) DeepCopyInto(out *CNStoreStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]LogSet, len(*in))
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]CNLabel, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.BoundTime != nil {
+		in, out := &in.BoundTime, &out.BoundTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSetList.
-func (in *LogSetList) DeepCopy() *LogSetList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CNStoreStatus.
+func (in *CNStoreStatus) DeepCopy() *CNStoreStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(LogSetList)
+	out := new(CNStoreStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LogSetList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogSetRef) DeepCopyInto(out *LogSetRef) {
+func (in *CertificateRef) DeepCopyInto(out *CertificateRef) {
 	*out = *in
-	if in.LogSet != nil {
-		in, out := &in.LogSet, &out.LogSet
-		*out = new(LogSet)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ExternalLogSet != nil {
-		in, out := &in.ExternalLogSet, &out.ExternalLogSet
-		*out = new(ExternalLogSet)
-		**out = **in
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSetRef.
-func (in *LogSetRef) DeepCopy() *LogSetRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRef.
+func (in *CertificateRef) DeepCopy() *CertificateRef {
 	if in == nil {
 		return nil
 	}
-	out := new(LogSetRef)
+	out := new(CertificateRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogSetSpec) DeepCopyInto(out *LogSetSpec) {
+func (in *ClaimPodRef) DeepCopyInto(out *ClaimPodRef) {
 	*out = *in
-	in.PodSet.DeepCopyInto(&out.PodSet)
-	in.Volume.DeepCopyInto(&out.Volume)
-	in.SharedStorage.DeepCopyInto(&out.SharedStorage)
-	in.InitialConfig.DeepCopyInto(&out.InitialConfig)
-	if in.StoreFailureTimeout != nil {
-		in, out := &in.StoreFailureTimeout, &out.StoreFailureTimeout
-		*out = new(v1.Duration)
-		**out = **in
-	}
-	if in.FailedPodStrategy != nil {
-		in, out := &in.FailedPodStrategy, &out.FailedPodStrategy
-		*out = new(FailedPodStrategy)
-		**out = **in
-	}
-	if in.PVCRetentionPolicy != nil {
-		in, out := &in.PVCRetentionPolicy, &out.PVCRetentionPolicy
-		*out = new(PVCRetentionPolicy)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSetSpec.
-func (in *LogSetSpec) DeepCopy() *LogSetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClaimPodRef.
+func (in *ClaimPodRef) DeepCopy() *ClaimPodRef {
 	if in == nil {
 		return nil
 	}
-	out := new(LogSetSpec)
+	out := new(ClaimPodRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogSetStatus) DeepCopyInto(out *LogSetStatus) {
+func (in *ClusterMetrics) DeepCopyInto(out *ClusterMetrics) {
 	*out = *in
-	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
-	in.FailoverStatus.DeepCopyInto(&out.FailoverStatus)
-	if in.Discovery != nil {
-		in, out := &in.Discovery, &out.Discovery
-		*out = new(LogSetDiscovery)
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSetStatus.
-func (in *LogSetStatus) DeepCopy() *LogSetStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMetrics.
+func (in *ClusterMetrics) DeepCopy() *ClusterMetrics {
 	if in == nil {
 		return nil
 	}
-	out := new(LogSetStatus)
+	out := new(ClusterMetrics)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MainContainer) DeepCopyInto(out *MainContainer) {
+func (in *ConditionalStatus) DeepCopyInto(out *ConditionalStatus) {
 	*out = *in
-	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MainContainer.
-func (in *MainContainer) DeepCopy() *MainContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionalStatus.
+func (in *ConditionalStatus) DeepCopy() *ConditionalStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MainContainer)
+	out := new(ConditionalStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MainContainerOverlay) DeepCopyInto(out *MainContainerOverlay) {
+func (in *ConfigThatChangeCNSpec) DeepCopyInto(out *ConfigThatChangeCNSpec) {
 	*out = *in
-	if in.Command != nil {
-		in, out := &in.Command, &out.Command
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Args != nil {
-		in, out := &in.Args, &out.Args
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.EnvFrom != nil {
-		in, out := &in.EnvFrom, &out.EnvFrom
-		*out = make([]corev1.EnvFromSource, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]corev1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.ImagePullPolicy != nil {
-		in, out := &in.ImagePullPolicy, &out.ImagePullPolicy
-		*out = new(corev1.PullPolicy)
-		**out = **in
-	}
-	if in.VolumeMounts != nil {
-		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]corev1.VolumeMount, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.LivenessProbe != nil {
-		in, out := &in.LivenessProbe, &out.LivenessProbe
-		*out = new(corev1.Probe)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ReadinessProbe != nil {
-		in, out := &in.ReadinessProbe, &out.ReadinessProbe
-		*out = new(corev1.Probe)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.StartupProbe != nil {
-		in, out := &in.StartupProbe, &out.StartupProbe
-		*out = new(corev1.Probe)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Lifecycle != nil {
-		in, out := &in.Lifecycle, &out.Lifecycle
-		*out = new(corev1.Lifecycle)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.SecurityContext != nil {
-		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(corev1.SecurityContext)
+	if in.CacheVolume != nil {
+		in, out := &in.CacheVolume, &out.CacheVolume
+		*out = new(Volume)
 		(*in).DeepCopyInto(*out)
 	}
+	in.SharedStorageCache.DeepCopyInto(&out.SharedStorageCache)
+	in.PythonUdfSidecar.DeepCopyInto(&out.PythonUdfSidecar)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MainContainerOverlay.
-func (in *MainContainerOverlay) DeepCopy() *MainContainerOverlay {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigThatChangeCNSpec.
+func (in *ConfigThatChangeCNSpec) DeepCopy() *ConfigThatChangeCNSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MainContainerOverlay)
+	out := new(ConfigThatChangeCNSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MatrixOneCluster) DeepCopyInto(out *MatrixOneCluster) {
+func (in *DNSet) DeepCopyInto(out *DNSet) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Deps.DeepCopyInto(&out.Deps)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatrixOneCluster.
-func (in *MatrixOneCluster) DeepCopy() *MatrixOneCluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSet.
+func (in *DNSet) DeepCopy() *DNSet {
 	if in == nil {
 		return nil
 	}
-	out := new(MatrixOneCluster)
+	out := new(DNSet)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MatrixOneCluster) DeepCopyObject() runtime.Object {
+func (in *DNSet) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1600,31 +1607,47 @@ func (in *MatrixOneCluster) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MatrixOneClusterList) DeepCopyInto(out *MatrixOneClusterList) {
+func (in *DNSetDeps) DeepCopyInto(out *DNSetDeps) {
+	*out = *in
+	in.LogSetRef.DeepCopyInto(&out.LogSetRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSetDeps.
+func (in *DNSetDeps) DeepCopy() *DNSetDeps {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSetDeps)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSetList) DeepCopyInto(out *DNSetList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]MatrixOneCluster, len(*in))
+		*out = make([]DNSet, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatrixOneClusterList.
-func (in *MatrixOneClusterList) DeepCopy() *MatrixOneClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSetList.
+func (in *DNSetList) DeepCopy() *DNSetList {
 	if in == nil {
 		return nil
 	}
-	out := new(MatrixOneClusterList)
+	out := new(DNSetList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *MatrixOneClusterList) DeepCopyObject() runtime.Object {
+func (in *DNSetList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1632,271 +1655,1302 @@ func (in *MatrixOneClusterList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MatrixOneClusterSpec) DeepCopyInto(out *MatrixOneClusterSpec) {
+func (in *DNSetSpec) DeepCopyInto(out *DNSetSpec) {
 	*out = *in
-	if in.TP != nil {
-		in, out := &in.TP, &out.TP
-		*out = new(CNSetSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.AP != nil {
-		in, out := &in.AP, &out.AP
-		*out = new(CNSetSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.CNGroups != nil {
-		in, out := &in.CNGroups, &out.CNGroups
-		*out = make([]CNGroup, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.DN != nil {
-		in, out := &in.DN, &out.DN
-		*out = new(DNSetSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.TN != nil {
-		in, out := &in.TN, &out.TN
-		*out = new(DNSetSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	in.LogService.DeepCopyInto(&out.LogService)
-	if in.WebUI != nil {
-		in, out := &in.WebUI, &out.WebUI
-		*out = new(WebUISpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Proxy != nil {
-		in, out := &in.Proxy, &out.Proxy
-		*out = new(ProxySetSpec)
+	in.PodSet.DeepCopyInto(&out.PodSet)
+	if in.CacheVolume != nil {
+		in, out := &in.CacheVolume, &out.CacheVolume
+		*out = new(Volume)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.TopologyEvenSpread != nil {
-		in, out := &in.TopologyEvenSpread, &out.TopologyEvenSpread
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.ImagePullPolicy != nil {
-		in, out := &in.ImagePullPolicy, &out.ImagePullPolicy
-		*out = new(corev1.PullPolicy)
-		**out = **in
-	}
-	if in.RestoreFrom != nil {
-		in, out := &in.RestoreFrom, &out.RestoreFrom
-		*out = new(string)
-		**out = **in
-	}
-	if in.MetricReaderEnabled != nil {
-		in, out := &in.MetricReaderEnabled, &out.MetricReaderEnabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.SemanticVersion != nil {
-		in, out := &in.SemanticVersion, &out.SemanticVersion
-		*out = new(string)
+	in.SharedStorageCache.DeepCopyInto(&out.SharedStorageCache)
+	if in.StoreFailureTimeout != nil {
+		in, out := &in.StoreFailureTimeout, &out.StoreFailureTimeout
+		*out = new(v1.Duration)
 		**out = **in
 	}
-	if in.OperatorVersion != nil {
-		in, out := &in.OperatorVersion, &out.OperatorVersion
-		*out = new(string)
+	if in.FailedPodStrategy != nil {
+		in, out := &in.FailedPodStrategy, &out.FailedPodStrategy
+		*out = new(FailedPodStrategy)
 		**out = **in
 	}
-	if in.MemoryFsSize != nil {
-		in, out := &in.MemoryFsSize, &out.MemoryFsSize
-		x := (*in).DeepCopy()
-		*out = &x
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatrixOneClusterSpec.
-func (in *MatrixOneClusterSpec) DeepCopy() *MatrixOneClusterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSetSpec.
+func (in *DNSetSpec) DeepCopy() *DNSetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MatrixOneClusterSpec)
+	out := new(DNSetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MatrixOneClusterStatus) DeepCopyInto(out *MatrixOneClusterStatus) {
+func (in *DNSetStatus) DeepCopyInto(out *DNSetStatus) {
 	*out = *in
 	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
-	if in.CredentialRef != nil {
-		in, out := &in.CredentialRef, &out.CredentialRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
+	in.FailoverStatus.DeepCopyInto(&out.FailoverStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSetStatus.
+func (in *DNSetStatus) DeepCopy() *DNSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultArgs) DeepCopyInto(out *DefaultArgs) {
+	*out = *in
+	if in.LogService != nil {
+		in, out := &in.LogService, &out.LogService
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	in.ClusterMetrics.DeepCopyInto(&out.ClusterMetrics)
-	in.CNGroupStatus.DeepCopyInto(&out.CNGroupStatus)
 	if in.DN != nil {
 		in, out := &in.DN, &out.DN
-		*out = new(DNSetStatus)
-		(*in).DeepCopyInto(*out)
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CN != nil {
+		in, out := &in.CN, &out.CN
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	if in.Proxy != nil {
 		in, out := &in.Proxy, &out.Proxy
-		*out = new(ProxySetStatus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Webui != nil {
-		in, out := &in.Webui, &out.Webui
-		*out = new(WebUIStatus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.LogService != nil {
-		in, out := &in.LogService, &out.LogService
-		*out = new(LogSetStatus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Readable != nil {
-		in, out := &in.Readable, &out.Readable
-		*out = new(ReadableStatus)
-		**out = **in
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatrixOneClusterStatus.
-func (in *MatrixOneClusterStatus) DeepCopy() *MatrixOneClusterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultArgs.
+func (in *DefaultArgs) DeepCopy() *DefaultArgs {
 	if in == nil {
 		return nil
 	}
-	out := new(MatrixOneClusterStatus)
+	out := new(DefaultArgs)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MigrateStatus) DeepCopyInto(out *MigrateStatus) {
+func (in *EmbeddedMetadata) DeepCopyInto(out *EmbeddedMetadata) {
 	*out = *in
-	out.Source = in.Source
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrateStatus.
-func (in *MigrateStatus) DeepCopy() *MigrateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedMetadata.
+func (in *EmbeddedMetadata) DeepCopy() *EmbeddedMetadata {
 	if in == nil {
 		return nil
 	}
-	out := new(MigrateStatus)
+	out := new(EmbeddedMetadata)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectRef) DeepCopyInto(out *ObjectRef) {
+func (in *ExternalLogSet) DeepCopyInto(out *ExternalLogSet) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectRef.
-func (in *ObjectRef) DeepCopy() *ObjectRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalLogSet.
+func (in *ExternalLogSet) DeepCopy() *ExternalLogSet {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectRef)
+	out := new(ExternalLogSet)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Overlay) DeepCopyInto(out *Overlay) {
+func (in *FailoverStatus) DeepCopyInto(out *FailoverStatus) {
 	*out = *in
-	in.MainContainerOverlay.DeepCopyInto(&out.MainContainerOverlay)
-	if in.Volumes != nil {
-		in, out := &in.Volumes, &out.Volumes
-		*out = make([]corev1.Volume, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.VolumeClaims != nil {
-		in, out := &in.VolumeClaims, &out.VolumeClaims
-		*out = make([]corev1.PersistentVolumeClaim, len(*in))
+	if in.AvailableStores != nil {
+		in, out := &in.AvailableStores, &out.AvailableStores
+		*out = make([]Store, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]corev1.Container, len(*in))
+	if in.FailedStores != nil {
+		in, out := &in.FailedStores, &out.FailedStores
+		*out = make([]Store, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.SidecarContainers != nil {
-		in, out := &in.SidecarContainers, &out.SidecarContainers
-		*out = make([]corev1.Container, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverStatus.
+func (in *FailoverStatus) DeepCopy() *FailoverStatus {
+	if in == nil {
+		return nil
 	}
-	if in.SecurityContext != nil {
-		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(corev1.PodSecurityContext)
+	out := new(FailoverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileSystemProvider) DeepCopyInto(out *FileSystemProvider) {
+	*out = *in
+	if in.Volume != nil {
+		in, out := &in.Volume, &out.Volume
+		*out = new(FileSystemVolume)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]corev1.LocalObjectReference, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileSystemProvider.
+func (in *FileSystemProvider) DeepCopy() *FileSystemProvider {
+	if in == nil {
+		return nil
 	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(corev1.Affinity)
-		(*in).DeepCopyInto(*out)
+	out := new(FileSystemProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileSystemVolume) DeepCopyInto(out *FileSystemVolume) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(corev1.PersistentVolumeClaimVolumeSource)
+		**out = **in
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]corev1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.NFS != nil {
+		in, out := &in.NFS, &out.NFS
+		*out = new(corev1.NFSVolumeSource)
+		**out = **in
 	}
-	if in.TerminationGracePeriodSeconds != nil {
-		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
-		*out = new(int64)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileSystemVolume.
+func (in *FileSystemVolume) DeepCopy() *FileSystemVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(FileSystemVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayParentRef) DeepCopyInto(out *GatewayParentRef) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
 		**out = **in
 	}
-	if in.HostAliases != nil {
-		in, out := &in.HostAliases, &out.HostAliases
-		*out = make([]corev1.HostAlias, len(*in))
+	if in.SectionName != nil {
+		in, out := &in.SectionName, &out.SectionName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParentRef.
+func (in *GatewayParentRef) DeepCopy() *GatewayParentRef {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayParentRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayRouteExposure) DeepCopyInto(out *GatewayRouteExposure) {
+	*out = *in
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]GatewayParentRef, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.TopologySpreadConstraints != nil {
-		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
-		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayRouteExposure.
+func (in *GatewayRouteExposure) DeepCopy() *GatewayRouteExposure {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayRouteExposure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HAKeeperStatus) DeepCopyInto(out *HAKeeperStatus) {
+	*out = *in
+	if in.LogShards != nil {
+		in, out := &in.LogShards, &out.LogShards
+		*out = make([]LogShardStatus, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.RuntimeClassName != nil {
-		in, out := &in.RuntimeClassName, &out.RuntimeClassName
-		*out = new(string)
-		**out = **in
+	if in.DownStores != nil {
+		in, out := &in.DownStores, &out.DownStores
+		*out = make([]HAKeeperStore, len(*in))
+		copy(*out, *in)
 	}
-	if in.DNSConfig != nil {
-		in, out := &in.DNSConfig, &out.DNSConfig
-		*out = new(corev1.PodDNSConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HAKeeperStatus.
+func (in *HAKeeperStatus) DeepCopy() *HAKeeperStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HAKeeperStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HAKeeperStore) DeepCopyInto(out *HAKeeperStore) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HAKeeperStore.
+func (in *HAKeeperStore) DeepCopy() *HAKeeperStore {
+	if in == nil {
+		return nil
+	}
+	out := new(HAKeeperStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPExposure) DeepCopyInto(out *HTTPExposure) {
+	*out = *in
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(IngressExposure)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.PodLabels != nil {
-		in, out := &in.PodLabels, &out.PodLabels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.HTTPRoute != nil {
+		in, out := &in.HTTPRoute, &out.HTTPRoute
+		*out = new(GatewayRouteExposure)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.PodAnnotations != nil {
-		in, out := &in.PodAnnotations, &out.PodAnnotations
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPExposure.
+func (in *HTTPExposure) DeepCopy() *HTTPExposure {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPExposure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressExposure) DeepCopyInto(out *IngressExposure) {
+	*out = *in
+	if in.ClassName != nil {
+		in, out := &in.ClassName, &out.ClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.TLSSecretName != nil {
+		in, out := &in.TLSSecretName, &out.TLSSecretName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressExposure.
+func (in *IngressExposure) DeepCopy() *IngressExposure {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressExposure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InitialConfig) DeepCopyInto(out *InitialConfig) {
+	*out = *in
+	if in.LogShards != nil {
+		in, out := &in.LogShards, &out.LogShards
+		*out = new(int)
+		**out = **in
+	}
+	if in.DNShards != nil {
+		in, out := &in.DNShards, &out.DNShards
+		*out = new(int)
+		**out = **in
+	}
+	if in.LogShardReplicas != nil {
+		in, out := &in.LogShardReplicas, &out.LogShardReplicas
+		*out = new(int)
+		**out = **in
+	}
+	if in.RestoreFrom != nil {
+		in, out := &in.RestoreFrom, &out.RestoreFrom
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InitialConfig.
+func (in *InitialConfig) DeepCopy() *InitialConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(InitialConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobAttemptStatus) DeepCopyInto(out *JobAttemptStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobAttemptStatus.
+func (in *JobAttemptStatus) DeepCopy() *JobAttemptStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JobAttemptStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogReplicaStatus) DeepCopyInto(out *LogReplicaStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogReplicaStatus.
+func (in *LogReplicaStatus) DeepCopy() *LogReplicaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LogReplicaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogSet) DeepCopyInto(out *LogSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSet.
+func (in *LogSet) DeepCopy() *LogSet {
+	if in == nil {
+		return nil
+	}
+	out := new(LogSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LogSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogSetDiscovery) DeepCopyInto(out *LogSetDiscovery) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSetDiscovery.
+func (in *LogSetDiscovery) DeepCopy() *LogSetDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(LogSetDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogSetList) DeepCopyInto(out *LogSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LogSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSetList.
+func (in *LogSetList) DeepCopy() *LogSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(LogSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LogSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogSetRef) DeepCopyInto(out *LogSetRef) {
+	*out = *in
+	if in.LogSet != nil {
+		in, out := &in.LogSet, &out.LogSet
+		*out = new(LogSet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalLogSet != nil {
+		in, out := &in.ExternalLogSet, &out.ExternalLogSet
+		*out = new(ExternalLogSet)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSetRef.
+func (in *LogSetRef) DeepCopy() *LogSetRef {
+	if in == nil {
+		return nil
+	}
+	out := new(LogSetRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogSetSpec) DeepCopyInto(out *LogSetSpec) {
+	*out = *in
+	in.PodSet.DeepCopyInto(&out.PodSet)
+	in.Volume.DeepCopyInto(&out.Volume)
+	in.SharedStorage.DeepCopyInto(&out.SharedStorage)
+	if in.ETLStorage != nil {
+		in, out := &in.ETLStorage, &out.ETLStorage
+		*out = new(SharedStorageProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	in.InitialConfig.DeepCopyInto(&out.InitialConfig)
+	if in.StoreFailureTimeout != nil {
+		in, out := &in.StoreFailureTimeout, &out.StoreFailureTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FailedPodStrategy != nil {
+		in, out := &in.FailedPodStrategy, &out.FailedPodStrategy
+		*out = new(FailedPodStrategy)
+		**out = **in
+	}
+	if in.PVCRetentionPolicy != nil {
+		in, out := &in.PVCRetentionPolicy, &out.PVCRetentionPolicy
+		*out = new(PVCRetentionPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSetSpec.
+func (in *LogSetSpec) DeepCopy() *LogSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LogSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogSetStatus) DeepCopyInto(out *LogSetStatus) {
+	*out = *in
+	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
+	in.FailoverStatus.DeepCopyInto(&out.FailoverStatus)
+	if in.Discovery != nil {
+		in, out := &in.Discovery, &out.Discovery
+		*out = new(LogSetDiscovery)
+		**out = **in
+	}
+	if in.HAKeeper != nil {
+		in, out := &in.HAKeeper, &out.HAKeeper
+		*out = new(HAKeeperStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSetStatus.
+func (in *LogSetStatus) DeepCopy() *LogSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LogSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogShardStatus) DeepCopyInto(out *LogShardStatus) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]LogReplicaStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogShardStatus.
+func (in *LogShardStatus) DeepCopy() *LogShardStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LogShardStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogicalBackupJob) DeepCopyInto(out *LogicalBackupJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogicalBackupJob.
+func (in *LogicalBackupJob) DeepCopy() *LogicalBackupJob {
+	if in == nil {
+		return nil
+	}
+	out := new(LogicalBackupJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LogicalBackupJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogicalBackupJobList) DeepCopyInto(out *LogicalBackupJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LogicalBackupJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogicalBackupJobList.
+func (in *LogicalBackupJobList) DeepCopy() *LogicalBackupJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(LogicalBackupJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LogicalBackupJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogicalBackupJobSpec) DeepCopyInto(out *LogicalBackupJobSpec) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	in.Source.DeepCopyInto(&out.Source)
+	in.Scope.DeepCopyInto(&out.Scope)
+	in.Target.DeepCopyInto(&out.Target)
+	if in.Overlay != nil {
+		in, out := &in.Overlay, &out.Overlay
+		*out = new(Overlay)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogicalBackupJobSpec.
+func (in *LogicalBackupJobSpec) DeepCopy() *LogicalBackupJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LogicalBackupJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogicalEndpoint) DeepCopyInto(out *LogicalEndpoint) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogicalEndpoint.
+func (in *LogicalEndpoint) DeepCopy() *LogicalEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(LogicalEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogicalJobStatus) DeepCopyInto(out *LogicalJobStatus) {
+	*out = *in
+	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
+	if in.Location != nil {
+		in, out := &in.Location, &out.Location
+		*out = new(SharedStorageProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogicalJobStatus.
+func (in *LogicalJobStatus) DeepCopy() *LogicalJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LogicalJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogicalRestoreJob) DeepCopyInto(out *LogicalRestoreJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogicalRestoreJob.
+func (in *LogicalRestoreJob) DeepCopy() *LogicalRestoreJob {
+	if in == nil {
+		return nil
+	}
+	out := new(LogicalRestoreJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LogicalRestoreJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogicalRestoreJobList) DeepCopyInto(out *LogicalRestoreJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LogicalRestoreJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogicalRestoreJobList.
+func (in *LogicalRestoreJobList) DeepCopy() *LogicalRestoreJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(LogicalRestoreJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LogicalRestoreJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogicalRestoreJobSpec) DeepCopyInto(out *LogicalRestoreJobSpec) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	in.Target.DeepCopyInto(&out.Target)
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(SharedStorageProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Overlay != nil {
+		in, out := &in.Overlay, &out.Overlay
+		*out = new(Overlay)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogicalRestoreJobSpec.
+func (in *LogicalRestoreJobSpec) DeepCopy() *LogicalRestoreJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LogicalRestoreJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogicalScope) DeepCopyInto(out *LogicalScope) {
+	*out = *in
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogicalScope.
+func (in *LogicalScope) DeepCopy() *LogicalScope {
+	if in == nil {
+		return nil
+	}
+	out := new(LogicalScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MainContainer) DeepCopyInto(out *MainContainer) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MainContainer.
+func (in *MainContainer) DeepCopy() *MainContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(MainContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MainContainerOverlay) DeepCopyInto(out *MainContainerOverlay) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImagePullPolicy != nil {
+		in, out := &in.ImagePullPolicy, &out.ImagePullPolicy
+		*out = new(corev1.PullPolicy)
+		**out = **in
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Lifecycle != nil {
+		in, out := &in.Lifecycle, &out.Lifecycle
+		*out = new(corev1.Lifecycle)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MainContainerOverlay.
+func (in *MainContainerOverlay) DeepCopy() *MainContainerOverlay {
+	if in == nil {
+		return nil
+	}
+	out := new(MainContainerOverlay)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatrixOneCluster) DeepCopyInto(out *MatrixOneCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatrixOneCluster.
+func (in *MatrixOneCluster) DeepCopy() *MatrixOneCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(MatrixOneCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MatrixOneCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatrixOneClusterList) DeepCopyInto(out *MatrixOneClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MatrixOneCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatrixOneClusterList.
+func (in *MatrixOneClusterList) DeepCopy() *MatrixOneClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(MatrixOneClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MatrixOneClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatrixOneClusterSpec) DeepCopyInto(out *MatrixOneClusterSpec) {
+	*out = *in
+	if in.TP != nil {
+		in, out := &in.TP, &out.TP
+		*out = new(CNSetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AP != nil {
+		in, out := &in.AP, &out.AP
+		*out = new(CNSetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CNGroups != nil {
+		in, out := &in.CNGroups, &out.CNGroups
+		*out = make([]CNGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DN != nil {
+		in, out := &in.DN, &out.DN
+		*out = new(DNSetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TN != nil {
+		in, out := &in.TN, &out.TN
+		*out = new(DNSetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.LogService.DeepCopyInto(&out.LogService)
+	if in.WebUI != nil {
+		in, out := &in.WebUI, &out.WebUI
+		*out = new(WebUISpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxySetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologyEvenSpread != nil {
+		in, out := &in.TopologyEvenSpread, &out.TopologyEvenSpread
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ImagePullPolicy != nil {
+		in, out := &in.ImagePullPolicy, &out.ImagePullPolicy
+		*out = new(corev1.PullPolicy)
+		**out = **in
+	}
+	if in.RestoreFrom != nil {
+		in, out := &in.RestoreFrom, &out.RestoreFrom
+		*out = new(string)
+		**out = **in
+	}
+	if in.PITR != nil {
+		in, out := &in.PITR, &out.PITR
+		*out = new(PITRSpec)
+		**out = **in
+	}
+	if in.MetricReaderEnabled != nil {
+		in, out := &in.MetricReaderEnabled, &out.MetricReaderEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SemanticVersion != nil {
+		in, out := &in.SemanticVersion, &out.SemanticVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.OperatorVersion != nil {
+		in, out := &in.OperatorVersion, &out.OperatorVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.MemoryFsSize != nil {
+		in, out := &in.MemoryFsSize, &out.MemoryFsSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatrixOneClusterSpec.
+func (in *MatrixOneClusterSpec) DeepCopy() *MatrixOneClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MatrixOneClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatrixOneClusterStatus) DeepCopyInto(out *MatrixOneClusterStatus) {
+	*out = *in
+	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
+	if in.CredentialRef != nil {
+		in, out := &in.CredentialRef, &out.CredentialRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	in.ClusterMetrics.DeepCopyInto(&out.ClusterMetrics)
+	in.CNGroupStatus.DeepCopyInto(&out.CNGroupStatus)
+	if in.DN != nil {
+		in, out := &in.DN, &out.DN
+		*out = new(DNSetStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxySetStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webui != nil {
+		in, out := &in.Webui, &out.Webui
+		*out = new(WebUIStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogService != nil {
+		in, out := &in.LogService, &out.LogService
+		*out = new(LogSetStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Readable != nil {
+		in, out := &in.Readable, &out.Readable
+		*out = new(ReadableStatus)
+		**out = **in
+	}
+	if in.PITR != nil {
+		in, out := &in.PITR, &out.PITR
+		*out = new(PITRStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatrixOneClusterStatus.
+func (in *MatrixOneClusterStatus) DeepCopy() *MatrixOneClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MatrixOneClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrateStatus) DeepCopyInto(out *MigrateStatus) {
+	*out = *in
+	out.Source = in.Source
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrateStatus.
+func (in *MigrateStatus) DeepCopy() *MigrateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectRef) DeepCopyInto(out *ObjectRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectRef.
+func (in *ObjectRef) DeepCopy() *ObjectRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Overlay) DeepCopyInto(out *Overlay) {
+	*out = *in
+	in.MainContainerOverlay.DeepCopyInto(&out.MainContainerOverlay)
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeClaims != nil {
+		in, out := &in.VolumeClaims, &out.VolumeClaims
+		*out = make([]corev1.PersistentVolumeClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SidecarContainers != nil {
+		in, out := &in.SidecarContainers, &out.SidecarContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]corev1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodLabels != nil {
+		in, out := &in.PodLabels, &out.PodLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodAnnotations != nil {
+		in, out := &in.PodAnnotations, &out.PodAnnotations
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
@@ -1909,220 +2963,1159 @@ func (in *Overlay) DeepCopyInto(out *Overlay) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Overlay.
-func (in *Overlay) DeepCopy() *Overlay {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Overlay.
+func (in *Overlay) DeepCopy() *Overlay {
+	if in == nil {
+		return nil
+	}
+	out := new(Overlay)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PITRSpec) DeepCopyInto(out *PITRSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PITRSpec.
+func (in *PITRSpec) DeepCopy() *PITRSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PITRSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PITRStatus) DeepCopyInto(out *PITRStatus) {
+	*out = *in
+	in.CreatedTime.DeepCopyInto(&out.CreatedTime)
+	if in.RecoverableFrom != nil {
+		in, out := &in.RecoverableFrom, &out.RecoverableFrom
+		*out = (*in).DeepCopy()
+	}
+	if in.RecoverableTo != nil {
+		in, out := &in.RecoverableTo, &out.RecoverableTo
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PITRStatus.
+func (in *PITRStatus) DeepCopy() *PITRStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PITRStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSet) DeepCopyInto(out *PodSet) {
+	*out = *in
+	in.MainContainer.DeepCopyInto(&out.MainContainer)
+	if in.Overlay != nil {
+		in, out := &in.Overlay, &out.Overlay
+		*out = new(Overlay)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologyEvenSpread != nil {
+		in, out := &in.TopologyEvenSpread, &out.TopologyEvenSpread
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = (*in).DeepCopy()
+	}
+	if in.DNSBasedIdentity != nil {
+		in, out := &in.DNSBasedIdentity, &out.DNSBasedIdentity
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ServiceArgs != nil {
+		in, out := &in.ServiceArgs, &out.ServiceArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MemoryLimitPercent != nil {
+		in, out := &in.MemoryLimitPercent, &out.MemoryLimitPercent
+		*out = new(int)
+		**out = **in
+	}
+	if in.ExportToPrometheus != nil {
+		in, out := &in.ExportToPrometheus, &out.ExportToPrometheus
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PromDiscoveryScheme != nil {
+		in, out := &in.PromDiscoveryScheme, &out.PromDiscoveryScheme
+		*out = new(PromDiscoveryScheme)
+		**out = **in
+	}
+	if in.SemanticVersion != nil {
+		in, out := &in.SemanticVersion, &out.SemanticVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.OperatorVersion != nil {
+		in, out := &in.OperatorVersion, &out.OperatorVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.MemoryFsSize != nil {
+		in, out := &in.MemoryFsSize, &out.MemoryFsSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSet.
+func (in *PodSet) DeepCopy() *PodSet {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PointInTimeRestore) DeepCopyInto(out *PointInTimeRestore) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PointInTimeRestore.
+func (in *PointInTimeRestore) DeepCopy() *PointInTimeRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(PointInTimeRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolScaleStrategy) DeepCopyInto(out *PoolScaleStrategy) {
+	*out = *in
+	if in.MaxPods != nil {
+		in, out := &in.MaxPods, &out.MaxPods
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolScaleStrategy.
+func (in *PoolScaleStrategy) DeepCopy() *PoolScaleStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolScaleStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolStrategy) DeepCopyInto(out *PoolStrategy) {
+	*out = *in
+	in.UpdateStrategy.DeepCopyInto(&out.UpdateStrategy)
+	in.ScaleStrategy.DeepCopyInto(&out.ScaleStrategy)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolStrategy.
+func (in *PoolStrategy) DeepCopy() *PoolStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolUpdateStrategy) DeepCopyInto(out *PoolUpdateStrategy) {
+	*out = *in
+	if in.ReclaimTimeout != nil {
+		in, out := &in.ReclaimTimeout, &out.ReclaimTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolUpdateStrategy.
+func (in *PoolUpdateStrategy) DeepCopy() *PoolUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyAutoscaling) DeepCopyInto(out *ProxyAutoscaling) {
+	*out = *in
+	if in.Tolerance != nil {
+		in, out := &in.Tolerance, &out.Tolerance
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleUpCooldown != nil {
+		in, out := &in.ScaleUpCooldown, &out.ScaleUpCooldown
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ScaleDownCooldown != nil {
+		in, out := &in.ScaleDownCooldown, &out.ScaleDownCooldown
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyAutoscaling.
+func (in *ProxyAutoscaling) DeepCopy() *ProxyAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyAutoscalingStatus) DeepCopyInto(out *ProxyAutoscalingStatus) {
+	*out = *in
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyAutoscalingStatus.
+func (in *ProxyAutoscalingStatus) DeepCopy() *ProxyAutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyAutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyDrainingStatus) DeepCopyInto(out *ProxyDrainingStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyDrainingStatus.
+func (in *ProxyDrainingStatus) DeepCopy() *ProxyDrainingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyDrainingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyPlugin) DeepCopyInto(out *ProxyPlugin) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = (*in).DeepCopy()
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyPlugin.
+func (in *ProxyPlugin) DeepCopy() *ProxyPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyRoute) DeepCopyInto(out *ProxyRoute) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Deps.DeepCopyInto(&out.Deps)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyRoute.
+func (in *ProxyRoute) DeepCopy() *ProxyRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProxyRoute) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyRouteBackend) DeepCopyInto(out *ProxyRouteBackend) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make([]CNLabel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyRouteBackend.
+func (in *ProxyRouteBackend) DeepCopy() *ProxyRouteBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyRouteBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyRouteDeps) DeepCopyInto(out *ProxyRouteDeps) {
+	*out = *in
+	in.LogSetRef.DeepCopyInto(&out.LogSetRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyRouteDeps.
+func (in *ProxyRouteDeps) DeepCopy() *ProxyRouteDeps {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyRouteDeps)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyRouteList) DeepCopyInto(out *ProxyRouteList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProxyRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyRouteList.
+func (in *ProxyRouteList) DeepCopy() *ProxyRouteList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyRouteList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProxyRouteList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyRouteMatch) DeepCopyInto(out *ProxyRouteMatch) {
+	*out = *in
+	if in.Accounts != nil {
+		in, out := &in.Accounts, &out.Accounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = make([]CNLabel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyRouteMatch.
+func (in *ProxyRouteMatch) DeepCopy() *ProxyRouteMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyRouteMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyRouteRule) DeepCopyInto(out *ProxyRouteRule) {
+	*out = *in
+	in.Match.DeepCopyInto(&out.Match)
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]ProxyRouteBackend, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Fallbacks != nil {
+		in, out := &in.Fallbacks, &out.Fallbacks
+		*out = make([]ProxyRouteBackend, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyRouteRule.
+func (in *ProxyRouteRule) DeepCopy() *ProxyRouteRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyRouteRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyRouteRuleStatus) DeepCopyInto(out *ProxyRouteRuleStatus) {
+	*out = *in
+	if in.Stores != nil {
+		in, out := &in.Stores, &out.Stores
+		*out = make([]ProxyRouteStore, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyRouteRuleStatus.
+func (in *ProxyRouteRuleStatus) DeepCopy() *ProxyRouteRuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyRouteRuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyRouteSpec) DeepCopyInto(out *ProxyRouteSpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ProxyRouteRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyRouteSpec.
+func (in *ProxyRouteSpec) DeepCopy() *ProxyRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyRouteStatus) DeepCopyInto(out *ProxyRouteStatus) {
+	*out = *in
+	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ProxyRouteRuleStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyRouteStatus.
+func (in *ProxyRouteStatus) DeepCopy() *ProxyRouteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyRouteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyRouteStore) DeepCopyInto(out *ProxyRouteStore) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyRouteStore.
+func (in *ProxyRouteStore) DeepCopy() *ProxyRouteStore {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyRouteStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxySet) DeepCopyInto(out *ProxySet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Deps.DeepCopyInto(&out.Deps)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxySet.
+func (in *ProxySet) DeepCopy() *ProxySet {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxySet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProxySet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxySetDeps) DeepCopyInto(out *ProxySetDeps) {
+	*out = *in
+	in.LogSetRef.DeepCopyInto(&out.LogSetRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxySetDeps.
+func (in *ProxySetDeps) DeepCopy() *ProxySetDeps {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxySetDeps)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxySetList) DeepCopyInto(out *ProxySetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProxySet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxySetList.
+func (in *ProxySetList) DeepCopy() *ProxySetList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxySetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProxySetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxySetSpec) DeepCopyInto(out *ProxySetSpec) {
+	*out = *in
+	in.PodSet.DeepCopyInto(&out.PodSet)
+	if in.ServiceAnnotations != nil {
+		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodePort != nil {
+		in, out := &in.NodePort, &out.NodePort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Exposure != nil {
+		in, out := &in.Exposure, &out.Exposure
+		*out = new(SQLExposure)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WaitPluginAddr != nil {
+		in, out := &in.WaitPluginAddr, &out.WaitPluginAddr
+		*out = new(string)
+		**out = **in
+	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = new(ProxyPlugin)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DrainTimeout != nil {
+		in, out := &in.DrainTimeout, &out.DrainTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ProxyAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxySetSpec.
+func (in *ProxySetSpec) DeepCopy() *ProxySetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxySetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxySetStatus) DeepCopyInto(out *ProxySetStatus) {
+	*out = *in
+	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
+	if in.Draining != nil {
+		in, out := &in.Draining, &out.Draining
+		*out = make([]ProxyDrainingStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ProxyAutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxySetStatus.
+func (in *ProxySetStatus) DeepCopy() *ProxySetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxySetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PythonUdfSidecar) DeepCopyInto(out *PythonUdfSidecar) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Overlay != nil {
+		in, out := &in.Overlay, &out.Overlay
+		*out = new(MainContainerOverlay)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PythonUdfSidecar.
+func (in *PythonUdfSidecar) DeepCopy() *PythonUdfSidecar {
+	if in == nil {
+		return nil
+	}
+	out := new(PythonUdfSidecar)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadableStatus) DeepCopyInto(out *ReadableStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadableStatus.
+func (in *ReadableStatus) DeepCopy() *ReadableStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadableStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreJob) DeepCopyInto(out *RestoreJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	if in.Overlay != nil {
+		in, out := &in.Overlay, &out.Overlay
+		*out = new(Overlay)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreJob.
+func (in *RestoreJob) DeepCopy() *RestoreJob {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RestoreJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreJobList) DeepCopyInto(out *RestoreJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RestoreJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreJobList.
+func (in *RestoreJobList) DeepCopy() *RestoreJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RestoreJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreJobSpec) DeepCopyInto(out *RestoreJobSpec) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ExternalSource != nil {
+		in, out := &in.ExternalSource, &out.ExternalSource
+		*out = new(SharedStorageProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PointInTime != nil {
+		in, out := &in.PointInTime, &out.PointInTime
+		*out = new(PointInTimeRestore)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Target.DeepCopyInto(&out.Target)
+	if in.Decryption != nil {
+		in, out := &in.Decryption, &out.Decryption
+		*out = new(BackupEncryption)
+		**out = **in
+	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ActiveDeadline != nil {
+		in, out := &in.ActiveDeadline, &out.ActiveDeadline
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreJobSpec.
+func (in *RestoreJobSpec) DeepCopy() *RestoreJobSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Overlay)
+	out := new(RestoreJobSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodSet) DeepCopyInto(out *PodSet) {
+func (in *RestoreJobStatus) DeepCopyInto(out *RestoreJobStatus) {
 	*out = *in
-	in.MainContainer.DeepCopyInto(&out.MainContainer)
-	if in.Overlay != nil {
-		in, out := &in.Overlay, &out.Overlay
-		*out = new(Overlay)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.TopologyEvenSpread != nil {
-		in, out := &in.TopologyEvenSpread, &out.TopologyEvenSpread
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
+	in.JobAttemptStatus.DeepCopyInto(&out.JobAttemptStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreJobStatus.
+func (in *RestoreJobStatus) DeepCopy() *RestoreJobStatus {
+	if in == nil {
+		return nil
 	}
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = (*in).DeepCopy()
+	out := new(RestoreJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int32)
+		**out = **in
 	}
-	if in.DNSBasedIdentity != nil {
-		in, out := &in.DNSBasedIdentity, &out.DNSBasedIdentity
-		*out = new(bool)
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(v1.Duration)
 		**out = **in
 	}
-	if in.ServiceArgs != nil {
-		in, out := &in.ServiceArgs, &out.ServiceArgs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
 	}
-	if in.MemoryLimitPercent != nil {
-		in, out := &in.MemoryLimitPercent, &out.MemoryLimitPercent
-		*out = new(int)
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingUpdateStrategy) DeepCopyInto(out *RollingUpdateStrategy) {
+	*out = *in
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
 		**out = **in
 	}
-	if in.ExportToPrometheus != nil {
-		in, out := &in.ExportToPrometheus, &out.ExportToPrometheus
-		*out = new(bool)
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
 		**out = **in
 	}
-	if in.PromDiscoveryScheme != nil {
-		in, out := &in.PromDiscoveryScheme, &out.PromDiscoveryScheme
-		*out = new(PromDiscoveryScheme)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateStrategy.
+func (in *RollingUpdateStrategy) DeepCopy() *RollingUpdateStrategy {
+	if in == nil {
+		return nil
 	}
-	if in.SemanticVersion != nil {
-		in, out := &in.SemanticVersion, &out.SemanticVersion
-		*out = new(string)
+	out := new(RollingUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3Provider) DeepCopyInto(out *S3Provider) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(S3ProviderType)
 		**out = **in
 	}
-	if in.OperatorVersion != nil {
-		in, out := &in.OperatorVersion, &out.OperatorVersion
-		*out = new(string)
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
-	if in.MemoryFsSize != nil {
-		in, out := &in.MemoryFsSize, &out.MemoryFsSize
-		x := (*in).DeepCopy()
-		*out = &x
+	if in.WebIdentity != nil {
+		in, out := &in.WebIdentity, &out.WebIdentity
+		*out = new(S3WebIdentity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertificateRef != nil {
+		in, out := &in.CertificateRef, &out.CertificateRef
+		*out = new(CertificateRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3RetentionPolicy != nil {
+		in, out := &in.S3RetentionPolicy, &out.S3RetentionPolicy
+		*out = new(PVCRetentionPolicy)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSet.
-func (in *PodSet) DeepCopy() *PodSet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Provider.
+func (in *S3Provider) DeepCopy() *S3Provider {
 	if in == nil {
 		return nil
 	}
-	out := new(PodSet)
+	out := new(S3Provider)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PoolScaleStrategy) DeepCopyInto(out *PoolScaleStrategy) {
+func (in *S3WebIdentity) DeepCopyInto(out *S3WebIdentity) {
 	*out = *in
-	if in.MaxPods != nil {
-		in, out := &in.MaxPods, &out.MaxPods
-		*out = new(int32)
+	if in.ExpirationSeconds != nil {
+		in, out := &in.ExpirationSeconds, &out.ExpirationSeconds
+		*out = new(int64)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolScaleStrategy.
-func (in *PoolScaleStrategy) DeepCopy() *PoolScaleStrategy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3WebIdentity.
+func (in *S3WebIdentity) DeepCopy() *S3WebIdentity {
 	if in == nil {
 		return nil
 	}
-	out := new(PoolScaleStrategy)
+	out := new(S3WebIdentity)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PoolStrategy) DeepCopyInto(out *PoolStrategy) {
+func (in *SQLAssertion) DeepCopyInto(out *SQLAssertion) {
 	*out = *in
-	in.UpdateStrategy.DeepCopyInto(&out.UpdateStrategy)
-	in.ScaleStrategy.DeepCopyInto(&out.ScaleStrategy)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolStrategy.
-func (in *PoolStrategy) DeepCopy() *PoolStrategy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLAssertion.
+func (in *SQLAssertion) DeepCopy() *SQLAssertion {
 	if in == nil {
 		return nil
 	}
-	out := new(PoolStrategy)
+	out := new(SQLAssertion)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PoolUpdateStrategy) DeepCopyInto(out *PoolUpdateStrategy) {
+func (in *SQLAssertionResult) DeepCopyInto(out *SQLAssertionResult) {
 	*out = *in
-	if in.ReclaimTimeout != nil {
-		in, out := &in.ReclaimTimeout, &out.ReclaimTimeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLAssertionResult.
+func (in *SQLAssertionResult) DeepCopy() *SQLAssertionResult {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLAssertionResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLExposure) DeepCopyInto(out *SQLExposure) {
+	*out = *in
+	if in.TCPRoute != nil {
+		in, out := &in.TCPRoute, &out.TCPRoute
+		*out = new(GatewayRouteExposure)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLExposure.
+func (in *SQLExposure) DeepCopy() *SQLExposure {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLExposure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingConfig) DeepCopyInto(out *ScalingConfig) {
+	*out = *in
+	if in.StoreDrainEnabled != nil {
+		in, out := &in.StoreDrainEnabled, &out.StoreDrainEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StoreDrainTimeout != nil {
+		in, out := &in.StoreDrainTimeout, &out.StoreDrainTimeout
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.MinDelaySeconds != nil {
+		in, out := &in.MinDelaySeconds, &out.MinDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolUpdateStrategy.
-func (in *PoolUpdateStrategy) DeepCopy() *PoolUpdateStrategy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingConfig.
+func (in *ScalingConfig) DeepCopy() *ScalingConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(PoolUpdateStrategy)
+	out := new(ScalingConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxySet) DeepCopyInto(out *ProxySet) {
+func (in *SharedStorageCache) DeepCopyInto(out *SharedStorageCache) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Deps.DeepCopyInto(&out.Deps)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.MemoryCacheSize != nil {
+		in, out := &in.MemoryCacheSize, &out.MemoryCacheSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.DiskCacheSize != nil {
+		in, out := &in.DiskCacheSize, &out.DiskCacheSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxySet.
-func (in *ProxySet) DeepCopy() *ProxySet {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedStorageCache.
+func (in *SharedStorageCache) DeepCopy() *SharedStorageCache {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxySet)
+	out := new(SharedStorageCache)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProxySet) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SharedStorageProvider) DeepCopyInto(out *SharedStorageProvider) {
+	*out = *in
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3Provider)
+		(*in).DeepCopyInto(*out)
 	}
-	return nil
+	if in.FileSystem != nil {
+		in, out := &in.FileSystem, &out.FileSystem
+		*out = new(FileSystemProvider)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedStorageProvider.
+func (in *SharedStorageProvider) DeepCopy() *SharedStorageProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(SharedStorageProvider)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxySetDeps) DeepCopyInto(out *ProxySetDeps) {
+func (in *SnapshotObject) DeepCopyInto(out *SnapshotObject) {
 	*out = *in
-	in.LogSetRef.DeepCopyInto(&out.LogSetRef)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxySetDeps.
-func (in *ProxySetDeps) DeepCopy() *ProxySetDeps {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotObject.
+func (in *SnapshotObject) DeepCopy() *SnapshotObject {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxySetDeps)
+	out := new(SnapshotObject)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxySetList) DeepCopyInto(out *ProxySetList) {
+func (in *SnapshotRecord) DeepCopyInto(out *SnapshotRecord) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ProxySet, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.CreatedTime.DeepCopyInto(&out.CreatedTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRecord.
+func (in *SnapshotRecord) DeepCopy() *SnapshotRecord {
+	if in == nil {
+		return nil
 	}
+	out := new(SnapshotRecord)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxySetList.
-func (in *ProxySetList) DeepCopy() *ProxySetList {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotRestore) DeepCopyInto(out *SnapshotRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRestore.
+func (in *SnapshotRestore) DeepCopy() *SnapshotRestore {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxySetList)
+	out := new(SnapshotRestore)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ProxySetList) DeepCopyObject() runtime.Object {
+func (in *SnapshotRestore) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2130,116 +4123,94 @@ func (in *ProxySetList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxySetSpec) DeepCopyInto(out *ProxySetSpec) {
+func (in *SnapshotRestoreList) DeepCopyInto(out *SnapshotRestoreList) {
 	*out = *in
-	in.PodSet.DeepCopyInto(&out.PodSet)
-	if in.ServiceAnnotations != nil {
-		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SnapshotRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.NodePort != nil {
-		in, out := &in.NodePort, &out.NodePort
-		*out = new(int32)
-		**out = **in
-	}
-	if in.WaitPluginAddr != nil {
-		in, out := &in.WaitPluginAddr, &out.WaitPluginAddr
-		*out = new(string)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxySetSpec.
-func (in *ProxySetSpec) DeepCopy() *ProxySetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRestoreList.
+func (in *SnapshotRestoreList) DeepCopy() *SnapshotRestoreList {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxySetSpec)
+	out := new(SnapshotRestoreList)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxySetStatus) DeepCopyInto(out *ProxySetStatus) {
-	*out = *in
-	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxySetStatus.
-func (in *ProxySetStatus) DeepCopy() *ProxySetStatus {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SnapshotRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(ProxySetStatus)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PythonUdfSidecar) DeepCopyInto(out *PythonUdfSidecar) {
+func (in *SnapshotRestoreSpec) DeepCopyInto(out *SnapshotRestoreSpec) {
 	*out = *in
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.Overlay != nil {
-		in, out := &in.Overlay, &out.Overlay
-		*out = new(MainContainerOverlay)
-		(*in).DeepCopyInto(*out)
-	}
+	out.SnapshotObject = in.SnapshotObject
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PythonUdfSidecar.
-func (in *PythonUdfSidecar) DeepCopy() *PythonUdfSidecar {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRestoreSpec.
+func (in *SnapshotRestoreSpec) DeepCopy() *SnapshotRestoreSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PythonUdfSidecar)
+	out := new(SnapshotRestoreSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReadableStatus) DeepCopyInto(out *ReadableStatus) {
+func (in *SnapshotRestoreStatus) DeepCopyInto(out *SnapshotRestoreStatus) {
 	*out = *in
+	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadableStatus.
-func (in *ReadableStatus) DeepCopy() *ReadableStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRestoreStatus.
+func (in *SnapshotRestoreStatus) DeepCopy() *SnapshotRestoreStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ReadableStatus)
+	out := new(SnapshotRestoreStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RestoreJob) DeepCopyInto(out *RestoreJob) {
+func (in *SnapshotSchedule) DeepCopyInto(out *SnapshotSchedule) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
-	if in.Overlay != nil {
-		in, out := &in.Overlay, &out.Overlay
-		*out = new(Overlay)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreJob.
-func (in *RestoreJob) DeepCopy() *RestoreJob {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotSchedule.
+func (in *SnapshotSchedule) DeepCopy() *SnapshotSchedule {
 	if in == nil {
 		return nil
 	}
-	out := new(RestoreJob)
+	out := new(SnapshotSchedule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RestoreJob) DeepCopyObject() runtime.Object {
+func (in *SnapshotSchedule) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2247,31 +4218,31 @@ func (in *RestoreJob) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RestoreJobList) DeepCopyInto(out *RestoreJobList) {
+func (in *SnapshotScheduleList) DeepCopyInto(out *SnapshotScheduleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]RestoreJob, len(*in))
+		*out = make([]SnapshotSchedule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreJobList.
-func (in *RestoreJobList) DeepCopy() *RestoreJobList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotScheduleList.
+func (in *SnapshotScheduleList) DeepCopy() *SnapshotScheduleList {
 	if in == nil {
 		return nil
 	}
-	out := new(RestoreJobList)
+	out := new(SnapshotScheduleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RestoreJobList) DeepCopyObject() runtime.Object {
+func (in *SnapshotScheduleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -2279,183 +4250,249 @@ func (in *RestoreJobList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RestoreJobSpec) DeepCopyInto(out *RestoreJobSpec) {
+func (in *SnapshotScheduleSpec) DeepCopyInto(out *SnapshotScheduleSpec) {
 	*out = *in
-	if in.TTL != nil {
-		in, out := &in.TTL, &out.TTL
-		*out = new(v1.Duration)
+	out.SnapshotObject = in.SnapshotObject
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
 		**out = **in
 	}
-	if in.ExternalSource != nil {
-		in, out := &in.ExternalSource, &out.ExternalSource
-		*out = new(SharedStorageProvider)
-		(*in).DeepCopyInto(*out)
-	}
-	in.Target.DeepCopyInto(&out.Target)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreJobSpec.
-func (in *RestoreJobSpec) DeepCopy() *RestoreJobSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotScheduleSpec.
+func (in *SnapshotScheduleSpec) DeepCopy() *SnapshotScheduleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RestoreJobSpec)
+	out := new(SnapshotScheduleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RestoreJobStatus) DeepCopyInto(out *RestoreJobStatus) {
+func (in *SnapshotScheduleStatus) DeepCopyInto(out *SnapshotScheduleStatus) {
 	*out = *in
 	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = make([]SnapshotRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreJobStatus.
-func (in *RestoreJobStatus) DeepCopy() *RestoreJobStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotScheduleStatus.
+func (in *SnapshotScheduleStatus) DeepCopy() *SnapshotScheduleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RestoreJobStatus)
+	out := new(SnapshotScheduleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RollingUpdateStrategy) DeepCopyInto(out *RollingUpdateStrategy) {
+func (in *StorageMigration) DeepCopyInto(out *StorageMigration) {
 	*out = *in
-	if in.MaxSurge != nil {
-		in, out := &in.MaxSurge, &out.MaxSurge
-		*out = new(intstr.IntOrString)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageMigration.
+func (in *StorageMigration) DeepCopy() *StorageMigration {
+	if in == nil {
+		return nil
 	}
-	if in.MaxUnavailable != nil {
-		in, out := &in.MaxUnavailable, &out.MaxUnavailable
-		*out = new(intstr.IntOrString)
-		**out = **in
+	out := new(StorageMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StorageMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
+	return nil
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateStrategy.
-func (in *RollingUpdateStrategy) DeepCopy() *RollingUpdateStrategy {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageMigrationCopyStatus) DeepCopyInto(out *StorageMigrationCopyStatus) {
+	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageMigrationCopyStatus.
+func (in *StorageMigrationCopyStatus) DeepCopy() *StorageMigrationCopyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RollingUpdateStrategy)
+	out := new(StorageMigrationCopyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *S3Provider) DeepCopyInto(out *S3Provider) {
+func (in *StorageMigrationList) DeepCopyInto(out *StorageMigrationList) {
 	*out = *in
-	if in.Type != nil {
-		in, out := &in.Type, &out.Type
-		*out = new(S3ProviderType)
-		**out = **in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]StorageMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageMigrationList.
+func (in *StorageMigrationList) DeepCopy() *StorageMigrationList {
+	if in == nil {
+		return nil
 	}
-	if in.CertificateRef != nil {
-		in, out := &in.CertificateRef, &out.CertificateRef
-		*out = new(CertificateRef)
-		(*in).DeepCopyInto(*out)
+	out := new(StorageMigrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StorageMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	if in.S3RetentionPolicy != nil {
-		in, out := &in.S3RetentionPolicy, &out.S3RetentionPolicy
-		*out = new(PVCRetentionPolicy)
-		**out = **in
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageMigrationProgress) DeepCopyInto(out *StorageMigrationProgress) {
+	*out = *in
+	if in.UpdateTime != nil {
+		in, out := &in.UpdateTime, &out.UpdateTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3Provider.
-func (in *S3Provider) DeepCopy() *S3Provider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageMigrationProgress.
+func (in *StorageMigrationProgress) DeepCopy() *StorageMigrationProgress {
 	if in == nil {
 		return nil
 	}
-	out := new(S3Provider)
+	out := new(StorageMigrationProgress)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScalingConfig) DeepCopyInto(out *ScalingConfig) {
+func (in *StorageMigrationSpec) DeepCopyInto(out *StorageMigrationSpec) {
 	*out = *in
-	if in.StoreDrainEnabled != nil {
-		in, out := &in.StoreDrainEnabled, &out.StoreDrainEnabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.StoreDrainTimeout != nil {
-		in, out := &in.StoreDrainTimeout, &out.StoreDrainTimeout
-		*out = new(v1.Duration)
-		**out = **in
-	}
-	if in.MinDelaySeconds != nil {
-		in, out := &in.MinDelaySeconds, &out.MinDelaySeconds
+	in.Target.DeepCopyInto(&out.Target)
+	if in.Parallelism != nil {
+		in, out := &in.Parallelism, &out.Parallelism
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Overlay != nil {
+		in, out := &in.Overlay, &out.Overlay
+		*out = new(Overlay)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingConfig.
-func (in *ScalingConfig) DeepCopy() *ScalingConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageMigrationSpec.
+func (in *StorageMigrationSpec) DeepCopy() *StorageMigrationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ScalingConfig)
+	out := new(StorageMigrationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SharedStorageCache) DeepCopyInto(out *SharedStorageCache) {
+func (in *StorageMigrationStatus) DeepCopyInto(out *StorageMigrationStatus) {
 	*out = *in
-	if in.MemoryCacheSize != nil {
-		in, out := &in.MemoryCacheSize, &out.MemoryCacheSize
-		x := (*in).DeepCopy()
-		*out = &x
+	in.ConditionalStatus.DeepCopyInto(&out.ConditionalStatus)
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(SharedStorageProvider)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.DiskCacheSize != nil {
-		in, out := &in.DiskCacheSize, &out.DiskCacheSize
-		x := (*in).DeepCopy()
-		*out = &x
+	if in.PreCopy != nil {
+		in, out := &in.PreCopy, &out.PreCopy
+		*out = new(StorageMigrationCopyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FinalSync != nil {
+		in, out := &in.FinalSync, &out.FinalSync
+		*out = new(StorageMigrationCopyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(StorageMigrationVerification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(StorageMigrationProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.QuiesceTime != nil {
+		in, out := &in.QuiesceTime, &out.QuiesceTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedStorageCache.
-func (in *SharedStorageCache) DeepCopy() *SharedStorageCache {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageMigrationStatus.
+func (in *StorageMigrationStatus) DeepCopy() *StorageMigrationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SharedStorageCache)
+	out := new(StorageMigrationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SharedStorageProvider) DeepCopyInto(out *SharedStorageProvider) {
+func (in *StorageMigrationVerification) DeepCopyInto(out *StorageMigrationVerification) {
 	*out = *in
-	if in.S3 != nil {
-		in, out := &in.S3, &out.S3
-		*out = new(S3Provider)
-		(*in).DeepCopyInto(*out)
+	if in.MismatchedKeys != nil {
+		in, out := &in.MismatchedKeys, &out.MismatchedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.FileSystem != nil {
-		in, out := &in.FileSystem, &out.FileSystem
-		*out = new(FileSystemProvider)
-		**out = **in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedStorageProvider.
-func (in *SharedStorageProvider) DeepCopy() *SharedStorageProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageMigrationVerification.
+func (in *StorageMigrationVerification) DeepCopy() *StorageMigrationVerification {
 	if in == nil {
 		return nil
 	}
-	out := new(SharedStorageProvider)
+	out := new(StorageMigrationVerification)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2586,6 +4623,11 @@ func (in *WebUIList) DeepCopyObject() runtime.Object {
 func (in *WebUISpec) DeepCopyInto(out *WebUISpec) {
 	*out = *in
 	in.PodSet.DeepCopyInto(&out.PodSet)
+	if in.Exposure != nil {
+		in, out := &in.Exposure, &out.Exposure
+		*out = new(HTTPExposure)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.UpdateStrategy != nil {
 		in, out := &in.UpdateStrategy, &out.UpdateStrategy
 		*out = new(RollingUpdateStrategy)
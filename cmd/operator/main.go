@@ -36,6 +36,7 @@ import (
 	"github.com/matrixorigin/matrixone-operator/pkg/controllers/bucketclaim"
 	"github.com/matrixorigin/matrixone-operator/pkg/controllers/cnstore"
 	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/proxyroute"
 	"github.com/matrixorigin/matrixone-operator/pkg/controllers/proxyset"
 	"github.com/matrixorigin/matrixone/pkg/logutil"
 	kruisev1alpha1 "github.com/openkruise/kruise-api/apps/v1alpha1"
@@ -183,11 +184,13 @@ func main() {
 		})
 	}
 
-	logSetActor := &logset.Actor{FailoverEnabled: failover}
+	haCliMgr := mocli.NewManager(mgr.GetClient(), zapLogger.Named("mocli-manager"))
+
+	logSetActor := &logset.Actor{FailoverEnabled: failover, ClientMgr: haCliMgr}
 	err = logSetActor.Reconcile(mgr)
 	exitIf(err, "unable to set up log service controller")
 
-	dnSetActor := &dnset.Actor{}
+	dnSetActor := &dnset.Actor{FailoverEnabled: failover, ClientMgr: haCliMgr}
 	err = dnSetActor.Reconcile(mgr)
 	exitIf(err, "unable to set up dn service controller")
 
@@ -204,14 +207,50 @@ func main() {
 	exitIf(err, "unable to set up matrixone cluster controller")
 
 	if features.DefaultFeatureGate.Enabled(features.BRSupport) {
-		backupActor := br.NewBackupActor(operatorCfg.BRConfig.Image)
+		backupActor := br.NewBackupActor(operatorCfg.BRConfig.Image, operatorCfg.BRConfig.GetStorageToolImage())
 		err = backupActor.Reconcile(mgr)
 		exitIf(err, "unable to setup backup actor")
 
-		restoreActor := br.NewRestoreActor(operatorCfg.BRConfig.Image)
+		scheduleActor := &br.ScheduleActor{}
+		err = scheduleActor.Reconcile(mgr)
+		exitIf(err, "unable to setup backup schedule actor")
+
+		cleanupActor := br.NewCleanupActor(operatorCfg.BRConfig.Image, operatorCfg.BRConfig.GetStorageToolImage())
+		err = cleanupActor.Reconcile(mgr)
+		exitIf(err, "unable to setup backup cleanup actor")
+
+		restoreActor := br.NewRestoreActor(operatorCfg.BRConfig.Image, operatorCfg.BRConfig.GetStorageToolImage(), operatorCfg.BRConfig.GetDumpImage())
 		err = restoreActor.Reconcile(mgr)
 		exitIf(err, "unable to setup restore actor")
 
+		repositoryActor := br.NewRepositoryActor(operatorCfg.BRConfig.Image, operatorCfg.BRConfig.GetStorageToolImage())
+		err = repositoryActor.Reconcile(mgr)
+		exitIf(err, "unable to setup backup repository actor")
+
+		verificationActor := &br.VerificationActor{}
+		err = verificationActor.Reconcile(mgr)
+		exitIf(err, "unable to setup backup verification actor")
+
+		snapshotScheduleActor := &br.SnapshotScheduleActor{}
+		err = snapshotScheduleActor.Reconcile(mgr)
+		exitIf(err, "unable to setup snapshot schedule actor")
+
+		snapshotRestoreActor := br.NewSnapshotRestoreActor(operatorCfg.BRConfig.GetDumpImage())
+		err = snapshotRestoreActor.Reconcile(mgr)
+		exitIf(err, "unable to setup snapshot restore actor")
+
+		logicalBackupActor := br.NewLogicalBackupActor(operatorCfg.BRConfig.GetDumpImage())
+		err = logicalBackupActor.Reconcile(mgr)
+		exitIf(err, "unable to setup logical backup actor")
+
+		logicalRestoreActor := br.NewLogicalRestoreActor(operatorCfg.BRConfig.GetDumpImage())
+		err = logicalRestoreActor.Reconcile(mgr)
+		exitIf(err, "unable to setup logical restore actor")
+
+		storageMigrationActor := br.NewStorageMigrationActor(operatorCfg.BRConfig.GetDumpImage())
+		err = storageMigrationActor.Reconcile(mgr)
+		exitIf(err, "unable to setup storage migration actor")
+
 		backupGC := &br.GCActor[*v1alpha1.BackupJob]{
 			ConditionType: v1alpha1.JobConditionTypeEnded,
 		}
@@ -223,6 +262,18 @@ func main() {
 		}
 		err = br.StartJobGCer(mgr, restoreGC, &v1alpha1.RestoreJob{})
 		exitIf(err, "unable to setup restore GCer")
+
+		logicalBackupGC := &br.GCActor[*v1alpha1.LogicalBackupJob]{
+			ConditionType: v1alpha1.JobConditionTypeEnded,
+		}
+		err = br.StartJobGCer(mgr, logicalBackupGC, &v1alpha1.LogicalBackupJob{})
+		exitIf(err, "unable to setup logical backup GCer")
+
+		logicalRestoreGC := &br.GCActor[*v1alpha1.LogicalRestoreJob]{
+			ConditionType: v1alpha1.JobConditionTypeEnded,
+		}
+		err = br.StartJobGCer(mgr, logicalRestoreGC, &v1alpha1.LogicalRestoreJob{})
+		exitIf(err, "unable to setup logical restore GCer")
 	}
 
 	if features.DefaultFeatureGate.Enabled(features.ProxySupport) {
@@ -236,6 +287,7 @@ func main() {
 	if features.DefaultFeatureGate.Enabled(features.S3Reclaim) {
 		bucketActor := bucketclaim.New(
 			bucketclaim.WithImage(operatorCfg.BucketCleanJob.Image),
+			bucketclaim.WithUsageInterval(operatorCfg.BucketCleanJob.UsageInterval),
 		)
 		err = bucketActor.Reconcile(mgr)
 		exitIf(err, "unable to set up bucketclaim cluster controller")
@@ -245,7 +297,6 @@ func main() {
 
 	qc, err := querycli.New()
 	exitIf(err, "unable to create query client")
-	haCliMgr := mocli.NewManager(mgr.GetClient(), zapLogger.Named("mocli-manager"))
 	if features.DefaultFeatureGate.Enabled(features.CNLabel) {
 		cnLabelController := cnstore.NewController(haCliMgr, qc)
 		err = cnLabelController.Reconcile(mgr)
@@ -254,6 +305,14 @@ func main() {
 		setupLog.Info(fmt.Sprintf("cn label not enabled, skip setup cnlabel"))
 	}
 
+	if features.DefaultFeatureGate.Enabled(features.ProxySupport) && features.DefaultFeatureGate.Enabled(features.CNLabel) {
+		routeActor := &proxyroute.Actor{ClientMgr: haCliMgr}
+		err = routeActor.Reconcile(mgr)
+		exitIf(err, "unable to set up proxyroute controller")
+	} else {
+		setupLog.Info(fmt.Sprintf("proxy support or cn label not enabled, skip setup proxyroute actor"))
+	}
+
 	if features.DefaultFeatureGate.Enabled(features.CNPool) {
 		if !features.DefaultFeatureGate.Enabled(features.CNLabel) {
 			panic("cn label must be enabled when cn pool is enabled")
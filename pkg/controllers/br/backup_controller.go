@@ -16,6 +16,7 @@ package br
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -31,21 +32,24 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	manager "sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
 const (
-	pollInterval = 15 * time.Second
+	pollInterval        = 15 * time.Second
+	catalogPollInterval = 5 * time.Second
 )
 
 type BackupActor struct {
 	backupImage string
+	toolImage   string
 }
 
-func NewBackupActor(image string) *BackupActor {
-	return &BackupActor{backupImage: image}
+func NewBackupActor(image string, toolImage string) *BackupActor {
+	return &BackupActor{backupImage: image, toolImage: toolImage}
 }
 
 var _ recon.Actor[*v1alpha1.BackupJob] = &BackupActor{}
@@ -75,7 +79,10 @@ func (c *BackupActor) waitJob(ctx *recon.Context[*v1alpha1.BackupJob]) error {
 		return errors.WrapPrefix(err, "error list owned backups", 0)
 	}
 	if len(backupList.Items) > 0 {
-		return c.completeBackup(ctx, &backupList.Items[0])
+		return c.publishCatalog(ctx, &backupList.Items[0])
+	}
+	if bj.Status.DeadlineExceeded(bj.Spec.ActiveDeadline, time.Now()) {
+		return c.failDeadlineExceeded(ctx)
 	}
 
 	job := &batchv1.Job{}
@@ -86,7 +93,7 @@ func (c *BackupActor) waitJob(ctx *recon.Context[*v1alpha1.BackupJob]) error {
 		return errors.WrapPrefix(err, "error get backup job", 0)
 	}
 	if job.Status.Failed > 0 {
-		return c.failBackup(ctx, "backup job is failed")
+		return c.retryOrFail(ctx, "backup job is failed")
 	}
 
 	svc := buildSvc(bj)
@@ -100,6 +107,7 @@ func (c *BackupActor) waitJob(ctx *recon.Context[*v1alpha1.BackupJob]) error {
 			Status: metav1.ConditionFalse,
 			Reason: "JobRunning",
 		})
+		bj.Status.Progress = backupProgress(job)
 		return recon.ErrReSync("wait backup complete", pollInterval)
 	}
 	// backup succeed
@@ -109,51 +117,276 @@ func (c *BackupActor) waitJob(ctx *recon.Context[*v1alpha1.BackupJob]) error {
 			return errors.Errorf("error parse backup id from stdout: %s", status.Stdout)
 		}
 		raw := strings.Trim(strings.Trim(parts[1], " "), "\n")
-		id := strings.SplitN(raw, ",", 2)[0]
+		info, err := parseBackupMeta(raw)
+		if err != nil {
+			return err
+		}
+		id := info.ID
+		atTime, completeTime := metav1.Now(), metav1.Now()
+		if info.AtTime != nil {
+			atTime = metav1.NewTime(*info.AtTime)
+		}
+		if info.CompleteTime != nil {
+			completeTime = metav1.NewTime(*info.CompleteTime)
+		}
+		version := info.Version
+		if version == "" {
+			version = c.sourceVersion(ctx)
+		}
+		var chain []string
+		if bj.GetBackupType() == v1alpha1.BackupTypeIncremental {
+			parent, err := getParentBackup(ctx, bj)
+			if err != nil {
+				// the parent might be gone while the backup is running
+				return c.failOnChainError(ctx, err)
+			}
+			chain = append(slices.Clone(parent.Meta.Chain), parent.Name)
+		}
 
 		// 1. ensure backup
 		backup := &v1alpha1.Backup{
 			ObjectMeta: metav1.ObjectMeta{
-				Name: fmt.Sprintf("%s-%s", bj.Name, id[:5]),
+				Name:   fmt.Sprintf("%s-%s", bj.Name, id[:backupNameIDLength]),
+				Labels: backupLabels(bj, version),
 			},
 			Meta: v1alpha1.BackupMeta{
-				Location: bj.Spec.Target,
-				ID:       id,
-				// TODO: get AtTime from backup Meta
-				AtTime:       metav1.Now(),
-				CompleteTime: metav1.Now(),
+				Location:     bj.Spec.Target,
+				ID:           id,
+				Size:         info.Size,
+				AtTime:       atTime,
+				CompleteTime: completeTime,
 				SourceRef:    bj.GetSourceRef(),
+				Version:      version,
+				Type:         bj.GetBackupType(),
+				Encryption:   bj.Spec.Encryption,
+				Chain:        chain,
 				Raw:          raw,
 			},
+			DeletionPolicy: bj.Spec.DeletionPolicy,
 		}
 		if err := util.Ignore(apierrors.IsAlreadyExists, ctx.Create(backup)); err != nil {
 			return errors.WrapPrefix(err, "error ensure backup", 0)
 		}
-		return c.completeBackup(ctx, backup)
+		return c.publishCatalog(ctx, backup)
+	}
+
+	return c.retryOrFail(ctx, status.Stderr)
+}
+
+// backupProgress returns the progress of the running backup job, mo_br reports no copy progress
+// so only the elapsed time is known
+func backupProgress(job *batchv1.Job) *v1alpha1.BackupProgress {
+	progress := &v1alpha1.BackupProgress{}
+	if job.Status.StartTime != nil {
+		progress.Elapsed = &metav1.Duration{Duration: time.Since(job.Status.StartTime.Time).Truncate(time.Second)}
+	}
+	return progress
+}
+
+// sourceVersion returns the MO version of the backup source, empty if unknown
+func (c *BackupActor) sourceVersion(ctx *recon.Context[*v1alpha1.BackupJob]) string {
+	bj := ctx.Obj
+	if bj.Spec.Source.ClusterRef == nil {
+		return ""
 	}
+	mo := &v1alpha1.MatrixOneCluster{}
+	if err := ctx.Get(types.NamespacedName{Namespace: bj.Namespace, Name: *bj.Spec.Source.ClusterRef}, mo); err != nil {
+		ctx.Log.Info("cannot resolve the version of the backup source", "error", err.Error())
+		return ""
+	}
+	return mo.Spec.Version
+}
 
-	return c.failBackup(ctx, status.Stderr)
+// backupLabels returns the labels of the backup produced by the backup job, the labels track
+// the backup job, the schedule that the backup job belongs to, the source and the version of the backup
+func backupLabels(bj *v1alpha1.BackupJob, version string) map[string]string {
+	labels := map[string]string{
+		common.PreNameLabelKey:               bj.Name,
+		common.PreUUIDLabelKey:               string(bj.UID),
+		common.BackupSourceNamespaceLabelKey: bj.Namespace,
+	}
+	if ref := bj.Spec.Source.ClusterRef; ref != nil {
+		labels[common.BackupSourceKindLabelKey] = "matrixonecluster"
+		labels[common.BackupSourceLabelKey] = *ref
+	} else if ref := bj.Spec.Source.CNSetRef; ref != nil {
+		labels[common.BackupSourceKindLabelKey] = "cnset"
+		labels[common.BackupSourceLabelKey] = *ref
+	}
+	if version != "" && len(validation.IsValidLabelValue(version)) == 0 {
+		labels[common.BackupVersionLabelKey] = version
+	}
+	if schedule, ok := bj.Labels[common.BackupScheduleLabelKey]; ok {
+		labels[common.BackupScheduleLabelKey] = schedule
+		labels[common.BackupScheduleNamespaceLabelKey] = bj.Namespace
+	}
+	return labels
 }
 
 func (c *BackupActor) failBackup(ctx *recon.Context[*v1alpha1.BackupJob], message string) error {
+	return c.endWithFailure(ctx, "JobFailed", message)
+}
+
+func (c *BackupActor) endWithFailure(ctx *recon.Context[*v1alpha1.BackupJob], reason string, message string) error {
 	// note: when backup failed, we keep the job for troubleshooting
 	ctx.Obj.Status.Phase = v1alpha1.JobPhaseFailed
 	meta.SetStatusCondition(&ctx.Obj.Status.Conditions, metav1.Condition{
 		Type:    v1alpha1.JobConditionTypeEnded,
 		Status:  metav1.ConditionTrue,
-		Reason:  "JobFailed",
+		Reason:  reason,
 		Message: message,
 	})
 	return ctx.UpdateStatus(ctx.Obj)
 }
 
-func (c *BackupActor) completeBackup(ctx *recon.Context[*v1alpha1.BackupJob], backup *v1alpha1.Backup) error {
+// failOnChainError fails the backup job if the error is caused by an unusable backup chain, which
+// would otherwise block the later backup jobs of the source forever, other errors are returned as is
+func (c *BackupActor) failOnChainError(ctx *recon.Context[*v1alpha1.BackupJob], err error) error {
+	if isChainError(err) {
+		return c.endWithFailure(ctx, "InvalidParent", err.Error())
+	}
+	return err
+}
+
+// failDeadlineExceeded stops the running attempt and fails the backup job
+func (c *BackupActor) failDeadlineExceeded(ctx *recon.Context[*v1alpha1.BackupJob]) error {
 	bj := ctx.Obj
+	if err := c.deleteJob(ctx, bj.Name); err != nil {
+		return err
+	}
+	return c.endWithFailure(ctx, reasonDeadlineExceeded, fmt.Sprintf("backup job is not completed within the active deadline %s", bj.Spec.ActiveDeadline.Duration))
+}
+
+// retryOrFail retries the failed attempt if allowed by the retry policy, otherwise fails the backup job
+func (c *BackupActor) retryOrFail(ctx *recon.Context[*v1alpha1.BackupJob], message string) error {
+	bj := ctx.Obj
+	if bj.Status.DeadlineExceeded(bj.Spec.ActiveDeadline, time.Now()) {
+		return c.failDeadlineExceeded(ctx)
+	}
+	if !scheduleRetry(bj.Spec.RetryPolicy, &bj.Status.JobAttemptStatus, time.Now()) {
+		return c.failBackup(ctx, message)
+	}
+	// the next attempt starts over with a new job
+	if err := c.deleteJob(ctx, bj.Name); err != nil {
+		return err
+	}
+	ctx.Log.Info("backup attempt failed, retry later", "attempt", bj.Status.Attempts, "nextRetryTime", bj.Status.NextRetryTime)
+	bj.Status.Phase = v1alpha1.JobPhasePending
+	meta.SetStatusCondition(&bj.Status.Conditions, retryCondition(bj.Spec.RetryPolicy, &bj.Status.JobAttemptStatus, message))
+	return ctx.UpdateStatus(bj)
+}
+
+func (c *BackupActor) deleteJob(ctx *recon.Context[*v1alpha1.BackupJob], name string) error {
 	if err := util.Ignore(apierrors.IsNotFound, ctx.Delete(
-		&batchv1.Job{ObjectMeta: common.ObjMetaTemplate(bj, bj.Name)},
+		&batchv1.Job{ObjectMeta: common.ObjMetaTemplate(ctx.Obj, name)},
 		client.PropagationPolicy(metav1.DeletePropagationBackground),
 	)); err != nil {
-		return errors.WrapPrefix(err, "error finalize backup job", 0)
+		return errors.WrapPrefix(err, "error delete job "+name, 0)
+	}
+	return nil
+}
+
+// waitQueue checks whether the backup job must wait for the other backup jobs of the same source,
+// the backup jobs of a source run one at a time in the order of creation
+func (c *BackupActor) waitQueue(ctx *recon.Context[*v1alpha1.BackupJob]) (*v1alpha1.BackupJob, error) {
+	jobList := &v1alpha1.BackupJobList{}
+	if err := ctx.List(jobList, client.InNamespace(ctx.Obj.Namespace)); err != nil {
+		return nil, errors.WrapPrefix(err, "error list backup jobs", 0)
+	}
+	return queuedBehind(ctx.Obj, jobList.Items), nil
+}
+
+// queuedBehind returns the backup job of the same source that the backup job waits for, nil if
+// the backup job can start
+func queuedBehind(bj *v1alpha1.BackupJob, jobs []v1alpha1.BackupJob) *v1alpha1.BackupJob {
+	var earlier *v1alpha1.BackupJob
+	for i := range jobs {
+		other := &jobs[i]
+		if other.Name == bj.Name || other.DeletionTimestamp != nil || other.GetSourceRef() != bj.GetSourceRef() {
+			continue
+		}
+		if other.Status.Phase == v1alpha1.JobPhaseCompleted || other.Status.Phase == v1alpha1.JobPhaseFailed {
+			continue
+		}
+		if other.Status.Phase == v1alpha1.JobPhaseRunning {
+			return other
+		}
+		if earlier == nil && createdBefore(other, bj) {
+			earlier = other
+		}
+	}
+	return earlier
+}
+
+func createdBefore(a, b *v1alpha1.BackupJob) bool {
+	if a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.Name < b.Name
+	}
+	return a.CreationTimestamp.Before(&b.CreationTimestamp)
+}
+
+// publishCatalog publishes the backup to the catalog of the backup location before the backup job
+// completes, a failed publish is reported in the conditions and does not fail the backup job
+func (c *BackupActor) publishCatalog(ctx *recon.Context[*v1alpha1.BackupJob], backup *v1alpha1.Backup) error {
+	bj := ctx.Obj
+	job := &batchv1.Job{}
+	err := ctx.Get(types.NamespacedName{Namespace: bj.Namespace, Name: catalogJobName(bj)}, job)
+	if apierrors.IsNotFound(err) {
+		if err := util.Ignore(apierrors.IsAlreadyExists, ctx.CreateOwned(c.buildCatalogJob(ctx, backup))); err != nil {
+			return errors.WrapPrefix(err, "error create catalog job", 0)
+		}
+		return recon.ErrReSync("wait backup published to catalog", catalogPollInterval)
+	}
+	if err != nil {
+		return errors.WrapPrefix(err, "error get catalog job", 0)
+	}
+	switch {
+	case job.Status.Succeeded > 0:
+		meta.SetStatusCondition(&bj.Status.Conditions, metav1.Condition{
+			Type:   v1alpha1.BackupJobConditionTypeCatalog,
+			Status: metav1.ConditionTrue,
+			Reason: "Published",
+		})
+	case job.Status.Failed > 0:
+		meta.SetStatusCondition(&bj.Status.Conditions, metav1.Condition{
+			Type:    v1alpha1.BackupJobConditionTypeCatalog,
+			Status:  metav1.ConditionFalse,
+			Reason:  "PublishFailed",
+			Message: fmt.Sprintf("catalog job %s failed, the backup cannot be discovered from the storage", job.Name),
+		})
+	default:
+		return recon.ErrReSync("wait backup published to catalog", catalogPollInterval)
+	}
+	return c.completeBackup(ctx, backup)
+}
+
+func (c *BackupActor) buildCatalogJob(ctx *recon.Context[*v1alpha1.BackupJob], backup *v1alpha1.Backup) *batchv1.Job {
+	bj := ctx.Obj
+	raw := backup.Meta.Raw
+	if backup.IsIncremental() {
+		// the chain is published along with the backup so that the backup can be restored from the catalog
+		if chain, err := resolveChain(ctx, backup); err == nil {
+			raw = chainRawMeta(chain)
+		}
+	}
+	script := publishCatalogScript(&backup.Meta.Location, backup.Meta.ID)
+	job := buildJobWithMeta(common.ObjMetaTemplate(bj, catalogJobName(bj)), bj.Spec.Overlay, c.toolImage, []string{"/bin/sh", "-c", script}, func(c *corev1.Container) {
+		c.Env = []corev1.EnvVar{{Name: RawMetaEnv, Value: raw}}
+	})
+	injectStorageCredential(job, &job.Spec.Template.Spec.Containers[0], &backup.Meta.Location)
+	mountStorage(job, targetVolume, &backup.Meta.Location)
+	return job
+}
+
+func catalogJobName(bj *v1alpha1.BackupJob) string {
+	return fmt.Sprintf("%s-catalog", bj.Name)
+}
+
+func (c *BackupActor) completeBackup(ctx *recon.Context[*v1alpha1.BackupJob], backup *v1alpha1.Backup) error {
+	bj := ctx.Obj
+	for _, name := range []string{bj.Name, catalogJobName(bj)} {
+		if err := c.deleteJob(ctx, name); err != nil {
+			return errors.WrapPrefix(err, "error finalize backup job", 0)
+		}
 	}
 	bj.Status.Backup = backup.Name
 	bj.Status.Phase = v1alpha1.JobPhaseCompleted
@@ -170,6 +403,37 @@ func (c *BackupActor) syncJob(ctx *recon.Context[*v1alpha1.BackupJob]) error {
 	if bj.Status.Phase == "" {
 		bj.Status.Phase = v1alpha1.JobPhasePending
 	}
+	now := time.Now()
+	if bj.Status.DeadlineExceeded(bj.Spec.ActiveDeadline, now) {
+		return c.failDeadlineExceeded(ctx)
+	}
+	if t := bj.Status.NextRetryTime; t != nil && now.Before(t.Time) {
+		return recon.ErrReSync("wait retry backoff", t.Sub(now))
+	}
+	if bj.Status.Attempts > 0 {
+		// the job of the previous attempt must be gone before the next attempt starts
+		err := ctx.Get(types.NamespacedName{Namespace: bj.Namespace, Name: bj.Name}, &batchv1.Job{})
+		if err == nil {
+			return recon.ErrReSync("wait the job of the previous attempt deleted", catalogPollInterval)
+		}
+		if !apierrors.IsNotFound(err) {
+			return errors.WrapPrefix(err, "error get backup job", 0)
+		}
+	}
+	blocking, err := c.waitQueue(ctx)
+	if err != nil {
+		return err
+	}
+	if blocking != nil {
+		bj.Status.Phase = v1alpha1.JobPhaseQueued
+		meta.SetStatusCondition(&bj.Status.Conditions, metav1.Condition{
+			Type:    v1alpha1.JobConditionTypeEnded,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Queued",
+			Message: fmt.Sprintf("waiting for backup job %s of the same source", blocking.Name),
+		})
+		return recon.ErrReSync("wait the backup jobs of the same source", pollInterval)
+	}
 	var moSecret string
 	backupCmd := &BackupCommand{}
 	if bj.Spec.Source.CNSetRef != nil {
@@ -206,46 +470,52 @@ func (c *BackupActor) syncJob(ctx *recon.Context[*v1alpha1.BackupJob]) error {
 		backupCmd.Port = mo.Status.Port
 		moSecret = mo.Status.CredentialRef.Name
 	}
-	backupCmd.S3.Endpoint = bj.Spec.Target.S3.Endpoint
-	backupCmd.S3.Type = string(bj.Spec.Target.S3.GetProviderType())
-	parts := strings.SplitN(bj.Spec.Target.S3.Path, "/", 2)
-	backupCmd.S3.Bucket = parts[0]
-	if len(parts) > 1 {
-		backupCmd.S3.Path = parts[1]
+	if err := checkStorage(&bj.Spec.Target); err != nil {
+		return errors.WrapPrefix(err, "bad backup target", 0)
 	}
-	optionalS3Secret := bj.Spec.Target.S3.SecretRef
-	if optionalS3Secret != nil {
-		backupCmd.S3.ReadEnvSecret = true
+	if s3 := bj.Spec.Target.S3; s3 != nil {
+		backupCmd.S3 = newS3(s3)
+	} else {
+		backupCmd.FileSystemPath = bj.Spec.Target.FileSystem.Path
+	}
+	if e := bj.Spec.Encryption; e != nil {
+		if msg := encryptionUnsupported(c.backupImage); msg != "" {
+			return c.endWithFailure(ctx, "EncryptionUnsupported", msg)
+		}
+		msg, err := missingKey(ctx, bj.Namespace, e)
+		if err != nil {
+			return err
+		}
+		if msg != "" {
+			return c.endWithFailure(ctx, "EncryptionKeyUnavailable", msg)
+		}
+		backupCmd.Encrypt = true
+	}
+	var chainMeta string
+	if bj.GetBackupType() == v1alpha1.BackupTypeIncremental {
+		parent, err := getParentBackup(ctx, bj)
+		if err != nil {
+			return c.failOnChainError(ctx, err)
+		}
+		chain, err := resolveChain(ctx, parent)
+		if err != nil {
+			return c.failOnChainError(ctx, err)
+		}
+		backupCmd.BaseID = parent.Meta.ID
+		chainMeta = chainRawMeta(chain)
 	}
 	job := buildJob(bj, c.backupImage, backupCmd.String(), func(c *corev1.Container) {
-		c.Env = []corev1.EnvVar{{
-			Name: MOUserEnvKey,
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{Name: moSecret},
-					Key:                  "username",
-				},
-			},
-		}, {
-			Name: MOPasswordEnvKey,
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{Name: moSecret},
-					Key:                  "password",
-				},
-			},
-		}}
-		if optionalS3Secret != nil {
-			for _, key := range []string{common.AWSAccessKeyID, common.AWSSecretAccessKey} {
-				c.Env = util.UpsertByKey(c.Env, corev1.EnvVar{Name: key, ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: &corev1.SecretKeySelector{
-						LocalObjectReference: *optionalS3Secret,
-						Key:                  key,
-					},
-				}}, util.EnvVarKey)
-			}
+		c.Env = moCredentialEnv(moSecret)
+		if chainMeta != "" {
+			c.Env = append(c.Env, corev1.EnvVar{Name: RawMetaEnv, Value: chainMeta})
 		}
 	})
+	injectStorageCredential(job, &job.Spec.Template.Spec.Containers[0], &bj.Spec.Target)
+	if e := bj.Spec.Encryption; e != nil {
+		mountEncryptionKey(&job.Spec.Template.Spec, &job.Spec.Template.Spec.Containers[0], e)
+	}
+	mountStorage(job, targetVolume, &bj.Spec.Target)
+	boundByDeadline(job, bj.Spec.ActiveDeadline, &bj.Status.JobAttemptStatus, now)
 	svc := buildSvc(bj)
 	if err := util.Ignore(apierrors.IsAlreadyExists, ctx.CreateOwned(job)); err != nil {
 		return errors.WrapPrefix(err, "error ensure job", 0)
@@ -253,6 +523,7 @@ func (c *BackupActor) syncJob(ctx *recon.Context[*v1alpha1.BackupJob]) error {
 	if err := util.Ignore(apierrors.IsAlreadyExists, ctx.CreateOwned(svc)); err != nil {
 		return errors.WrapPrefix(err, "error ensure service", 0)
 	}
+	startAttempt(&bj.Status.JobAttemptStatus, now)
 	// mark as running
 	bj.Status.Phase = v1alpha1.JobPhaseRunning
 	meta.SetStatusCondition(&bj.Status.Conditions, metav1.Condition{
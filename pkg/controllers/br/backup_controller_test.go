@@ -0,0 +1,179 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/matrixorigin/controller-runtime/pkg/fake"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+)
+
+func Test_backupLabels(t *testing.T) {
+	g := NewGomegaWithT(t)
+	bj := &v1alpha1.BackupJob{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "daily", UID: "uid"},
+		Spec: v1alpha1.BackupJobSpec{
+			Source: v1alpha1.BackupSource{ClusterRef: pointer.String("mo")},
+		},
+	}
+	labels := backupLabels(bj, "v2.1.0")
+	g.Expect(labels).To(HaveKeyWithValue(common.BackupSourceKindLabelKey, "matrixonecluster"))
+	g.Expect(labels).To(HaveKeyWithValue(common.BackupSourceLabelKey, "mo"))
+	g.Expect(labels).To(HaveKeyWithValue(common.BackupSourceNamespaceLabelKey, "default"))
+	g.Expect(labels).To(HaveKeyWithValue(common.BackupVersionLabelKey, "v2.1.0"))
+
+	// invalid label value is skipped
+	labels = backupLabels(bj, "registry/mo:v2.1.0")
+	g.Expect(labels).NotTo(HaveKey(common.BackupVersionLabelKey))
+}
+
+func Test_backupProgress(t *testing.T) {
+	g := NewGomegaWithT(t)
+	job := &batchv1.Job{Status: batchv1.JobStatus{StartTime: &metav1.Time{Time: time.Now().Add(-time.Minute)}}}
+	progress := backupProgress(job)
+	g.Expect(progress.Elapsed.Duration).To(BeNumerically(">=", time.Minute))
+
+	// the job is not started yet
+	progress = backupProgress(&batchv1.Job{})
+	g.Expect(progress.Elapsed).To(BeNil())
+}
+
+func Test_queuedBehind(t *testing.T) {
+	g := NewGomegaWithT(t)
+	base := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	job := func(name string, cluster string, minutes int, phase string) v1alpha1.BackupJob {
+		return v1alpha1.BackupJob{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:         "default",
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(base.Add(time.Duration(minutes) * time.Minute)),
+			},
+			Spec:   v1alpha1.BackupJobSpec{Source: v1alpha1.BackupSource{ClusterRef: pointer.String(cluster)}},
+			Status: v1alpha1.BackupJobStatus{Phase: phase},
+		}
+	}
+	jobs := []v1alpha1.BackupJob{
+		job("done", "mo", 0, v1alpha1.JobPhaseCompleted),
+		job("first", "mo", 1, v1alpha1.JobPhasePending),
+		job("second", "mo", 2, v1alpha1.JobPhaseQueued),
+		job("other", "other", 3, v1alpha1.JobPhaseRunning),
+	}
+	// the jobs of a source run in the order of creation
+	g.Expect(queuedBehind(&jobs[1], jobs)).To(BeNil())
+	g.Expect(queuedBehind(&jobs[2], jobs).Name).To(Equal("first"))
+	g.Expect(queuedBehind(&jobs[3], jobs)).To(BeNil())
+
+	// the running job blocks the others regardless of the creation time
+	jobs[2].Status.Phase = v1alpha1.JobPhaseRunning
+	g.Expect(queuedBehind(&jobs[1], jobs).Name).To(Equal("second"))
+}
+
+func TestBackupActor_retryOrFail(t *testing.T) {
+	g := NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(v1alpha1.AddToScheme(s))
+
+	bj := &v1alpha1.BackupJob{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "daily"},
+		Spec: v1alpha1.BackupJobSpec{
+			Source:      v1alpha1.BackupSource{ClusterRef: pointer.String("mo")},
+			RetryPolicy: &v1alpha1.RetryPolicy{MaxAttempts: pointer.Int32(2), Backoff: &metav1.Duration{Duration: time.Minute}},
+		},
+		Status: v1alpha1.BackupJobStatus{
+			Phase:            v1alpha1.JobPhaseRunning,
+			JobAttemptStatus: v1alpha1.JobAttemptStatus{Attempts: 1, StartTime: &metav1.Time{Time: time.Now()}},
+		},
+	}
+	job := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "daily"}}
+	cli := &fake.Client{Client: fake.KubeClientBuilder().WithScheme(s).WithObjects(bj, job).WithStatusSubresource(bj).Build()}
+	ctx := fake.NewContext(bj, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+	actor := NewBackupActor("br", "aws-cli")
+
+	// the failed attempt is retried after the backoff with a new job
+	g.Expect(actor.retryOrFail(ctx, "connection reset")).To(Succeed())
+	g.Expect(bj.Status.Phase).To(Equal(v1alpha1.JobPhasePending))
+	g.Expect(bj.Status.NextRetryTime.Time).To(BeTemporally("~", time.Now().Add(time.Minute), 5*time.Second))
+	cond, ok := recon.GetCondition(bj, v1alpha1.JobConditionTypeEnded)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(cond.Reason).To(Equal(reasonRetrying))
+	g.Expect(apierrors.IsNotFound(ctx.Get(types.NamespacedName{Namespace: "default", Name: "daily"}, &batchv1.Job{}))).To(BeTrue())
+	err := actor.syncJob(ctx)
+	g.Expect(err).To(BeAssignableToTypeOf(&recon.ReSync{}))
+
+	// the attempts are exhausted
+	bj.Status.Attempts = 2
+	g.Expect(actor.retryOrFail(ctx, "connection reset")).To(Succeed())
+	g.Expect(bj.Status.Phase).To(Equal(v1alpha1.JobPhaseFailed))
+
+	// the deadline is exceeded
+	bj.Status.Phase = v1alpha1.JobPhaseRunning
+	bj.Status.Attempts = 1
+	bj.Spec.ActiveDeadline = &metav1.Duration{Duration: time.Minute}
+	bj.Status.StartTime = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+	g.Expect(actor.retryOrFail(ctx, "connection reset")).To(Succeed())
+	cond, _ = recon.GetCondition(bj, v1alpha1.JobConditionTypeEnded)
+	g.Expect(cond.Reason).To(Equal(reasonDeadlineExceeded))
+}
+
+func TestBackupActor_syncJobMissingParent(t *testing.T) {
+	g := NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(v1alpha1.AddToScheme(s))
+
+	mo := &v1alpha1.MatrixOneCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mo"},
+		Status: v1alpha1.MatrixOneClusterStatus{
+			Host:          "mo-tp-cn",
+			Port:          6001,
+			CredentialRef: &corev1.LocalObjectReference{Name: "mo-credential"},
+		},
+	}
+	bj := &v1alpha1.BackupJob{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "hourly"},
+		Spec: v1alpha1.BackupJobSpec{
+			Source:       v1alpha1.BackupSource{ClusterRef: pointer.String("mo")},
+			Target:       v1alpha1.SharedStorageProvider{FileSystem: &v1alpha1.FileSystemProvider{Path: "/backup"}},
+			Type:         v1alpha1.BackupTypeIncremental,
+			ParentBackup: "daily-abcde",
+		},
+	}
+	cli := &fake.Client{Client: fake.KubeClientBuilder().WithScheme(s).WithObjects(mo, bj).WithStatusSubresource(bj).Build()}
+	ctx := fake.NewContext(bj, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+	actor := NewBackupActor("br", "aws-cli")
+
+	// the job ends instead of blocking the later backups of the source
+	g.Expect(actor.syncJob(ctx)).To(Succeed())
+	g.Expect(bj.Status.Phase).To(Equal(v1alpha1.JobPhaseFailed))
+	cond, ok := recon.GetCondition(bj, v1alpha1.JobConditionTypeEnded)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(cond.Reason).To(Equal("InvalidParent"))
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/matrixorigin/controller-runtime/pkg/util"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The catalog of a backup location is the mo_br.meta directory in the location, each backup
+// published to the catalog is a file named by the backup id that contains the mo_br meta lines
+// of the backup chain, the last line is the meta of the backup itself. The catalog makes the
+// backups in the location discoverable without the Backup objects, e.g. from another Kubernetes
+// cluster. The catalog is accessed by the storage tool image, which must provide the aws cli.
+const (
+	catalogDir = "mo_br.meta"
+
+	catalogVolume    = "catalog"
+	catalogMountPath = "/catalog"
+
+	// catalogEntryEnd is printed after each catalog entry when the catalog is listed
+	catalogEntryEnd = "CATALOG_ENTRY_END"
+)
+
+// catalogPath returns the path of the catalog in the storage
+func catalogPath(sp *v1alpha1.SharedStorageProvider) string {
+	if sp.S3 != nil {
+		return fmt.Sprintf("s3://%s/%s", strings.TrimSuffix(sp.S3.Path, "/"), catalogDir)
+	}
+	return path.Join(sp.FileSystem.Path, catalogDir)
+}
+
+// awsCLI returns the aws cli command that accesses the S3 storage
+func awsCLI(s3 *v1alpha1.S3Provider) string {
+	sb := strings.Builder{}
+	if s3.GetProviderType() == v1alpha1.S3ProviderTypeOSS {
+		// oss only accepts the virtual hosted style, while aws cli addresses the bucket in path for custom endpoints
+		sb.WriteString(`sh -c 'aws configure set default.s3.addressing_style virtual && exec aws "$@"' aws`)
+	} else {
+		sb.WriteString("aws")
+	}
+	if endpoint := s3.GetEndpoint(); endpoint != "" {
+		sb.WriteString(fmt.Sprintf(" --endpoint-url %s", endpoint))
+	}
+	if s3.Region != "" {
+		sb.WriteString(fmt.Sprintf(" --region %s", s3.Region))
+	}
+	return sb.String()
+}
+
+// publishCatalogScript writes the meta in RawMetaEnv to the catalog entry of the backup
+func publishCatalogScript(sp *v1alpha1.SharedStorageProvider, id string) string {
+	if sp.S3 != nil {
+		return fmt.Sprintf(`printf '%%s\n' "$%s" | %s s3 cp - %s/%s`, RawMetaEnv, awsCLI(sp.S3), catalogPath(sp), id)
+	}
+	dir := catalogPath(sp)
+	return fmt.Sprintf(`mkdir -p %s && printf '%%s\n' "$%s" > %s/%s`, dir, RawMetaEnv, dir, id)
+}
+
+// removeCatalogScript removes the catalog entry of the backup
+func removeCatalogScript(sp *v1alpha1.SharedStorageProvider, id string) string {
+	if sp.S3 != nil {
+		return fmt.Sprintf("%s s3 rm %s/%s", awsCLI(sp.S3), catalogPath(sp), id)
+	}
+	return fmt.Sprintf("rm -f %s/%s", catalogPath(sp), id)
+}
+
+// fetchCatalogScript downloads the catalog to the catalog volume, the modification times of the
+// entries are only kept for the fileSystem storage, so the latest backup cannot be found by the
+// fetched catalog of S3
+func fetchCatalogScript(sp *v1alpha1.SharedStorageProvider) string {
+	if sp.S3 != nil {
+		return fmt.Sprintf("%s s3 cp %s/ %s/ --recursive", awsCLI(sp.S3), catalogPath(sp), catalogMountPath)
+	}
+	dir := catalogPath(sp)
+	return fmt.Sprintf("if [ -d %s ]; then cp -p %s/* %s/; fi", dir, dir, catalogMountPath)
+}
+
+// listCatalogScript prints the entries of the fetched catalog, each followed by catalogEntryEnd
+func listCatalogScript() string {
+	return fmt.Sprintf(`for f in %s/*; do if [ -f "$f" ]; then cat "$f" && echo %s; fi; done`, catalogMountPath, catalogEntryEnd)
+}
+
+// runBeforeBR adds an init container that runs the script against the storage before the br container
+func runBeforeBR(job *batchv1.Job, name string, image string, volume string, sp *v1alpha1.SharedStorageProvider, script string) *corev1.Container {
+	podSpec := &job.Spec.Template.Spec
+	podSpec.InitContainers = append(podSpec.InitContainers, corev1.Container{
+		Name:    name,
+		Image:   image,
+		Command: []string{"/bin/sh", "-c", script},
+	})
+	c := &podSpec.InitContainers[len(podSpec.InitContainers)-1]
+	injectStorageCredential(job, c, sp)
+	mountStorageTo(job, c, volume, sp)
+	return c
+}
+
+// fetchCatalog downloads the catalog of the storage before the br container starts, the catalog
+// is mounted to the br container at catalogMountPath
+func fetchCatalog(job *batchv1.Job, image string, volume string, sp *v1alpha1.SharedStorageProvider) {
+	podSpec := &job.Spec.Template.Spec
+	podSpec.Volumes = util.UpsertByKey(podSpec.Volumes, corev1.Volume{
+		Name:         catalogVolume,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}, func(v corev1.Volume) string {
+		return v.Name
+	})
+	mount := corev1.VolumeMount{Name: catalogVolume, MountPath: catalogMountPath}
+	c := runBeforeBR(job, "fetch-catalog", image, volume, sp, fetchCatalogScript(sp))
+	c.VolumeMounts = append(c.VolumeMounts, mount)
+	br := &podSpec.Containers[0]
+	br.VolumeMounts = append(br.VolumeMounts, mount)
+}
+
+// parseCatalog parses the listed catalog entries
+func parseCatalog(out string) []string {
+	var entries []string
+	for _, entry := range strings.Split(out, catalogEntryEnd) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
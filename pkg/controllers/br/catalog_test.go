@@ -0,0 +1,68 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"testing"
+
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_catalogScripts(t *testing.T) {
+	g := NewGomegaWithT(t)
+	s3 := &v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{
+		Path:     "bucket/backup/",
+		Endpoint: "http://minio:9000",
+	}}
+	g.Expect(publishCatalogScript(s3, "abc")).To(Equal(`printf '%s\n' "$RAW_META" | aws --endpoint-url http://minio:9000 s3 cp - s3://bucket/backup/mo_br.meta/abc`))
+	g.Expect(removeCatalogScript(s3, "abc")).To(Equal("aws --endpoint-url http://minio:9000 s3 rm s3://bucket/backup/mo_br.meta/abc"))
+	g.Expect(fetchCatalogScript(s3)).To(Equal("aws --endpoint-url http://minio:9000 s3 cp s3://bucket/backup/mo_br.meta/ /catalog/ --recursive"))
+
+	fs := &v1alpha1.SharedStorageProvider{FileSystem: &v1alpha1.FileSystemProvider{Path: "/backup"}}
+	g.Expect(publishCatalogScript(fs, "abc")).To(Equal(`mkdir -p /backup/mo_br.meta && printf '%s\n' "$RAW_META" > /backup/mo_br.meta/abc`))
+	g.Expect(removeCatalogScript(fs, "abc")).To(Equal("rm -f /backup/mo_br.meta/abc"))
+}
+
+func Test_fetchCatalog(t *testing.T) {
+	g := NewGomegaWithT(t)
+	rj := &v1alpha1.RestoreJob{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "restore"}}
+	job := buildJob(rj, "br", "true", func(c *corev1.Container) {})
+	sp := &v1alpha1.SharedStorageProvider{FileSystem: &v1alpha1.FileSystemProvider{
+		Path: "/backup",
+		Volume: &v1alpha1.FileSystemVolume{
+			NFS: &corev1.NFSVolumeSource{Server: "nfs", Path: "/exports"},
+		},
+	}}
+	fetchCatalog(job, "aws-cli", sourceVolume, sp)
+	podSpec := job.Spec.Template.Spec
+	g.Expect(podSpec.Volumes).To(HaveLen(2))
+	g.Expect(podSpec.InitContainers).To(HaveLen(1))
+	g.Expect(podSpec.InitContainers[0].Image).To(Equal("aws-cli"))
+	g.Expect(podSpec.InitContainers[0].VolumeMounts).To(ConsistOf(
+		corev1.VolumeMount{Name: sourceVolume, MountPath: "/backup"},
+		corev1.VolumeMount{Name: catalogVolume, MountPath: catalogMountPath},
+	))
+	g.Expect(podSpec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: catalogVolume, MountPath: catalogMountPath}))
+}
+
+func Test_parseCatalog(t *testing.T) {
+	g := NewGomegaWithT(t)
+	out := "full,meta\nCATALOG_ENTRY_END\nfull,meta\nincr,meta\nCATALOG_ENTRY_END\n"
+	g.Expect(parseCatalog(out)).To(Equal([]string{"full,meta", "full,meta\nincr,meta"}))
+	g.Expect(parseCatalog("")).To(BeEmpty())
+}
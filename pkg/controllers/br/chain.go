@@ -0,0 +1,127 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// chainError reports a backup chain that cannot be used, which cannot be recovered by retrying
+type chainError struct {
+	msg string
+}
+
+func (e *chainError) Error() string {
+	return e.msg
+}
+
+func newChainError(format string, args ...any) error {
+	return &chainError{msg: fmt.Sprintf(format, args...)}
+}
+
+// isChainError returns whether the error is caused by an unusable backup chain
+func isChainError(err error) bool {
+	var ce *chainError
+	return errors.As(err, &ce)
+}
+
+// resolveChain returns the backups that are required to restore the backup, ordered from
+// the full base backup to the backup itself
+func resolveChain(cli recon.KubeClient, b *v1alpha1.Backup) ([]*v1alpha1.Backup, error) {
+	var chain []*v1alpha1.Backup
+	for _, name := range b.Meta.Chain {
+		parent := &v1alpha1.Backup{}
+		if err := cli.Get(types.NamespacedName{Name: name}, parent); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, newChainError("backup chain of %s is broken, backup %s not found", b.Name, name)
+			}
+			return nil, errors.WrapPrefix(err, "error get backup "+name, 0)
+		}
+		chain = append(chain, parent)
+	}
+	return append(chain, b), nil
+}
+
+// getParentBackup returns the parent backup of the incremental backup job, a chainError is returned
+// if the parent cannot be used
+func getParentBackup(cli recon.KubeClient, bj *v1alpha1.BackupJob) (*v1alpha1.Backup, error) {
+	if bj.Spec.ParentBackup == "" {
+		return nil, newChainError("parentBackup must be set for incremental backup")
+	}
+	parent := &v1alpha1.Backup{}
+	if err := cli.Get(types.NamespacedName{Name: bj.Spec.ParentBackup}, parent); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, newChainError("parent backup %s not found", bj.Spec.ParentBackup)
+		}
+		return nil, errors.WrapPrefix(err, "error get parent backup", 0)
+	}
+	if parent.DeletionTimestamp != nil {
+		return nil, newChainError("parent backup %s is being deleted", parent.Name)
+	}
+	if !equality.Semantic.DeepEqual(parent.Meta.Location, bj.Spec.Target) {
+		return nil, newChainError("parent backup %s is not stored in the backup target", parent.Name)
+	}
+	if !equality.Semantic.DeepEqual(parent.Meta.Encryption, bj.Spec.Encryption) {
+		return nil, newChainError("parent backup %s is not encrypted with the same key", parent.Name)
+	}
+	return parent, nil
+}
+
+// activeDependents returns the names of the unfinished incremental backup jobs based on the backup
+func activeDependents(b *v1alpha1.Backup, jobs []v1alpha1.BackupJob) []string {
+	var names []string
+	for _, j := range jobs {
+		if j.Spec.ParentBackup != b.Name || j.GetBackupType() != v1alpha1.BackupTypeIncremental {
+			continue
+		}
+		if j.Status.Phase == v1alpha1.JobPhaseCompleted || j.Status.Phase == v1alpha1.JobPhaseFailed {
+			continue
+		}
+		names = append(names, fmt.Sprintf("%s/%s", j.Namespace, j.Name))
+	}
+	return names
+}
+
+// chainRawMeta returns the content of the mo_br meta file that contains all the backups in the chain
+func chainRawMeta(chain []*v1alpha1.Backup) string {
+	var metas []string
+	for _, b := range chain {
+		metas = append(metas, b.Meta.Raw)
+	}
+	return strings.Join(metas, "\n")
+}
+
+// dependedBackups returns the names of the backups that the given backups depend on,
+// the backups that are being deleted are ignored
+func dependedBackups(backups []v1alpha1.Backup) map[string]bool {
+	depended := map[string]bool{}
+	for _, b := range backups {
+		if b.DeletionTimestamp != nil {
+			continue
+		}
+		for _, name := range b.Meta.Chain {
+			depended[name] = true
+		}
+	}
+	return depended
+}
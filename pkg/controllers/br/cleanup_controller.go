@@ -0,0 +1,151 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/controller-runtime/pkg/util"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	manager "sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// CleanupActor deletes the backup data in the storage when a Backup with Delete deletionPolicy is deleted,
+// the deletion of any Backup is blocked while incremental backups depend on it
+type CleanupActor struct {
+	image     string
+	toolImage string
+}
+
+func NewCleanupActor(image string, toolImage string) *CleanupActor {
+	return &CleanupActor{image: image, toolImage: toolImage}
+}
+
+var _ recon.Actor[*v1alpha1.Backup] = &CleanupActor{}
+
+func (c *CleanupActor) Observe(_ *recon.Context[*v1alpha1.Backup]) (recon.Action[*v1alpha1.Backup], error) {
+	// the backup data is only touched on deletion
+	return nil, nil
+}
+
+func (c *CleanupActor) Finalize(ctx *recon.Context[*v1alpha1.Backup]) (bool, error) {
+	b := ctx.Obj
+	backupList := &v1alpha1.BackupList{}
+	if err := ctx.List(backupList); err != nil {
+		return false, errors.WrapPrefix(err, "error list backups", 0)
+	}
+	if dependedBackups(backupList.Items)[b.Name] {
+		// the backup is required to restore the incremental backups based on it, regardless of
+		// whether its data is retained
+		return false, c.cleanupFailed(ctx, "InUse", "backup is depended by incremental backups")
+	}
+	jobList := &v1alpha1.BackupJobList{}
+	if err := ctx.List(jobList); err != nil {
+		return false, errors.WrapPrefix(err, "error list backup jobs", 0)
+	}
+	if jobs := activeDependents(b, jobList.Items); len(jobs) > 0 {
+		// the running incremental backups read the meta of the backup, releasing it would fail them
+		return false, c.cleanupFailed(ctx, "InUse", fmt.Sprintf("backup is the parent of running backup jobs: %s", strings.Join(jobs, ", ")))
+	}
+	if b.GetDeletionPolicy() != v1alpha1.PVCRetentionPolicyDelete {
+		return true, nil
+	}
+	ns := b.GetSourceNamespace()
+	if ns == "" {
+		return false, c.cleanupFailed(ctx, "InvalidSource", fmt.Sprintf("cannot resolve the namespace of source %s to run the cleanup job", b.Meta.SourceRef))
+	}
+	job := &batchv1.Job{}
+	err := ctx.Get(types.NamespacedName{Namespace: ns, Name: cleanupJobName(b)}, job)
+	if apierrors.IsNotFound(err) {
+		job, err = c.buildCleanupJob(b, ns)
+		if err != nil {
+			return false, c.cleanupFailed(ctx, "InvalidLocation", err.Error())
+		}
+		if err := util.Ignore(apierrors.IsAlreadyExists, ctx.CreateOwned(job)); err != nil {
+			return false, c.cleanupFailed(ctx, "FailCreateJob", err.Error())
+		}
+		b.SetCondition(metav1.Condition{
+			Type:   v1alpha1.BackupConditionTypeCleanup,
+			Status: metav1.ConditionFalse,
+			Reason: "JobRunning",
+		})
+		return false, ctx.UpdateStatus(b)
+	}
+	if err != nil {
+		return false, errors.WrapPrefix(err, "error get cleanup job", 0)
+	}
+	if job.Status.Succeeded > 0 {
+		ctx.Log.Info("backup data deleted", "backup", b.Name, "id", b.Meta.ID)
+		return true, nil
+	}
+	if job.Status.Failed > 0 {
+		// the failed job is kept for troubleshooting, delete the job to retry or set the deletionPolicy
+		// to Retain to release the backup
+		return false, c.cleanupFailed(ctx, "JobFailed", fmt.Sprintf("cleanup job %s/%s failed", job.Namespace, job.Name))
+	}
+	// wait the job to complete
+	return false, nil
+}
+
+func (c *CleanupActor) cleanupFailed(ctx *recon.Context[*v1alpha1.Backup], reason string, message string) error {
+	ctx.Obj.SetCondition(metav1.Condition{
+		Type:    v1alpha1.BackupConditionTypeCleanup,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+	return ctx.UpdateStatus(ctx.Obj)
+}
+
+func (c *CleanupActor) buildCleanupJob(b *v1alpha1.Backup, ns string) (*batchv1.Job, error) {
+	if err := checkStorage(&b.Meta.Location); err != nil {
+		return nil, err
+	}
+	meta := common.ObjMetaTemplate(b, cleanupJobName(b))
+	meta.Namespace = ns
+	deleteCmd := &DeleteCommand{
+		BackupID:      b.Meta.ID,
+		RawMeta:       b.Meta.Raw,
+		ReadEnvSecret: b.Meta.Location.S3 != nil && b.Meta.Location.S3.SecretRef != nil,
+	}
+	job := buildJobWithMeta(meta, nil, c.image, []string{"/bin/sh", "-c", deleteCmd.String()}, func(c *corev1.Container) {
+		c.Env = []corev1.EnvVar{{Name: RawMetaEnv, Value: b.Meta.Raw}}
+	})
+	injectStorageCredential(job, &job.Spec.Template.Spec.Containers[0], &b.Meta.Location)
+	mountStorage(job, sourceVolume, &b.Meta.Location)
+	// the backup is removed from the catalog first so that it won't be discovered again
+	runBeforeBR(job, "remove-catalog", c.toolImage, sourceVolume, &b.Meta.Location, removeCatalogScript(&b.Meta.Location, b.Meta.ID))
+	return job, nil
+}
+
+func cleanupJobName(b *v1alpha1.Backup) string {
+	return fmt.Sprintf("%s-cleanup", b.Name)
+}
+
+func (c *CleanupActor) Reconcile(mgr manager.Manager) error {
+	return recon.Setup[*v1alpha1.Backup](&v1alpha1.Backup{}, "backup", mgr, c, recon.WithBuildFn(func(b *builder.Builder) {
+		b.Owns(&batchv1.Job{})
+	}))
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/matrixorigin/controller-runtime/pkg/fake"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestCleanupActor_Finalize(t *testing.T) {
+	g := NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(v1alpha1.AddToScheme(s))
+
+	policy := v1alpha1.PVCRetentionPolicyDelete
+	backup := &v1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "daily-abcde"},
+		Meta: v1alpha1.BackupMeta{
+			Location: v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{
+				Path:      "bucket/backup",
+				SecretRef: &corev1.LocalObjectReference{Name: "aws"},
+			}},
+			ID:        "abcdefg",
+			SourceRef: "matrixonecluster/default/mo",
+			Raw:       "abcdefg,meta",
+		},
+		DeletionPolicy: &policy,
+	}
+	incremental := &v1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "daily-fghij"},
+		Meta: v1alpha1.BackupMeta{
+			Type:  v1alpha1.BackupTypeIncremental,
+			Chain: []string{backup.Name},
+		},
+	}
+	cli := &fake.Client{Client: fake.KubeClientBuilder().WithScheme(s).WithObjects(backup, incremental).WithStatusSubresource(backup).Build()}
+	ctx := fake.NewContext(backup, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+	actor := NewCleanupActor("br", "aws-cli")
+
+	// the data is kept until the incremental backups are deleted
+	done, err := actor.Finalize(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(done).To(BeFalse())
+	cond, ok := recon.GetCondition(backup, v1alpha1.BackupConditionTypeCleanup)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(cond.Reason).To(Equal("InUse"))
+	g.Expect(ctx.Delete(incremental)).To(Succeed())
+
+	// the cleanup job is started in the namespace of the backup source
+	done, err = actor.Finalize(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(done).To(BeFalse())
+	job := &batchv1.Job{}
+	g.Expect(ctx.Get(types.NamespacedName{Namespace: "default", Name: "daily-abcde-cleanup"}, job)).To(Succeed())
+	g.Expect(job.Spec.Template.Spec.Containers[0].Command[2]).To(ContainSubstring("/mo_br delete abcdefg --access_key_id="))
+	g.Expect(job.Spec.Template.Spec.InitContainers[0].Command[2]).To(Equal("aws s3 rm s3://bucket/backup/mo_br.meta/abcdefg"))
+
+	// failure is surfaced in status
+	job.Status.Failed = 1
+	g.Expect(cli.Status().Update(ctx, job)).To(Succeed())
+	done, err = actor.Finalize(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(done).To(BeFalse())
+	cond, ok = recon.GetCondition(backup, v1alpha1.BackupConditionTypeCleanup)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(cond.Reason).To(Equal("JobFailed"))
+
+	job.Status.Failed = 0
+	job.Status.Succeeded = 1
+	g.Expect(cli.Status().Update(ctx, job)).To(Succeed())
+	done, err = actor.Finalize(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(done).To(BeTrue())
+
+	// retained backup is released directly unless incremental backups depend on it
+	backup.DeletionPolicy = nil
+	incremental.ResourceVersion = ""
+	g.Expect(ctx.Create(incremental)).To(Succeed())
+	g.Expect(actor.Finalize(ctx)).To(BeFalse())
+	g.Expect(ctx.Delete(incremental)).To(Succeed())
+
+	// the running incremental backup job based on the backup blocks the deletion as well
+	running := &v1alpha1.BackupJob{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "hourly"},
+		Spec:       v1alpha1.BackupJobSpec{Type: v1alpha1.BackupTypeIncremental, ParentBackup: backup.Name},
+		Status:     v1alpha1.BackupJobStatus{Phase: v1alpha1.JobPhaseRunning},
+	}
+	g.Expect(ctx.Create(running)).To(Succeed())
+	g.Expect(actor.Finalize(ctx)).To(BeFalse())
+	cond, _ = recon.GetCondition(backup, v1alpha1.BackupConditionTypeCleanup)
+	g.Expect(cond.Message).To(ContainSubstring("default/hourly"))
+	g.Expect(ctx.Delete(running)).To(Succeed())
+	g.Expect(actor.Finalize(ctx)).To(BeTrue())
+}
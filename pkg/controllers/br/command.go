@@ -28,6 +28,14 @@ const (
 	RestoreAccessEnvKey = "RESTORE_ACCESS_KEY_ID"
 	RestoreSecretEnvKey = "RESTORE_SECRET_ACCESS_KEY"
 
+	// BackupAccessEnvKey and BackupSecretEnvKey are the keys of the backup location of a restore, which
+	// are kept out of the env of aws so that the target is never accessed with them
+	BackupAccessEnvKey = "BACKUP_ACCESS_KEY_ID"
+	BackupSecretEnvKey = "BACKUP_SECRET_ACCESS_KEY"
+
+	// EncryptionKeyFile is the file of the data key that encrypts or decrypts the backup data
+	EncryptionKeyFile = "/etc/mo-br/encryption/key"
+
 	MetaDelimiter = "META_DELIMITER"
 	RawMetaEnv    = "RAW_META"
 )
@@ -35,36 +43,79 @@ const (
 type BackupCommand struct {
 	Host string
 	Port int
-	S3   S3
+	// S3 is the backup location in S3, mutual exclusive with FileSystemPath
+	S3 *S3
+	// FileSystemPath is the backup location in the shared fileSystem, mutual exclusive with S3
+	FileSystemPath string
+	// BaseID is the ID of the parent backup of an incremental backup, the metas of the
+	// backup chain must be provided in RawMetaEnv. Full backup is taken if not set
+	BaseID string
+	// Encrypt encrypts the backup data with the data key in EncryptionKeyFile
+	Encrypt bool
 }
 
 type S3 struct {
 	Endpoint      string
 	Bucket        string
 	Path          string
-	Type          string
 	ReadEnvSecret bool
+	// PathStyle addresses the bucket in the path of the URL, which is enabled by the minio flags of mo_br
+	PathStyle bool
+}
+
+func newS3(p *v1alpha1.S3Provider) *S3 {
+	s3 := &S3{
+		Endpoint:      p.GetEndpoint(),
+		ReadEnvSecret: p.SecretRef != nil,
+		PathStyle:     p.UsePathStyle(),
+	}
+	parts := strings.SplitN(p.Path, "/", 2)
+	s3.Bucket = parts[0]
+	if len(parts) > 1 {
+		s3.Path = parts[1]
+	}
+	return s3
 }
 
 func (b *BackupCommand) String() string {
 	sb := strings.Builder{}
+	if b.BaseID != "" {
+		// mo_br looks up the base backup in the local meta file
+		sb.WriteString(fmt.Sprintf("echo \"$%s\" > /mo_br.meta", RawMetaEnv))
+		sb.WriteString(` && sha256sum mo_br.meta | awk '{printf "%s",$1}' > mo_br.meta.sha256 && `)
+	}
 	sb.WriteString("/mo_br backup")
 	sb.WriteString(fmt.Sprintf(" --host=%s", b.Host))
 	sb.WriteString(fmt.Sprintf(" --port=%d", b.Port))
 	sb.WriteString(" --user=$MO_USER")
 	sb.WriteString(" --password=$MO_PASSWORD")
-	sb.WriteString(" --backup_dir=s3")
-	sb.WriteString(fmt.Sprintf(" --endpoint=%s", b.S3.Endpoint))
-	sb.WriteString(fmt.Sprintf(" --bucket=%s", b.S3.Bucket))
-	if b.S3.Path != "" {
-		sb.WriteString(fmt.Sprintf(" --filepath=%s", b.S3.Path))
+	if b.S3 == nil {
+		sb.WriteString(" --backup_dir=filesystem")
+		sb.WriteString(fmt.Sprintf(" --path=%s", b.FileSystemPath))
+	} else {
+		sb.WriteString(" --backup_dir=s3")
+		sb.WriteString(fmt.Sprintf(" --endpoint=%s", b.S3.Endpoint))
+		sb.WriteString(fmt.Sprintf(" --bucket=%s", b.S3.Bucket))
+		if b.S3.Path != "" {
+			sb.WriteString(fmt.Sprintf(" --filepath=%s", b.S3.Path))
+		}
+		if b.S3.PathStyle {
+			sb.WriteString(" --is_minio")
+		}
+		if b.S3.ReadEnvSecret {
+			sb.WriteString(" --access_key_id=$AWS_ACCESS_KEY_ID")
+			sb.WriteString(" --secret_access_key=$AWS_SECRET_ACCESS_KEY")
+		}
 	}
-	if b.S3.Type == string(v1alpha1.S3ProviderTypeMinIO) {
-		sb.WriteString(" --is_minio")
+	if b.Encrypt {
+		sb.WriteString(fmt.Sprintf(" --encryption_key_file=%s", EncryptionKeyFile))
 	}
-	if b.S3.ReadEnvSecret {
-		sb.WriteString(" --access_key_id=$AWS_ACCESS_KEY_ID")
-		sb.WriteString(" --secret_access_key=$AWS_SECRET_ACCESS_KEY")
+	if b.BaseID != "" {
+		sb.WriteString(" --backup_type=incremental")
+		sb.WriteString(fmt.Sprintf(" --base_id=%s", b.BaseID))
+		// the new backup is appended to the meta file after the chain
+		sb.WriteString(fmt.Sprintf(" && echo %s && tail -n 1 /mo_br.meta", MetaDelimiter))
+		return sb.String()
 	}
 	sb.WriteString(fmt.Sprintf(" && echo %s && cat /mo_br.meta", MetaDelimiter))
 	return sb.String()
@@ -72,23 +123,50 @@ func (b *BackupCommand) String() string {
 
 type RestoreCommand struct {
 	BackupID string
-	Target   S3
-	RawMeta  string
+	// Target is the restore location in S3, mutual exclusive with TargetFileSystemPath
+	Target *S3
+	// TargetFileSystemPath is the restore location in the shared fileSystem, mutual exclusive with Target
+	TargetFileSystemPath string
+	RawMeta              string
+	// CatalogDir is the directory of the fetched catalog of the backup location, the metas are read
+	// from the catalog instead of RawMetaEnv if set, and the latest backup in the catalog is restored
+	// if BackupID is empty, which is only allowed for the fileSystem catalog that keeps the modification
+	// times
+	CatalogDir string
+	// Decrypt decrypts the backup data with the data key in EncryptionKeyFile
+	Decrypt bool
 
+	// ReadSourceEnvSecret reads the keys of the backup location from BackupAccessEnvKey and BackupSecretEnvKey
 	ReadSourceEnvSecret bool
 }
 
 func (c *RestoreCommand) String() string {
 	sb := strings.Builder{}
-	sb.WriteString(fmt.Sprintf("echo $%s > /mo_br.meta", RawMetaEnv))
+	id := c.BackupID
+	if c.CatalogDir != "" {
+		sb.WriteString(fmt.Sprintf("cat %s/* > /mo_br.meta", c.CatalogDir))
+		if id == "" {
+			id = fmt.Sprintf("$(ls -t %s | head -n 1)", c.CatalogDir)
+		}
+	} else {
+		sb.WriteString(fmt.Sprintf("echo \"$%s\" > /mo_br.meta", RawMetaEnv))
+	}
 	sb.WriteString(` && sha256sum mo_br.meta | awk '{printf "%s",$1}' > mo_br.meta.sha256`)
 	sb.WriteString(" && /mo_br restore")
-	sb.WriteString(fmt.Sprintf(" %s", c.BackupID))
-	sb.WriteString(" --restore_dir s3")
+	sb.WriteString(fmt.Sprintf(" %s", id))
 	if c.ReadSourceEnvSecret {
-		sb.WriteString(" --backup_access_key_id=$AWS_ACCESS_KEY_ID")
-		sb.WriteString(" --backup_secret_access_key=$AWS_SECRET_ACCESS_KEY")
+		sb.WriteString(fmt.Sprintf(" --backup_access_key_id=$%s", BackupAccessEnvKey))
+		sb.WriteString(fmt.Sprintf(" --backup_secret_access_key=$%s", BackupSecretEnvKey))
+	}
+	if c.Decrypt {
+		sb.WriteString(fmt.Sprintf(" --encryption_key_file=%s", EncryptionKeyFile))
+	}
+	if c.Target == nil {
+		sb.WriteString(" --restore_dir filesystem")
+		sb.WriteString(fmt.Sprintf(" --restore_path=%s", c.TargetFileSystemPath))
+		return sb.String()
 	}
+	sb.WriteString(" --restore_dir s3")
 	sb.WriteString(fmt.Sprintf(" --restore_endpoint=%s", c.Target.Endpoint))
 	sb.WriteString(fmt.Sprintf(" --restore_bucket=%s", c.Target.Bucket))
 	if c.Target.Path != "" {
@@ -98,8 +176,109 @@ func (c *RestoreCommand) String() string {
 		sb.WriteString(fmt.Sprintf(" --restore_access_key_id=$%s", RestoreAccessEnvKey))
 		sb.WriteString(fmt.Sprintf(" --restore_secret_access_key=$%s", RestoreSecretEnvKey))
 	}
-	if c.Target.Type == string(v1alpha1.S3ProviderTypeMinIO) {
+	if c.Target.PathStyle {
 		sb.WriteString(" --restore_is_minio")
 	}
 	return sb.String()
 }
+
+type DeleteCommand struct {
+	BackupID string
+	RawMeta  string
+
+	ReadEnvSecret bool
+}
+
+func (c *DeleteCommand) String() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("echo \"$%s\" > /mo_br.meta", RawMetaEnv))
+	sb.WriteString(` && sha256sum mo_br.meta | awk '{printf "%s",$1}' > mo_br.meta.sha256`)
+	sb.WriteString(" && /mo_br delete")
+	sb.WriteString(fmt.Sprintf(" %s", c.BackupID))
+	if c.ReadEnvSecret {
+		sb.WriteString(" --access_key_id=$AWS_ACCESS_KEY_ID")
+		sb.WriteString(" --secret_access_key=$AWS_SECRET_ACCESS_KEY")
+	}
+	return sb.String()
+}
+
+// systemDatabases are not dumped when an account is dumped
+var systemDatabases = []string{"mo_catalog", "information_schema", "system", "system_metrics", "mysql", "mo_task", "mo_debug"}
+
+type DumpCommand struct {
+	Host string
+	Port int
+	// Databases are the databases to dump with the tables, all the tables of a database are dumped
+	// if no table is listed. All the user databases of the account are dumped if not set
+	Databases []DumpDatabase
+	// Dir is the directory that the dump is written to, one SQL file per database
+	Dir string
+	// AWSCLI is the aws cli command that uploads the dump to S3Path, the dump is kept in Dir if not set
+	AWSCLI string
+	S3Path string
+}
+
+type DumpDatabase struct {
+	Name   string
+	Tables []string
+}
+
+func (c *DumpCommand) String() string {
+	sb := strings.Builder{}
+	dir := shellQuote(c.Dir)
+	sb.WriteString(fmt.Sprintf("mkdir -p %s", dir))
+	if len(c.Databases) == 0 {
+		sb.WriteString(fmt.Sprintf(" && for db in $(%s -N -e 'SHOW DATABASES' | grep -v -x", mysqlCLI(c.Host, c.Port)))
+		for _, db := range systemDatabases {
+			sb.WriteString(fmt.Sprintf(" -e %s", db))
+		}
+		sb.WriteString(fmt.Sprintf("); do %s -db \"$db\" > %s/\"$db\".sql || exit 1; done", c.moDump(), dir))
+	}
+	for _, db := range c.Databases {
+		sb.WriteString(fmt.Sprintf(" && %s -db %s", c.moDump(), shellQuote(db.Name)))
+		if len(db.Tables) > 0 {
+			sb.WriteString(fmt.Sprintf(" -tbl %s", shellQuote(strings.Join(db.Tables, ","))))
+		}
+		sb.WriteString(fmt.Sprintf(" > %s", shellQuote(fmt.Sprintf("%s/%s.sql", c.Dir, db.Name))))
+	}
+	if c.AWSCLI != "" {
+		sb.WriteString(fmt.Sprintf(" && %s s3 cp %s %s --recursive", c.AWSCLI, dir, shellQuote(c.S3Path)))
+	}
+	return sb.String()
+}
+
+func (c *DumpCommand) moDump() string {
+	return fmt.Sprintf("mo-dump -h %s -P %d -u \"$%s\" -p \"$%s\"", c.Host, c.Port, MOUserEnvKey, MOPasswordEnvKey)
+}
+
+type LoadCommand struct {
+	Host string
+	Port int
+	// Dir is the directory of the dump, each SQL file is loaded into the database named by the file
+	Dir string
+	// AWSCLI is the aws cli command that downloads the dump from S3Path to Dir, the dump is read from Dir directly if not set
+	AWSCLI string
+	S3Path string
+}
+
+func (c *LoadCommand) String() string {
+	sb := strings.Builder{}
+	dir := shellQuote(c.Dir)
+	if c.AWSCLI != "" {
+		sb.WriteString(fmt.Sprintf("mkdir -p %s && %s s3 cp %s %s --recursive && ", dir, c.AWSCLI, shellQuote(c.S3Path), dir))
+	}
+	mysql := mysqlCLI(c.Host, c.Port)
+	sb.WriteString(fmt.Sprintf("for f in %s/*.sql; do db=$(basename \"$f\" .sql)", dir))
+	sb.WriteString(fmt.Sprintf(" && %s -e \"CREATE DATABASE IF NOT EXISTS \\`$db\\`\"", mysql))
+	sb.WriteString(fmt.Sprintf(" && %s -D \"$db\" < \"$f\" || exit 1; done", mysql))
+	return sb.String()
+}
+
+// shellQuote quotes the value as a single word of the shell, so that it is never expanded or split
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func mysqlCLI(host string, port int) string {
+	return fmt.Sprintf("mysql -h %s -P %d -u \"$%s\" -p\"$%s\"", host, port, MOUserEnvKey, MOPasswordEnvKey)
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"testing"
+
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBackupCommand_String(t *testing.T) {
+	g := NewGomegaWithT(t)
+	minio := v1alpha1.S3ProviderTypeMinIO
+	cmd := &BackupCommand{
+		Host: "mo",
+		Port: 6001,
+		S3: newS3(&v1alpha1.S3Provider{
+			Path:      "bucket/backup",
+			Endpoint:  "http://minio:9000",
+			Type:      &minio,
+			SecretRef: &corev1.LocalObjectReference{Name: "minio"},
+		}),
+	}
+	g.Expect(cmd.String()).To(Equal("/mo_br backup --host=mo --port=6001 --user=$MO_USER --password=$MO_PASSWORD" +
+		" --backup_dir=s3 --endpoint=http://minio:9000 --bucket=bucket --filepath=backup --is_minio" +
+		" --access_key_id=$AWS_ACCESS_KEY_ID --secret_access_key=$AWS_SECRET_ACCESS_KEY" +
+		" && echo META_DELIMITER && cat /mo_br.meta"))
+
+	cmd = &BackupCommand{Host: "mo", Port: 6001, FileSystemPath: "/backup"}
+	g.Expect(cmd.String()).To(Equal("/mo_br backup --host=mo --port=6001 --user=$MO_USER --password=$MO_PASSWORD" +
+		" --backup_dir=filesystem --path=/backup && echo META_DELIMITER && cat /mo_br.meta"))
+
+	cmd.Encrypt = true
+	g.Expect(cmd.String()).To(ContainSubstring("--path=/backup --encryption_key_file=/etc/mo-br/encryption/key &&"))
+	cmd.Encrypt = false
+
+	cmd.BaseID = "base"
+	g.Expect(cmd.String()).To(HavePrefix(`echo "$RAW_META" > /mo_br.meta`))
+	g.Expect(cmd.String()).To(HaveSuffix("--path=/backup --backup_type=incremental --base_id=base" +
+		" && echo META_DELIMITER && tail -n 1 /mo_br.meta"))
+}
+
+func TestRestoreCommand_String(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cmd := &RestoreCommand{
+		BackupID: "id",
+		Target: newS3(&v1alpha1.S3Provider{
+			Path:      "bucket",
+			Endpoint:  "s3.amazonaws.com",
+			SecretRef: &corev1.LocalObjectReference{Name: "aws"},
+		}),
+	}
+	g.Expect(cmd.String()).To(HaveSuffix("/mo_br restore id --restore_dir s3 --restore_endpoint=s3.amazonaws.com --restore_bucket=bucket" +
+		" --restore_access_key_id=$RESTORE_ACCESS_KEY_ID --restore_secret_access_key=$RESTORE_SECRET_ACCESS_KEY"))
+
+	cmd = &RestoreCommand{BackupID: "id", TargetFileSystemPath: "/data", ReadSourceEnvSecret: true}
+	g.Expect(cmd.String()).To(HaveSuffix("/mo_br restore id --backup_access_key_id=$BACKUP_ACCESS_KEY_ID" +
+		" --backup_secret_access_key=$BACKUP_SECRET_ACCESS_KEY --restore_dir filesystem --restore_path=/data"))
+
+	// the metas are read from the fetched catalog, and the latest backup is restored if the id is not set
+	cmd = &RestoreCommand{TargetFileSystemPath: "/data", CatalogDir: "/catalog"}
+	g.Expect(cmd.String()).To(HavePrefix("cat /catalog/* > /mo_br.meta"))
+	g.Expect(cmd.String()).To(ContainSubstring("/mo_br restore $(ls -t /catalog | head -n 1) --restore_dir filesystem"))
+}
+
+func TestDumpCommand_String(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cmd := &DumpCommand{
+		Host:      "mo",
+		Port:      6001,
+		Databases: []DumpDatabase{{Name: "db1"}, {Name: "db2", Tables: []string{"t1", "t2"}}},
+		Dir:       "/dump/default_dump",
+		AWSCLI:    "aws",
+		S3Path:    "s3://bucket/dump/default_dump",
+	}
+	g.Expect(cmd.String()).To(Equal(`mkdir -p '/dump/default_dump'` +
+		` && mo-dump -h mo -P 6001 -u "$MO_USER" -p "$MO_PASSWORD" -db 'db1' > '/dump/default_dump/db1.sql'` +
+		` && mo-dump -h mo -P 6001 -u "$MO_USER" -p "$MO_PASSWORD" -db 'db2' -tbl 't1,t2' > '/dump/default_dump/db2.sql'` +
+		` && aws s3 cp '/dump/default_dump' 's3://bucket/dump/default_dump' --recursive`))
+
+	// the values are never expanded by the shell
+	cmd.Databases = []DumpDatabase{{Name: "db';$(rm -rf /)"}}
+	g.Expect(cmd.String()).To(ContainSubstring(`-db 'db'\'';$(rm -rf /)' >`))
+
+	// all the user databases are dumped for an account
+	cmd = &DumpCommand{Host: "mo", Port: 6001, Dir: "/backup/default_dump"}
+	g.Expect(cmd.String()).To(ContainSubstring(`for db in $(mysql -h mo -P 6001 -u "$MO_USER" -p"$MO_PASSWORD" -N -e 'SHOW DATABASES' | grep -v -x -e mo_catalog`))
+	g.Expect(cmd.String()).To(HaveSuffix(`-db "$db" > '/backup/default_dump'/"$db".sql || exit 1; done`))
+}
+
+func TestLoadCommand_String(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cmd := &LoadCommand{Host: "mo", Port: 6001, Dir: "/dump", AWSCLI: "aws", S3Path: "s3://bucket/dump/default_dump"}
+	g.Expect(cmd.String()).To(Equal(`mkdir -p '/dump' && aws s3 cp 's3://bucket/dump/default_dump' '/dump' --recursive` +
+		` && for f in '/dump'/*.sql; do db=$(basename "$f" .sql)` +
+		" && mysql -h mo -P 6001 -u \"$MO_USER\" -p\"$MO_PASSWORD\" -e \"CREATE DATABASE IF NOT EXISTS \\`$db\\`\"" +
+		` && mysql -h mo -P 6001 -u "$MO_USER" -p"$MO_PASSWORD" -D "$db" < "$f" || exit 1; done`))
+}
+
+func Test_mountStorage(t *testing.T) {
+	g := NewGomegaWithT(t)
+	bj := &v1alpha1.BackupJob{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "backup"}}
+	job := buildJob(bj, "br", "true", func(c *corev1.Container) {})
+	sp := &v1alpha1.SharedStorageProvider{FileSystem: &v1alpha1.FileSystemProvider{
+		Path: "/backup",
+		Volume: &v1alpha1.FileSystemVolume{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "backup"},
+		},
+	}}
+	g.Expect(checkStorage(sp)).To(Succeed())
+	mountStorage(job, targetVolume, sp)
+	podSpec := job.Spec.Template.Spec
+	g.Expect(podSpec.Volumes).To(HaveLen(1))
+	g.Expect(podSpec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("backup"))
+	g.Expect(podSpec.Containers[0].VolumeMounts).To(Equal([]corev1.VolumeMount{{Name: targetVolume, MountPath: "/backup"}}))
+
+	// the path is mounted once if the source and target share it
+	mountStorage(job, sourceVolume, sp)
+	g.Expect(job.Spec.Template.Spec.Volumes).To(HaveLen(1))
+	g.Expect(job.Spec.Template.Spec.Containers[0].VolumeMounts).To(HaveLen(1))
+
+	// S3 storage needs no mount
+	job = &batchv1.Job{}
+	mountStorage(job, targetVolume, &v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket"}})
+	g.Expect(job.Spec.Template.Spec.Volumes).To(BeEmpty())
+
+	g.Expect(checkStorage(&v1alpha1.SharedStorageProvider{})).NotTo(Succeed())
+}
@@ -15,9 +15,12 @@
 package br
 
 import (
+	"slices"
 	"time"
 
+	"github.com/go-errors/errors"
 	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/controller-runtime/pkg/util"
 	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
 	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
 	batchv1 "k8s.io/api/batch/v1"
@@ -30,6 +33,9 @@ import (
 
 const (
 	defaultCMDRestPort = 8080
+
+	sourceVolume = "source"
+	targetVolume = "target"
 )
 
 type JobObject interface {
@@ -41,15 +47,18 @@ type JobObject interface {
 }
 
 func buildJob(o JobObject, image string, command string, injectEnv func(c *corev1.Container)) *batchv1.Job {
-	meta := common.ObjMetaTemplate(o, o.GetName())
+	return buildJobWithMeta(common.ObjMetaTemplate(o, o.GetName()), o.GetOverlay(), image, []string{"/cmdrest", "--", command}, injectEnv)
+}
+
+func buildJobWithMeta(meta metav1.ObjectMeta, overlay *v1alpha1.Overlay, image string, command []string, injectEnv func(c *corev1.Container)) *batchv1.Job {
 	brContainer := corev1.Container{
 		Name:  "br",
 		Image: image,
 	}
-	if o.GetOverlay() != nil {
-		o.GetOverlay().OverlayMainContainer(&brContainer)
+	if overlay != nil {
+		overlay.OverlayMainContainer(&brContainer)
 	}
-	brContainer.Command = []string{"/cmdrest", "--", command}
+	brContainer.Command = command
 	injectEnv(&brContainer)
 	tpl := corev1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
@@ -60,9 +69,9 @@ func buildJob(o JobObject, image string, command string, injectEnv func(c *corev
 			RestartPolicy: corev1.RestartPolicyNever,
 		},
 	}
-	if o.GetOverlay() != nil {
-		o.GetOverlay().OverlayPodMeta(&tpl.ObjectMeta)
-		o.GetOverlay().OverlayPodSpec(&tpl.Spec)
+	if overlay != nil {
+		overlay.OverlayPodMeta(&tpl.ObjectMeta)
+		overlay.OverlayPodSpec(&tpl.Spec)
 	}
 	job := &batchv1.Job{
 		ObjectMeta: meta,
@@ -76,8 +85,74 @@ func buildJob(o JobObject, image string, command string, injectEnv func(c *corev
 	return job
 }
 
+// checkStorage checks whether the storage provider can be used by the br jobs
+func checkStorage(sp *v1alpha1.SharedStorageProvider) error {
+	switch {
+	case sp.S3 != nil && sp.FileSystem != nil:
+		return errors.New("more than 1 storage provider configured")
+	case sp.S3 != nil:
+		return nil
+	case sp.FileSystem != nil:
+		if sp.FileSystem.Path == "" {
+			return errors.New("path must be set for file-system storage")
+		}
+		return nil
+	default:
+		return errors.New("no storage provider configured")
+	}
+}
+
+// mountStorage mounts the volume of the fileSystem storage to the br container, the mount
+// is skipped for other storages and for fileSystem storages without a volume
+func mountStorage(job *batchv1.Job, name string, sp *v1alpha1.SharedStorageProvider) {
+	if sp.FileSystem == nil || sp.FileSystem.Volume == nil {
+		return
+	}
+	mountStorageTo(job, &job.Spec.Template.Spec.Containers[0], name, sp)
+}
+
+// mountStorageTo mounts the volume of the fileSystem storage to the given container of the job,
+// the mount is skipped if the path is already mounted, e.g. the source and target of a restore
+// share the same path
+func mountStorageTo(job *batchv1.Job, c *corev1.Container, name string, sp *v1alpha1.SharedStorageProvider) {
+	fs := sp.FileSystem
+	if fs == nil || fs.Volume == nil {
+		return
+	}
+	if slices.ContainsFunc(c.VolumeMounts, func(m corev1.VolumeMount) bool {
+		return m.MountPath == fs.Path
+	}) {
+		return
+	}
+	podSpec := &job.Spec.Template.Spec
+	podSpec.Volumes = util.UpsertByKey(podSpec.Volumes, corev1.Volume{
+		Name: name,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: fs.Volume.PersistentVolumeClaim,
+			NFS:                   fs.Volume.NFS,
+		},
+	}, func(v corev1.Volume) string {
+		return v.Name
+	})
+	c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+		Name:      name,
+		MountPath: fs.Path,
+	})
+}
+
+// injectStorageCredential injects the credentials of the storage to the container of the job
+func injectStorageCredential(job *batchv1.Job, c *corev1.Container, sp *v1alpha1.SharedStorageProvider) {
+	if sp.S3 != nil {
+		common.InjectS3Credential(sp.S3, &job.Spec.Template.Spec, c)
+	}
+}
+
 func buildSvc(o client.Object) *corev1.Service {
-	meta := common.ObjMetaTemplate(o, o.GetName())
+	return buildSvcWithMeta(common.ObjMetaTemplate(o, o.GetName()))
+}
+
+// buildSvcWithMeta builds the service that exposes the cmdrest port of the pods selected by the labels of the meta
+func buildSvcWithMeta(meta metav1.ObjectMeta) *corev1.Service {
 	svc := &corev1.Service{
 		ObjectMeta: meta,
 		Spec: corev1.ServiceSpec{
@@ -0,0 +1,78 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+)
+
+const encryptionKeyVolume = "encryption-key"
+
+// missingKey checks whether the data key is available in the namespace, a message describing the
+// missing key is returned if not
+func missingKey(cli recon.KubeClient, ns string, e *v1alpha1.BackupEncryption) (string, error) {
+	secret := &corev1.Secret{}
+	if err := cli.Get(types.NamespacedName{Namespace: ns, Name: e.SecretRef.Name}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf("secret %s/%s of the data key is not found", ns, e.SecretRef.Name), nil
+		}
+		return "", errors.WrapPrefix(err, "error get the secret of the data key", 0)
+	}
+	if len(secret.Data[e.KeyID]) == 0 {
+		return fmt.Sprintf("key %s is not found in secret %s/%s", e.KeyID, ns, e.SecretRef.Name), nil
+	}
+	return "", nil
+}
+
+// encryptionUnsupported checks whether the mo_br in the image supports the encryption, a message
+// describing the reason is returned if not. The version is resolved from the image tag, which must
+// be a semantic version
+func encryptionUnsupported(image string) string {
+	v, ok := v1alpha1.GetImageSemVer(image)
+	if !ok {
+		return fmt.Sprintf("cannot resolve the mo_br version from image %s to check the encryption support", image)
+	}
+	if !v1alpha1.HasMOFeature(*v, v1alpha1.MOFeatureBackupEncryption) {
+		return fmt.Sprintf("mo_br %s does not support the encryption", v)
+	}
+	return ""
+}
+
+// mountEncryptionKey mounts the data key to EncryptionKeyFile of the mo_br container, the key is read
+// from the file instead of the command line or the env so that it is not exposed in the process list
+func mountEncryptionKey(podSpec *corev1.PodSpec, c *corev1.Container, e *v1alpha1.BackupEncryption) {
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: encryptionKeyVolume,
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+			SecretName:  e.SecretRef.Name,
+			Items:       []corev1.KeyToPath{{Key: e.KeyID, Path: filepath.Base(EncryptionKeyFile)}},
+			DefaultMode: pointer.Int32(0400),
+		}},
+	})
+	c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{
+		Name:      encryptionKeyVolume,
+		MountPath: filepath.Dir(EncryptionKeyFile),
+		ReadOnly:  true,
+	})
+}
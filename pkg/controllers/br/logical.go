@@ -0,0 +1,212 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/controller-runtime/pkg/util"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/cmd"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// the dump in S3 is staged in the dump volume
+	dumpVolume    = "dump"
+	dumpMountPath = "/dump"
+)
+
+// logicalJob is a LogicalBackupJob or a LogicalRestoreJob
+type logicalJob interface {
+	JobObject
+
+	GetLogicalStatus() *v1alpha1.LogicalJobStatus
+}
+
+// observeLogicalJob returns the action of the logical job according to its phase
+func observeLogicalJob[T logicalJob](ctx *recon.Context[T], sync recon.Action[T], wait recon.Action[T]) recon.Action[T] {
+	switch ctx.Obj.GetLogicalStatus().Phase {
+	case v1alpha1.JobPhaseCompleted, v1alpha1.JobPhaseFailed:
+		return nil
+	case v1alpha1.JobPhaseRunning:
+		return wait
+	default:
+		return sync
+	}
+}
+
+// endpointCluster returns the cluster that the logical job connects to and the Secret of the credential,
+// nil is returned if the cluster is not found
+func endpointCluster(cli recon.KubeClient, ns string, ep *v1alpha1.LogicalEndpoint) (*v1alpha1.MatrixOneCluster, string, error) {
+	mo, err := getCluster(cli, ns, ep.ClusterRef)
+	if err != nil || mo == nil {
+		return nil, "", err
+	}
+	if !recon.IsReady(&mo.Status) || mo.Status.Host == "" || mo.Status.CredentialRef == nil {
+		return nil, "", recon.ErrReSync(fmt.Sprintf("wait cluster %s ready", mo.Name), pollInterval)
+	}
+	if ep.SecretRef != nil {
+		return mo, ep.SecretRef.Name, nil
+	}
+	return mo, mo.Status.CredentialRef.Name, nil
+}
+
+// dumpDir returns the directory of the dump of the LogicalBackupJob under the target
+func dumpDir(o client.Object) string {
+	return fmt.Sprintf("%s_%s", o.GetNamespace(), o.GetName())
+}
+
+// dumpLocation returns the location of the dump directory under the target
+func dumpLocation(target *v1alpha1.SharedStorageProvider, dir string) *v1alpha1.SharedStorageProvider {
+	loc := target.DeepCopy()
+	if loc.S3 != nil {
+		loc.S3.Path = fmt.Sprintf("%s/%s", strings.TrimSuffix(loc.S3.Path, "/"), dir)
+	} else {
+		loc.FileSystem.Path = path.Join(loc.FileSystem.Path, dir)
+	}
+	return loc
+}
+
+// s3URL returns the URL of the S3 location for the aws cli
+func s3URL(s3 *v1alpha1.S3Provider) string {
+	return fmt.Sprintf("s3://%s", strings.TrimSuffix(s3.Path, "/"))
+}
+
+// dumpDatabases groups the scope by database, nil is returned to dump all the databases of the account
+func dumpDatabases(scope *v1alpha1.LogicalScope) []DumpDatabase {
+	var dbs []DumpDatabase
+	for _, db := range scope.Databases {
+		dbs = append(dbs, DumpDatabase{Name: db})
+	}
+	index := map[string]int{}
+	for _, t := range scope.Tables {
+		db, table, _ := strings.Cut(t, ".")
+		i, ok := index[db]
+		if !ok {
+			i = len(dbs)
+			index[db] = i
+			dbs = append(dbs, DumpDatabase{Name: db})
+		}
+		dbs[i].Tables = append(dbs[i].Tables, table)
+	}
+	return dbs
+}
+
+// buildLogicalJob builds the job that runs the command with the credential against the dump storage
+func buildLogicalJob(o logicalJob, image string, command string, moSecret string, sp *v1alpha1.SharedStorageProvider) *batchv1.Job {
+	job := buildJob(o, image, command, func(c *corev1.Container) {
+		c.Env = moCredentialEnv(moSecret)
+	})
+	injectStorageCredential(job, &job.Spec.Template.Spec.Containers[0], sp)
+	mountStorage(job, targetVolume, sp)
+	if sp.S3 != nil {
+		podSpec := &job.Spec.Template.Spec
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name:         dumpVolume,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
+		br := &podSpec.Containers[0]
+		br.VolumeMounts = append(br.VolumeMounts, corev1.VolumeMount{Name: dumpVolume, MountPath: dumpMountPath})
+	}
+	return job
+}
+
+// startLogicalJob creates the job and the service to poll the job status
+func startLogicalJob[T logicalJob](ctx *recon.Context[T], job *batchv1.Job) error {
+	o := ctx.Obj
+	if err := util.Ignore(apierrors.IsAlreadyExists, ctx.CreateOwned(job)); err != nil {
+		return errors.WrapPrefix(err, "error ensure job", 0)
+	}
+	if err := util.Ignore(apierrors.IsAlreadyExists, ctx.CreateOwned(buildSvc(o))); err != nil {
+		return errors.WrapPrefix(err, "error ensure service", 0)
+	}
+	status := o.GetLogicalStatus()
+	status.Phase = v1alpha1.JobPhaseRunning
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:   v1alpha1.JobConditionTypeEnded,
+		Status: metav1.ConditionFalse,
+		Reason: "JobRunning",
+	})
+	return ctx.UpdateStatus(o)
+}
+
+// waitLogicalJob polls the status of the command, complete is called to record the result on success
+func waitLogicalJob[T logicalJob](ctx *recon.Context[T], complete func(status *v1alpha1.LogicalJobStatus)) error {
+	o := ctx.Obj
+	job := &batchv1.Job{}
+	if err := ctx.Get(types.NamespacedName{Namespace: o.GetNamespace(), Name: o.GetName()}, job); err != nil {
+		if apierrors.IsNotFound(err) {
+			return endLogicalJob(ctx, v1alpha1.JobPhaseFailed, "JobFailed", "job is cleaned externally")
+		}
+		return errors.WrapPrefix(err, "error get job", 0)
+	}
+	if job.Status.Failed > 0 {
+		return endLogicalJob(ctx, v1alpha1.JobPhaseFailed, "JobFailed", "job is failed")
+	}
+	svc := buildSvc(o)
+	status, err := cmd.GetCmdStatus(fmt.Sprintf("%s.%s", svc.Name, svc.Namespace), defaultCMDRestPort)
+	if err != nil {
+		return errors.WrapPrefix(err, "error get command status", 0)
+	}
+	if !status.Completed {
+		return recon.ErrReSync("wait command complete", pollInterval)
+	}
+	if status.ExitCode != 0 {
+		return endLogicalJob(ctx, v1alpha1.JobPhaseFailed, "JobFailed", status.Stderr)
+	}
+	if complete != nil {
+		complete(o.GetLogicalStatus())
+	}
+	o.GetLogicalStatus().CompletionTime = &metav1.Time{Time: time.Now()}
+	return endLogicalJob(ctx, v1alpha1.JobPhaseCompleted, "JobComplete", "")
+}
+
+func endLogicalJob[T logicalJob](ctx *recon.Context[T], phase string, reason string, msg string) error {
+	status := ctx.Obj.GetLogicalStatus()
+	status.Phase = phase
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:    v1alpha1.JobConditionTypeEnded,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: msg,
+	})
+	return ctx.UpdateStatus(ctx.Obj)
+}
+
+func finalizeLogicalJob[T logicalJob](ctx *recon.Context[T]) (bool, error) {
+	o := ctx.Obj
+	err := ctx.Delete(&batchv1.Job{ObjectMeta: common.ObjMetaTemplate(o, o.GetName())}, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err == nil {
+		// check next time
+		return false, nil
+	}
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	return false, errors.WrapPrefix(err, "error delete job", 0)
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// LogicalBackupActor reconciles LogicalBackupJob
+type LogicalBackupActor struct {
+	image string
+}
+
+func NewLogicalBackupActor(image string) *LogicalBackupActor {
+	return &LogicalBackupActor{image: image}
+}
+
+var _ recon.Actor[*v1alpha1.LogicalBackupJob] = &LogicalBackupActor{}
+
+func (c *LogicalBackupActor) Observe(ctx *recon.Context[*v1alpha1.LogicalBackupJob]) (recon.Action[*v1alpha1.LogicalBackupJob], error) {
+	return observeLogicalJob(ctx, c.syncJob, c.waitJob), nil
+}
+
+func (c *LogicalBackupActor) waitJob(ctx *recon.Context[*v1alpha1.LogicalBackupJob]) error {
+	lbj := ctx.Obj
+	return waitLogicalJob(ctx, func(status *v1alpha1.LogicalJobStatus) {
+		status.Location = dumpLocation(&lbj.Spec.Target, dumpDir(lbj))
+	})
+}
+
+func (c *LogicalBackupActor) syncJob(ctx *recon.Context[*v1alpha1.LogicalBackupJob]) error {
+	lbj := ctx.Obj
+	if lbj.Status.Phase == "" {
+		lbj.Status.Phase = v1alpha1.JobPhasePending
+	}
+	mo, moSecret, err := endpointCluster(ctx, lbj.Namespace, &lbj.Spec.Source)
+	if err != nil {
+		return err
+	}
+	if mo == nil {
+		return endLogicalJob(ctx, v1alpha1.JobPhaseFailed, "ClusterNotFound", fmt.Sprintf("cluster %s not found", lbj.Spec.Source.ClusterRef))
+	}
+	if err := checkStorage(&lbj.Spec.Target); err != nil {
+		return errors.WrapPrefix(err, "bad dump target", 0)
+	}
+	dumpCmd := &DumpCommand{
+		Host:      mo.Status.Host,
+		Port:      mo.Status.Port,
+		Databases: dumpDatabases(&lbj.Spec.Scope),
+	}
+	dir := dumpDir(lbj)
+	if s3 := lbj.Spec.Target.S3; s3 != nil {
+		// the dump is staged locally and then uploaded
+		dumpCmd.Dir = path.Join(dumpMountPath, dir)
+		dumpCmd.AWSCLI = awsCLI(s3)
+		dumpCmd.S3Path = fmt.Sprintf("%s/%s", s3URL(s3), dir)
+	} else {
+		dumpCmd.Dir = path.Join(lbj.Spec.Target.FileSystem.Path, dir)
+	}
+	job := buildLogicalJob(lbj, c.image, dumpCmd.String(), moSecret, &lbj.Spec.Target)
+	return startLogicalJob(ctx, job)
+}
+
+func (c *LogicalBackupActor) Finalize(ctx *recon.Context[*v1alpha1.LogicalBackupJob]) (bool, error) {
+	return finalizeLogicalJob(ctx)
+}
+
+func (c *LogicalBackupActor) Reconcile(mgr manager.Manager) error {
+	return recon.Setup[*v1alpha1.LogicalBackupJob](&v1alpha1.LogicalBackupJob{}, "logicalbackupjob", mgr, c, recon.WithBuildFn(func(b *builder.Builder) {
+		b.Owns(&batchv1.Job{})
+	}))
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"fmt"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// LogicalRestoreActor reconciles LogicalRestoreJob
+type LogicalRestoreActor struct {
+	image string
+}
+
+func NewLogicalRestoreActor(image string) *LogicalRestoreActor {
+	return &LogicalRestoreActor{image: image}
+}
+
+var _ recon.Actor[*v1alpha1.LogicalRestoreJob] = &LogicalRestoreActor{}
+
+func (c *LogicalRestoreActor) Observe(ctx *recon.Context[*v1alpha1.LogicalRestoreJob]) (recon.Action[*v1alpha1.LogicalRestoreJob], error) {
+	return observeLogicalJob(ctx, c.syncJob, c.waitJob), nil
+}
+
+func (c *LogicalRestoreActor) waitJob(ctx *recon.Context[*v1alpha1.LogicalRestoreJob]) error {
+	return waitLogicalJob(ctx, nil)
+}
+
+func (c *LogicalRestoreActor) syncJob(ctx *recon.Context[*v1alpha1.LogicalRestoreJob]) error {
+	lrj := ctx.Obj
+	if lrj.Status.Phase == "" {
+		lrj.Status.Phase = v1alpha1.JobPhasePending
+	}
+	// storage is the storage mounted to access the dump in the source location
+	source, storage := lrj.Spec.Source, lrj.Spec.Source
+	if name := lrj.Spec.BackupJobName; name != "" {
+		lbj := &v1alpha1.LogicalBackupJob{}
+		if err := ctx.Get(types.NamespacedName{Namespace: lrj.Namespace, Name: name}, lbj); err != nil {
+			if apierrors.IsNotFound(err) {
+				return endLogicalJob(ctx, v1alpha1.JobPhaseFailed, "BackupJobNotFound", fmt.Sprintf("logical backup job %s not found", name))
+			}
+			return errors.WrapPrefix(err, "error get logical backup job", 0)
+		}
+		switch lbj.Status.Phase {
+		case v1alpha1.JobPhaseFailed:
+			return endLogicalJob(ctx, v1alpha1.JobPhaseFailed, "BackupJobFailed", fmt.Sprintf("logical backup job %s is failed", name))
+		case v1alpha1.JobPhaseCompleted:
+			// the dump is a directory under the target of the backup job
+			source, storage = lbj.Status.Location, &lbj.Spec.Target
+		}
+		if source == nil {
+			return recon.ErrReSync(fmt.Sprintf("wait logical backup job %s complete", name), pollInterval)
+		}
+	}
+	if source == nil {
+		return errors.New("bad restore config, either backupJobName or source must be set")
+	}
+	if err := checkStorage(source); err != nil {
+		return errors.WrapPrefix(err, "bad dump source", 0)
+	}
+	mo, moSecret, err := endpointCluster(ctx, lrj.Namespace, &lrj.Spec.Target)
+	if err != nil {
+		return err
+	}
+	if mo == nil {
+		return endLogicalJob(ctx, v1alpha1.JobPhaseFailed, "ClusterNotFound", fmt.Sprintf("cluster %s not found", lrj.Spec.Target.ClusterRef))
+	}
+	loadCmd := &LoadCommand{
+		Host: mo.Status.Host,
+		Port: mo.Status.Port,
+	}
+	if s3 := source.S3; s3 != nil {
+		// the dump is downloaded before loaded
+		loadCmd.Dir = dumpMountPath
+		loadCmd.AWSCLI = awsCLI(s3)
+		loadCmd.S3Path = s3URL(s3)
+	} else {
+		loadCmd.Dir = source.FileSystem.Path
+	}
+	lrj.Status.Location = source
+	job := buildLogicalJob(lrj, c.image, loadCmd.String(), moSecret, storage)
+	return startLogicalJob(ctx, job)
+}
+
+func (c *LogicalRestoreActor) Finalize(ctx *recon.Context[*v1alpha1.LogicalRestoreJob]) (bool, error) {
+	return finalizeLogicalJob(ctx)
+}
+
+func (c *LogicalRestoreActor) Reconcile(mgr manager.Manager) error {
+	return recon.Setup[*v1alpha1.LogicalRestoreJob](&v1alpha1.LogicalRestoreJob{}, "logicalrestorejob", mgr, c, recon.WithBuildFn(func(b *builder.Builder) {
+		b.Owns(&batchv1.Job{})
+	}))
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/matrixorigin/controller-runtime/pkg/fake"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+func Test_dumpDatabases(t *testing.T) {
+	g := NewGomegaWithT(t)
+	g.Expect(dumpDatabases(&v1alpha1.LogicalScope{Account: "sys"})).To(BeNil())
+	g.Expect(dumpDatabases(&v1alpha1.LogicalScope{Tables: []string{"db1.t1", "db2.t1", "db1.t2"}})).To(Equal([]DumpDatabase{
+		{Name: "db1", Tables: []string{"t1", "t2"}},
+		{Name: "db2", Tables: []string{"t1"}},
+	}))
+}
+
+func TestLogicalJobs(t *testing.T) {
+	g := NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(v1alpha1.AddToScheme(s))
+
+	mo := &v1alpha1.MatrixOneCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mo"},
+		Status: v1alpha1.MatrixOneClusterStatus{
+			ConditionalStatus: v1alpha1.ConditionalStatus{Conditions: []metav1.Condition{{
+				Type:   recon.ConditionTypeReady,
+				Status: metav1.ConditionTrue,
+			}}},
+			CredentialRef: &corev1.LocalObjectReference{Name: "mo-credential"},
+			Host:          "mo-tp-cn",
+			Port:          6001,
+		},
+	}
+	lbj := &v1alpha1.LogicalBackupJob{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dump"},
+		Spec: v1alpha1.LogicalBackupJobSpec{
+			Source: v1alpha1.LogicalEndpoint{ClusterRef: mo.Name},
+			Scope:  v1alpha1.LogicalScope{Databases: []string{"db"}},
+			Target: v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{
+				Path:      "bucket/dump/",
+				SecretRef: &corev1.LocalObjectReference{Name: "aws"},
+			}},
+		},
+	}
+	lrj := &v1alpha1.LogicalRestoreJob{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "load"},
+		Spec: v1alpha1.LogicalRestoreJobSpec{
+			Target:        v1alpha1.LogicalEndpoint{ClusterRef: mo.Name, SecretRef: &corev1.LocalObjectReference{Name: "acc-admin"}},
+			BackupJobName: lbj.Name,
+		},
+	}
+	cli := &fake.Client{Client: fake.KubeClientBuilder().WithScheme(s).WithObjects(mo, lbj, lrj).WithStatusSubresource(lbj, lrj).Build()}
+	eventEmitter := fake.NewMockEventEmitter(gomock.NewController(t))
+
+	// the dump is staged locally and uploaded to the directory of the job under the target
+	backupCtx := fake.NewContext(lbj, cli, eventEmitter)
+	g.Expect(NewLogicalBackupActor("dump").syncJob(backupCtx)).To(Succeed())
+	g.Expect(lbj.Status.Phase).To(Equal(v1alpha1.JobPhaseRunning))
+	job := &batchv1.Job{}
+	g.Expect(backupCtx.Get(types.NamespacedName{Namespace: "default", Name: lbj.Name}, job)).To(Succeed())
+	c := job.Spec.Template.Spec.Containers[0]
+	g.Expect(c.Command[2]).To(ContainSubstring("-db 'db' > '/dump/default_dump/db.sql' && aws s3 cp '/dump/default_dump' 's3://bucket/dump/default_dump' --recursive"))
+	g.Expect(c.Env[0].ValueFrom.SecretKeyRef.Name).To(Equal("mo-credential"))
+	g.Expect(c.Env).To(ContainElement(HaveField("Name", "AWS_ACCESS_KEY_ID")))
+	g.Expect(c.VolumeMounts).To(Equal([]corev1.VolumeMount{{Name: dumpVolume, MountPath: dumpMountPath}}))
+
+	// the restore waits the dump to complete
+	restoreCtx := fake.NewContext(lrj, cli, eventEmitter)
+	actor := NewLogicalRestoreActor("dump")
+	g.Expect(actor.syncJob(restoreCtx)).To(BeAssignableToTypeOf(&recon.ReSync{}))
+	lbj.Status.Phase = v1alpha1.JobPhaseCompleted
+	lbj.Status.Location = dumpLocation(&lbj.Spec.Target, dumpDir(lbj))
+	g.Expect(backupCtx.UpdateStatus(lbj)).To(Succeed())
+
+	// the dump is downloaded and loaded with the credential of the restore
+	g.Expect(actor.syncJob(restoreCtx)).To(Succeed())
+	g.Expect(lrj.Status.Phase).To(Equal(v1alpha1.JobPhaseRunning))
+	g.Expect(lrj.Status.Location.S3.Path).To(Equal("bucket/dump/default_dump"))
+	g.Expect(restoreCtx.Get(types.NamespacedName{Namespace: "default", Name: lrj.Name}, job)).To(Succeed())
+	c = job.Spec.Template.Spec.Containers[0]
+	g.Expect(c.Command[2]).To(HavePrefix("mkdir -p '/dump' && aws s3 cp 's3://bucket/dump/default_dump' '/dump' --recursive"))
+	g.Expect(c.Env[0].ValueFrom.SecretKeyRef.Name).To(Equal("acc-admin"))
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"encoding/csv"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// indexes of the fields in the mo_br meta line
+const (
+	metaFieldID = iota
+	metaFieldSize
+	metaFieldPath
+	metaFieldAtTime
+	metaFieldDuration
+	metaFieldCompleteTime
+	metaFieldBackupTS
+	metaFieldBackupType
+	metaFieldVersion
+)
+
+// backupNameIDLength is the length of the backup id prefix in the name of the Backup
+const backupNameIDLength = 5
+
+// metaTimeLayouts are the layouts that mo_br might use to format the timestamps
+var metaTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05.999999999 -0700",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// backupMetaInfo is the information of a backup parsed from the mo_br meta line,
+// the fields that are absent in the meta line are left empty
+type backupMetaInfo struct {
+	ID           string
+	Size         *resource.Quantity
+	AtTime       *time.Time
+	CompleteTime *time.Time
+	Type         v1alpha1.BackupType
+	Version      string
+}
+
+// parseBackupMeta parses the meta line written by mo_br, which is a CSV record in the format of
+// id,size,path,atTime,duration,completeTime,backupTS,backupType[,version]
+func parseBackupMeta(raw string) (*backupMetaInfo, error) {
+	r := csv.NewReader(strings.NewReader(raw))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	fields, err := r.Read()
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "error parse backup meta", 0)
+	}
+	field := func(i int) string {
+		if i < len(fields) {
+			return strings.TrimSpace(fields[i])
+		}
+		return ""
+	}
+	info := &backupMetaInfo{
+		ID:      field(metaFieldID),
+		Version: field(metaFieldVersion),
+	}
+	if len(info.ID) < backupNameIDLength {
+		return nil, errors.Errorf("backup id is shorter than %d characters in backup meta: %s", backupNameIDLength, raw)
+	}
+	if size, err := strconv.ParseInt(field(metaFieldSize), 10, 64); err == nil {
+		info.Size = resource.NewQuantity(size, resource.BinarySI)
+	}
+	info.AtTime = parseMetaTime(field(metaFieldAtTime))
+	info.CompleteTime = parseMetaTime(field(metaFieldCompleteTime))
+	if strings.EqualFold(field(metaFieldBackupType), string(v1alpha1.BackupTypeIncremental)) {
+		info.Type = v1alpha1.BackupTypeIncremental
+	}
+	return info, nil
+}
+
+func parseMetaTime(s string) *time.Time {
+	// strip the monotonic clock reading of the go time string
+	if i := strings.Index(s, " m="); i >= 0 {
+		s = s[:i]
+	}
+	if s == "" {
+		return nil
+	}
+	for _, layout := range metaTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
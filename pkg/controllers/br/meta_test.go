@@ -0,0 +1,48 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func Test_parseBackupMeta(t *testing.T) {
+	g := NewGomegaWithT(t)
+	info, err := parseBackupMeta("018e1f5a-7b4c,1073741824,s3:bucket/backup,2025-03-01 02:00:00.123456 +0000 UTC m=+12.3,5m0s,2025-03-01 02:05:00.5 +0000 UTC,1740794400123456000,full,v2.1.0")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(info.ID).To(Equal("018e1f5a-7b4c"))
+	g.Expect(info.Size.String()).To(Equal("1Gi"))
+	g.Expect(info.AtTime.Equal(time.Date(2025, 3, 1, 2, 0, 0, 123456000, time.UTC))).To(BeTrue())
+	g.Expect(info.CompleteTime.Equal(time.Date(2025, 3, 1, 2, 5, 0, 500000000, time.UTC))).To(BeTrue())
+	g.Expect(info.Version).To(Equal("v2.1.0"))
+	g.Expect(info.Type).To(BeEmpty())
+
+	// the fields absent in the meta are left empty
+	info, err = parseBackupMeta("abcdefg,meta")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(info.ID).To(Equal("abcdefg"))
+	g.Expect(info.Size).To(BeNil())
+	g.Expect(info.AtTime).To(BeNil())
+	g.Expect(info.Version).To(BeEmpty())
+
+	_, err = parseBackupMeta("")
+	g.Expect(err).To(HaveOccurred())
+	// the id prefix names the Backup
+	_, err = parseBackupMeta("abc,meta")
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,471 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	migrationStagePreCopy = "precopy"
+	migrationStageSync    = "sync"
+	migrationStageVerify  = "verify"
+
+	// migrationStageLabel distinguishes the pods of the stages so that the service of a stage selects its own pod
+	migrationStageLabel = "matrixorigin.io/migration-stage"
+
+	// the listings and the objects in transit are staged in the migration volume
+	migrationVolume    = "migration"
+	migrationMountPath = "/tmp/migration"
+
+	// the objects checksummed by the pre-copy are mounted to the verification
+	migrationChecksummedVolume    = "checksummed"
+	migrationChecksummedMountPath = "/etc/migration/checksummed"
+	migrationChecksummedKey       = "objects"
+
+	migrationPlanMarker        = "MIGRATION_PLAN"
+	migrationCopyMarker        = "MIGRATION_COPY"
+	migrationVerifyMarker      = "MIGRATION_VERIFY"
+	migrationMismatchMarker    = "MIGRATION_MISMATCH"
+	migrationChecksummedMarker = "MIGRATION_CHECKSUMMED"
+	migrationProgressMarker    = "MIGRATION_PROGRESS"
+
+	// maxMismatchedKeys is the max number of mismatched keys reported in status
+	maxMismatchedKeys = 10
+
+	// maxChecksummedBytes is the max size of the checksummed objects kept in the ConfigMap, the objects
+	// beyond are checksummed again by the verification
+	maxChecksummedBytes = 900 * 1024
+
+	// migrationProgressInterval is the interval that the scripts report the progress of a step
+	migrationProgressInterval = 10 * time.Second
+)
+
+// migrationSide is the source or the target of a migration in the scripts
+type migrationSide struct {
+	// Name is the name of the aws cli wrapper of the side, which is also the prefix of the listing files
+	Name string
+	// Args are the global options of the aws cli to access the storage
+	Args string
+	// AccessKeyEnv and SecretKeyEnv are the env of the static keys, empty if the keys are not configured
+	AccessKeyEnv string
+	SecretKeyEnv string
+	// Virtual addresses the bucket in the virtual hosted style
+	Virtual bool
+}
+
+type migrationScriptArgs struct {
+	Dir         string
+	Stage       string
+	Parallelism int32
+	Sides       []migrationSide
+
+	// ChecksummedFile is the file of the objects checksummed by the pre-copy, read by the verification
+	ChecksummedFile string
+	ProgressSeconds int
+
+	PlanMarker        string
+	CopyMarker        string
+	VerifyMarker      string
+	MismatchMarker    string
+	ChecksummedMarker string
+	ProgressMarker    string
+	MaxMismatched     int
+}
+
+// migrationTpl renders the script of a migration stage. The aws cli of each side is wrapped in a script that
+// sets the credentials and the options of the side, so that the source and the target can be accessed with
+// different credentials in the same pod. The objects are listed as '<relative key>\t<size>\t<etag>' sorted by key,
+// and a copy pass copies the objects that are missing or differ in size in the target, the final sync removes
+// the objects that are not in the source as well. The objects up to 5GB are uploaded in a single part, so that
+// the ETags of the copies are the MD5 checksums of the contents. The verification compares the ETags, and
+// compares the MD5 checksums of the contents if the ETags are not comparable, e.g. the source object is uploaded
+// in multiple parts. The pre-copy checksums such objects in advance and reports them, so that only the objects
+// changed after the pre-copy are checksummed while the writes are quiesced.
+var migrationTpl = template.Must(template.New("migration").Parse(`set -eu
+dir={{.Dir}}
+tab=$(printf '\t')
+{{- range .Sides}}
+cat > "$dir/{{.Name}}" <<'EOF'
+#!/bin/sh
+{{- if .AccessKeyEnv}}
+export AWS_ACCESS_KEY_ID="${{.AccessKeyEnv}}" AWS_SECRET_ACCESS_KEY="${{.SecretKeyEnv}}"
+{{- end}}
+export AWS_CONFIG_FILE={{$.Dir}}/{{.Name}}.config
+exec aws{{.Args}} "$@"
+EOF
+chmod +x "$dir/{{.Name}}"
+AWS_CONFIG_FILE="$dir/{{.Name}}.config" aws configure set default.s3.multipart_threshold 5GB
+{{- if .Virtual}}
+AWS_CONFIG_FILE="$dir/{{.Name}}.config" aws configure set default.s3.addressing_style virtual
+{{- end}}
+{{- end}}
+count() { awk 'END { print NR }' "$1"; }
+list() {
+  "$dir/$1" s3api list-objects-v2 --bucket "$2" --prefix "$3" --query 'Contents[].[Key,Size,ETag]' --output text > "$dir/$1.raw"
+  awk -F "$tab" -v OFS="$tab" -v n="${#3}" 'NF >= 3 && length($1) > n { etag = tolower($3); gsub(/"/, "", etag); print substr($1, n + 1), $2, etag }' "$dir/$1.raw" | LC_ALL=C sort -t "$tab" -k 1,1 > "$dir/$1.list"
+}
+# run runs the command for each key in the file of the step, the command appends the key to the done file
+# of the step, which is counted in the progress reported periodically while the step is running
+run() {
+  : > "$dir/$1.done"
+  while sleep {{.ProgressSeconds}}; do echo "{{.ProgressMarker}} $1 done=$(count "$dir/$1.done") total=$(count "$dir/$1")"; done &
+  reporter=$!
+  rc=0
+  tr '\n' '\0' < "$dir/$1" | xargs -0 -r -n 1 -P {{.Parallelism}} sh -c "$2" "$1" || rc=$?
+  kill "$reporter" 2>/dev/null || true
+  return $rc
+}
+# compare classifies the objects as '<class>\t<key>\t<source size>\t<source etag>\t<target size>\t<target etag>',
+# the objects in the checksummed file are verified if they are not changed since they were checksummed
+compare() {
+  LC_ALL=C join -t "$tab" -a 1 -a 2 -e MISSING -o 0,1.2,1.3,2.2,2.3 "$dir/source.list" "$dir/target.list" | awk -F "$tab" -v OFS="$tab" -v checksummed="$1" -v summary="$dir/summary" '
+FILENAME == checksummed { verified[$0]; next }
+$4 == "MISSING" { print "missing", $0; next }
+$2 == "MISSING" { print "extra", $0; next }
+{ objects++; bytes += $2 }
+$2 != $4 { print "size", $0; next }
+$3 == $5 && $3 !~ /-/ { print "etag", $0; next }
+($1 OFS $2 OFS $3 OFS $5) in verified { print "verified", $0; next }
+{ print "checksum", $0 }
+END { printf "objects=%.0f bytes=%.0f\n", objects, bytes > summary }' "$1" - > "$dir/verify"
+}
+# checksum compares the MD5 checksums of the contents of the objects whose ETags are not comparable
+checksum() {
+  awk -F "$tab" '$1 == "checksum" { print $2 }' "$dir/verify" > "$dir/checksum"
+  : > "$dir/corrupted"
+  run checksum 's=$(mktemp {{.Dir}}/object.XXXXXX) && t=$(mktemp {{.Dir}}/object.XXXXXX) && {{.Dir}}/source s3 cp --only-show-errors "$SOURCE_URL/$1" "$s" && {{.Dir}}/target s3 cp --only-show-errors "$TARGET_URL/$1" "$t" && [ "$(md5sum < "$s")" = "$(md5sum < "$t")" ] || echo "$1" >> {{.Dir}}/corrupted; rm -f "$s" "$t"; echo "$1" >> {{.Dir}}/checksum.done' || true
+}
+list source "$SOURCE_BUCKET" "$SOURCE_PREFIX"
+list target "$TARGET_BUCKET" "$TARGET_PREFIX"
+{{- if eq .Stage "verify"}}
+checksummed={{.ChecksummedFile}}
+[ -f "$checksummed" ] || checksummed=/dev/null
+compare "$checksummed"
+checksum
+awk -F "$tab" '$1 != "etag" && $1 != "verified" && $1 != "checksum" { print $2 }' "$dir/verify" | cat - "$dir/corrupted" > "$dir/mismatched"
+echo "{{.VerifyMarker}} $(cat "$dir/summary") etag=$(awk -F "$tab" '$1 == "etag" { n++ } END { print n + 0 }' "$dir/verify") verified=$(awk -F "$tab" '$1 == "verified" { n++ } END { print n + 0 }' "$dir/verify") checksum=$(count "$dir/checksum") mismatched=$(count "$dir/mismatched")"
+head -n {{.MaxMismatched}} "$dir/mismatched" | sed 's/^/{{.MismatchMarker}} /'
+{{- else}}
+awk -F "$tab" '{ n++; s += $2 } END { printf "objects=%.0f bytes=%.0f\n", n, s }' "$dir/source.list" > "$dir/summary"
+cut -f 1,2 "$dir/source.list" | LC_ALL=C sort > "$dir/source.sizes"
+cut -f 1,2 "$dir/target.list" | LC_ALL=C sort > "$dir/target.sizes"
+LC_ALL=C comm -23 "$dir/source.sizes" "$dir/target.sizes" | cut -f 1 > "$dir/copy"
+echo "{{.PlanMarker}} $(cat "$dir/summary") missing=$(count "$dir/copy")"
+run copy 'f=$(mktemp {{.Dir}}/object.XXXXXX) && {{.Dir}}/source s3 cp --only-show-errors "$SOURCE_URL/$1" "$f" && {{.Dir}}/target s3 cp --only-show-errors "$f" "$TARGET_URL/$1"; rc=$?; rm -f "$f"; [ $rc -eq 0 ] && echo "$1" >> {{.Dir}}/copy.done; exit $rc'
+: > "$dir/remove"
+: > "$dir/checksummed"
+{{- if eq .Stage "sync"}}
+cut -f 1 "$dir/source.list" > "$dir/source.keys"
+cut -f 1 "$dir/target.list" > "$dir/target.keys"
+LC_ALL=C comm -13 "$dir/source.keys" "$dir/target.keys" > "$dir/remove"
+run remove '{{.Dir}}/target s3 rm --only-show-errors "$TARGET_URL/$1" && echo "$1" >> {{.Dir}}/remove.done'
+{{- else}}
+list target "$TARGET_BUCKET" "$TARGET_PREFIX"
+compare /dev/null
+checksum
+awk -F "$tab" -v OFS="$tab" -v corrupted="$dir/corrupted" 'FILENAME == corrupted { bad[$0]; next } $1 == "checksum" && !($2 in bad) { print $2, $3, $4, $6 }' "$dir/corrupted" "$dir/verify" > "$dir/checksummed"
+sed 's/^/{{.ChecksummedMarker}} /' "$dir/checksummed"
+{{- end}}
+echo "{{.CopyMarker}} copied=$(count "$dir/copy") removed=$(count "$dir/remove") checksummed=$(count "$dir/checksummed")"
+{{- end}}
+`))
+
+// newMigrationSide returns the side of the S3 storage in the scripts, the static keys of the side are
+// injected to the env prefixed by env
+func newMigrationSide(name string, env string, s3 *v1alpha1.S3Provider) migrationSide {
+	side := migrationSide{
+		Name:    name,
+		Virtual: s3.GetProviderType() == v1alpha1.S3ProviderTypeOSS,
+	}
+	if endpoint := s3.GetEndpoint(); endpoint != "" {
+		side.Args += fmt.Sprintf(" --endpoint-url %s", endpoint)
+	}
+	if s3.Region != "" {
+		side.Args += fmt.Sprintf(" --region %s", s3.Region)
+	}
+	if staticKeys(s3) {
+		side.AccessKeyEnv = env + "_" + common.AWSAccessKeyID
+		side.SecretKeyEnv = env + "_" + common.AWSSecretAccessKey
+	}
+	return side
+}
+
+// staticKeys returns whether the S3 storage is accessed with the static keys in a Secret
+func staticKeys(s3 *v1alpha1.S3Provider) bool {
+	return s3.WebIdentity == nil && s3.SecretRef != nil
+}
+
+// migrationScript renders the script of the stage that migrates the objects from the source to the target
+func migrationScript(stage string, parallelism int32, source, target *v1alpha1.S3Provider) (string, error) {
+	args := migrationScriptArgs{
+		Dir:         migrationMountPath,
+		Stage:       stage,
+		Parallelism: parallelism,
+		Sides: []migrationSide{
+			newMigrationSide("source", "SOURCE", source),
+			newMigrationSide("target", "TARGET", target),
+		},
+		ChecksummedFile:   migrationChecksummedMountPath + "/" + migrationChecksummedKey,
+		ProgressSeconds:   int(migrationProgressInterval.Seconds()),
+		PlanMarker:        migrationPlanMarker,
+		CopyMarker:        migrationCopyMarker,
+		VerifyMarker:      migrationVerifyMarker,
+		MismatchMarker:    migrationMismatchMarker,
+		ChecksummedMarker: migrationChecksummedMarker,
+		ProgressMarker:    migrationProgressMarker,
+		MaxMismatched:     maxMismatchedKeys,
+	}
+	buf := &bytes.Buffer{}
+	if err := migrationTpl.Execute(buf, args); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// s3Location splits the path of the S3 storage to the bucket and the key prefix of the objects
+func s3Location(s3 *v1alpha1.S3Provider) (bucket string, prefix string) {
+	bucket, dir, _ := strings.Cut(strings.Trim(s3.Path, "/"), "/")
+	if dir != "" {
+		prefix = dir + "/"
+	}
+	return bucket, prefix
+}
+
+// migrationEnv returns the env of the locations of the S3 storage
+func migrationEnv(env string, s3 *v1alpha1.S3Provider) []corev1.EnvVar {
+	bucket, prefix := s3Location(s3)
+	return []corev1.EnvVar{
+		{Name: env + "_BUCKET", Value: bucket},
+		{Name: env + "_PREFIX", Value: prefix},
+		{Name: env + "_URL", Value: s3URL(s3)},
+	}
+}
+
+// migrationMeta returns the meta of the job and the service of the stage
+func migrationMeta(sm *v1alpha1.StorageMigration, stage string) metav1.ObjectMeta {
+	meta := common.ObjMetaTemplate(sm, fmt.Sprintf("%s-%s", sm.Name, stage))
+	meta.Labels[migrationStageLabel] = stage
+	return meta
+}
+
+// buildMigrationJob builds the job that runs the stage of the migration
+func buildMigrationJob(sm *v1alpha1.StorageMigration, image string, stage string) (*batchv1.Job, error) {
+	source := sm.Status.Source.S3
+	target := sm.Spec.Target.S3
+	script, err := migrationScript(stage, sm.GetParallelism(), source, target)
+	if err != nil {
+		return nil, err
+	}
+	job := buildJobWithMeta(migrationMeta(sm, stage), sm.Spec.Overlay, image, []string{"/cmdrest", "--", script}, func(c *corev1.Container) {
+		c.Env = append(c.Env, migrationEnv("SOURCE", source)...)
+		c.Env = append(c.Env, migrationEnv("TARGET", target)...)
+	})
+	podSpec := &job.Spec.Template.Spec
+	c := &podSpec.Containers[0]
+	for _, side := range []struct {
+		env string
+		s3  *v1alpha1.S3Provider
+	}{{"SOURCE", source}, {"TARGET", target}} {
+		if staticKeys(side.s3) {
+			// the keys of the sides are wrapped to the aws env by the scripts
+			common.NewS3SecretSource(side.s3, side.env+"_"+common.AWSAccessKeyID, side.env+"_"+common.AWSSecretAccessKey).Inject(podSpec, c)
+		} else {
+			common.InjectS3Credential(side.s3, podSpec, c)
+		}
+	}
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name:         migrationVolume,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	})
+	c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{Name: migrationVolume, MountPath: migrationMountPath})
+	if stage == migrationStageVerify {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: migrationChecksummedVolume,
+			VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: checksummedName(sm)},
+				Optional:             pointer.Bool(true),
+			}},
+		})
+		c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{Name: migrationChecksummedVolume, MountPath: migrationChecksummedMountPath, ReadOnly: true})
+	}
+	return job, nil
+}
+
+// checksummedName is the name of the ConfigMap of the objects checksummed by the pre-copy
+func checksummedName(sm *v1alpha1.StorageMigration) string {
+	return fmt.Sprintf("%s-checksummed", sm.Name)
+}
+
+// buildChecksummed builds the ConfigMap of the objects checksummed by the pre-copy, the objects beyond the
+// size limit of the ConfigMap are left to the verification
+func buildChecksummed(sm *v1alpha1.StorageMigration, out string) *corev1.ConfigMap {
+	buf := &strings.Builder{}
+	for _, line := range strings.Split(out, "\n") {
+		obj, ok := strings.CutPrefix(line, migrationChecksummedMarker+" ")
+		if !ok {
+			continue
+		}
+		if buf.Len()+len(obj)+1 > maxChecksummedBytes {
+			break
+		}
+		buf.WriteString(obj)
+		buf.WriteString("\n")
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: common.ObjMetaTemplate(sm, checksummedName(sm)),
+		Data:       map[string]string{migrationChecksummedKey: buf.String()},
+	}
+}
+
+// migrationUnsupported returns a non-empty reason if the shared storage of the cluster cannot be migrated to the target
+func migrationUnsupported(mo *v1alpha1.MatrixOneCluster, target *v1alpha1.SharedStorageProvider) string {
+	source := mo.Spec.LogService.SharedStorage.S3
+	switch {
+	case source == nil || target.S3 == nil:
+		return "only the S3 compatible storages can be migrated"
+	case source.CertificateRef != nil || target.S3.CertificateRef != nil:
+		return "the storages with custom certificates cannot be migrated"
+	case source.GetEndpoint() == target.S3.GetEndpoint() && pathOverlapped(source.Path, target.S3.Path):
+		return fmt.Sprintf("target path %q overlaps with the source path %q", target.S3.Path, source.Path)
+	case source.WebIdentity != nil && target.S3.WebIdentity != nil && !equality.Semantic.DeepEqual(source.WebIdentity, target.S3.WebIdentity):
+		// the web identity is injected to the aws env of the pod, which is shared by the sides
+		return "the source and the target must use the same web identity"
+	}
+	if etl := mo.Spec.LogService.GetETLS3(); etl != nil {
+		if v1alpha1.UniqueBucketLabel(etl) == v1alpha1.UniqueBucketLabel(target.S3) {
+			return "target must not be the etl storage"
+		}
+		// the etl storage must be accessed with the credentials of the shared storage
+		if !equality.Semantic.DeepEqual(etl.SecretRef, target.S3.SecretRef) || !equality.Semantic.DeepEqual(etl.WebIdentity, target.S3.WebIdentity) {
+			return "the etl storage must be accessed with the credentials of the target"
+		}
+	}
+	return ""
+}
+
+func pathOverlapped(a, b string) bool {
+	a = strings.Trim(a, "/") + "/"
+	b = strings.Trim(b, "/") + "/"
+	return strings.HasPrefix(a, b) || strings.HasPrefix(b, a)
+}
+
+// parseMigrationMarker parses the 'key=value' fields of the line of the marker in the output
+func parseMigrationMarker(out string, marker string) (map[string]int64, error) {
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] != marker {
+			continue
+		}
+		return parseMarkerFields(marker, fields[1:])
+	}
+	return nil, fmt.Errorf("no %s found in output", marker)
+}
+
+func parseMarkerFields(marker string, fields []string) (map[string]int64, error) {
+	values := map[string]int64{}
+	for _, f := range fields {
+		k, v, ok := strings.Cut(f, "=")
+		if !ok {
+			return nil, fmt.Errorf("bad field %q in %s", f, marker)
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad field %q in %s: %v", f, marker, err)
+		}
+		values[k] = n
+	}
+	return values, nil
+}
+
+// parseProgress parses the latest progress of the running stage in the output, nil is returned if no
+// progress is reported yet
+func parseProgress(stage string, out string) *v1alpha1.StorageMigrationProgress {
+	lines := strings.Split(out, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		fields := strings.Fields(lines[i])
+		if len(fields) < 2 || fields[0] != migrationProgressMarker {
+			continue
+		}
+		values, err := parseMarkerFields(migrationProgressMarker, fields[2:])
+		if err != nil {
+			// the line might be partially written
+			continue
+		}
+		return &v1alpha1.StorageMigrationProgress{
+			Stage: stage,
+			Step:  fields[1],
+			Done:  values["done"],
+			Total: values["total"],
+		}
+	}
+	return nil
+}
+
+// parseCopyResult parses the output of a copy pass
+func parseCopyResult(out string) (*v1alpha1.StorageMigrationCopyStatus, error) {
+	plan, err := parseMigrationMarker(out, migrationPlanMarker)
+	if err != nil {
+		return nil, err
+	}
+	res, err := parseMigrationMarker(out, migrationCopyMarker)
+	if err != nil {
+		return nil, err
+	}
+	return &v1alpha1.StorageMigrationCopyStatus{
+		Objects:        plan["objects"],
+		Bytes:          plan["bytes"],
+		Copied:         res["copied"],
+		Removed:        res["removed"],
+		Checksummed:    res["checksummed"],
+		CompletionTime: &metav1.Time{Time: time.Now()},
+	}, nil
+}
+
+// parseVerification parses the output of the verification
+func parseVerification(out string) (*v1alpha1.StorageMigrationVerification, error) {
+	res, err := parseMigrationMarker(out, migrationVerifyMarker)
+	if err != nil {
+		return nil, err
+	}
+	v := &v1alpha1.StorageMigrationVerification{
+		Objects:        res["objects"],
+		Bytes:          res["bytes"],
+		ETagMatched:    res["etag"],
+		Preverified:    res["verified"],
+		Checksummed:    res["checksum"],
+		Mismatched:     res["mismatched"],
+		CompletionTime: &metav1.Time{Time: time.Now()},
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if key, ok := strings.CutPrefix(line, migrationMismatchMarker+" "); ok {
+			v.MismatchedKeys = append(v.MismatchedKeys, key)
+		}
+	}
+	return v, nil
+}
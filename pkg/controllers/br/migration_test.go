@@ -0,0 +1,209 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/matrixorigin/controller-runtime/pkg/fake"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+func Test_migrationScript(t *testing.T) {
+	g := NewGomegaWithT(t)
+	oss := v1alpha1.S3ProviderTypeOSS
+	source := &v1alpha1.S3Provider{Path: "bucket/data", SecretRef: &corev1.LocalObjectReference{Name: "aws"}}
+	target := &v1alpha1.S3Provider{Type: &oss, Path: "new-bucket/data", Endpoint: "https://oss-cn-hangzhou.aliyuncs.com"}
+
+	script, err := migrationScript(migrationStagePreCopy, 4, source, target)
+	g.Expect(err).NotTo(HaveOccurred())
+	// each side accesses its storage with its own credentials and options
+	g.Expect(script).To(ContainSubstring(`export AWS_ACCESS_KEY_ID="$SOURCE_AWS_ACCESS_KEY_ID"`))
+	g.Expect(script).NotTo(ContainSubstring("TARGET_AWS_ACCESS_KEY_ID"))
+	g.Expect(script).To(ContainSubstring("exec aws --endpoint-url https://oss-cn-hangzhou.aliyuncs.com"))
+	g.Expect(script).To(ContainSubstring(`AWS_CONFIG_FILE="$dir/target.config" aws configure set default.s3.addressing_style virtual`))
+	g.Expect(script).To(ContainSubstring("-P 4"))
+	g.Expect(script).NotTo(ContainSubstring("s3 rm"))
+	// the copies keep the MD5 ETags and the objects with incomparable ETags are checksummed before quiescing
+	g.Expect(script).To(ContainSubstring(`AWS_CONFIG_FILE="$dir/target.config" aws configure set default.s3.multipart_threshold 5GB`))
+	g.Expect(script).To(ContainSubstring(migrationChecksummedMarker))
+	g.Expect(script).To(ContainSubstring(migrationProgressMarker))
+
+	// the final sync removes the objects that are removed from the source
+	script, err = migrationScript(migrationStageSync, 4, source, target)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(script).To(ContainSubstring("s3 rm"))
+
+	script, err = migrationScript(migrationStageVerify, 4, source, target)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(script).To(ContainSubstring("md5sum"))
+	g.Expect(script).To(ContainSubstring(migrationChecksummedMountPath + "/" + migrationChecksummedKey))
+	g.Expect(script).NotTo(ContainSubstring(migrationCopyMarker))
+}
+
+func Test_s3Location(t *testing.T) {
+	g := NewGomegaWithT(t)
+	bucket, prefix := s3Location(&v1alpha1.S3Provider{Path: "bucket/data/mo/"})
+	g.Expect(bucket).To(Equal("bucket"))
+	g.Expect(prefix).To(Equal("data/mo/"))
+	bucket, prefix = s3Location(&v1alpha1.S3Provider{Path: "bucket"})
+	g.Expect(bucket).To(Equal("bucket"))
+	g.Expect(prefix).To(BeEmpty())
+}
+
+func Test_parseMigrationResult(t *testing.T) {
+	g := NewGomegaWithT(t)
+	res, err := parseCopyResult("MIGRATION_PLAN objects=10 bytes=2048 missing=3\nupload failed: none\nMIGRATION_COPY copied=3 removed=1\n")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.Objects).To(Equal(int64(10)))
+	g.Expect(res.Bytes).To(Equal(int64(2048)))
+	g.Expect(res.Copied).To(Equal(int64(3)))
+	g.Expect(res.Removed).To(Equal(int64(1)))
+	_, err = parseCopyResult("MIGRATION_PLAN objects=10 bytes=2048 missing=3\n")
+	g.Expect(err).To(HaveOccurred())
+
+	out := "MIGRATION_PLAN objects=2 bytes=20 missing=2\nMIGRATION_CHECKSUMMED big\t10\tabc-2\t0cc1\nMIGRATION_COPY copied=2 removed=0 checksummed=1\n"
+	res, err = parseCopyResult(out)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.Checksummed).To(Equal(int64(1)))
+	sm := &v1alpha1.StorageMigration{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "migrate"}}
+	cm := buildChecksummed(sm, out)
+	g.Expect(cm.Name).To(Equal("migrate-checksummed"))
+	g.Expect(cm.Data).To(Equal(map[string]string{migrationChecksummedKey: "big\t10\tabc-2\t0cc1\n"}))
+
+	// the latest complete progress of the running stage is reported
+	g.Expect(parseProgress(migrationStagePreCopy, "MIGRATION_PLAN objects=2 bytes=20 missing=2\n")).To(BeNil())
+	p := parseProgress(migrationStagePreCopy, "MIGRATION_PROGRESS copy done=1 total=3\nMIGRATION_PROGRESS copy done=2 total=3\nMIGRATION_PROGRESS copy done=")
+	g.Expect(p).To(Equal(&v1alpha1.StorageMigrationProgress{Stage: migrationStagePreCopy, Step: "copy", Done: 2, Total: 3}))
+
+	v, err := parseVerification("MIGRATION_VERIFY objects=10 bytes=2048 etag=7 verified=1 checksum=2 mismatched=2\nMIGRATION_MISMATCH a/b c\nMIGRATION_MISMATCH d\n")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(v.ETagMatched).To(Equal(int64(7)))
+	g.Expect(v.Preverified).To(Equal(int64(1)))
+	g.Expect(v.Checksummed).To(Equal(int64(2)))
+	g.Expect(v.Mismatched).To(Equal(int64(2)))
+	g.Expect(v.MismatchedKeys).To(Equal([]string{"a/b c", "d"}))
+	_, err = parseVerification("MIGRATION_VERIFY objects=ten\n")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func Test_migrationUnsupported(t *testing.T) {
+	g := NewGomegaWithT(t)
+	aws := &corev1.LocalObjectReference{Name: "aws"}
+	mo := &v1alpha1.MatrixOneCluster{}
+	mo.Spec.LogService.SharedStorage.S3 = &v1alpha1.S3Provider{Path: "bucket/data", SecretRef: aws}
+	target := func(path string) *v1alpha1.SharedStorageProvider {
+		return &v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: path, SecretRef: aws}}
+	}
+	g.Expect(migrationUnsupported(mo, target("new-bucket/data"))).To(BeEmpty())
+	g.Expect(migrationUnsupported(mo, target("bucket/data-new"))).To(BeEmpty())
+	g.Expect(migrationUnsupported(mo, target("bucket/data/new"))).NotTo(BeEmpty())
+	g.Expect(migrationUnsupported(mo, target("bucket"))).NotTo(BeEmpty())
+	g.Expect(migrationUnsupported(mo, &v1alpha1.SharedStorageProvider{FileSystem: &v1alpha1.FileSystemProvider{Path: "/data"}})).NotTo(BeEmpty())
+
+	// the dedicated etl storage is accessed with the credentials of the shared storage
+	mo.Spec.LogService.ETLStorage = &v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "etl/data", SecretRef: aws}}
+	g.Expect(migrationUnsupported(mo, target("new-bucket/data"))).To(BeEmpty())
+	g.Expect(migrationUnsupported(mo, &v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "new-bucket/data"}})).NotTo(BeEmpty())
+}
+
+func TestStorageMigrationActor(t *testing.T) {
+	g := NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(v1alpha1.AddToScheme(s))
+
+	mo := &v1alpha1.MatrixOneCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mo"},
+		Status: v1alpha1.MatrixOneClusterStatus{
+			ConditionalStatus: v1alpha1.ConditionalStatus{Conditions: []metav1.Condition{{
+				Type:   recon.ConditionTypeReady,
+				Status: metav1.ConditionTrue,
+			}}},
+		},
+	}
+	mo.Spec.LogService.SharedStorage.S3 = &v1alpha1.S3Provider{Path: "bucket/data", SecretRef: &corev1.LocalObjectReference{Name: "aws"}}
+	sm := &v1alpha1.StorageMigration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "migrate"},
+		Spec: v1alpha1.StorageMigrationSpec{
+			ClusterRef: mo.Name,
+			Target: v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{
+				Path:      "new-bucket/data",
+				SecretRef: &corev1.LocalObjectReference{Name: "new"},
+			}},
+		},
+	}
+	cnPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mo-tp-cn-0", Labels: map[string]string{
+		common.MatrixoneClusterLabelKey: mo.Name,
+		common.ComponentLabelKey:        "CNSet",
+	}}}
+	cli := &fake.Client{Client: fake.KubeClientBuilder().WithScheme(s).WithObjects(mo, sm, cnPod).WithStatusSubresource(sm).Build()}
+	ctx := fake.NewContext(sm, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+	actor := NewStorageMigrationActor("dump")
+
+	// the source is recorded and the objects are copied while the cluster is serving
+	g.Expect(actor.start(ctx)).To(Succeed())
+	g.Expect(sm.Status.Phase).To(Equal(v1alpha1.StorageMigrationPhasePreCopying))
+	g.Expect(sm.Status.Source.S3.Path).To(Equal("bucket/data"))
+	g.Expect(actor.preCopy(ctx)).To(BeAssignableToTypeOf(&recon.ReSync{}))
+	job := &batchv1.Job{}
+	g.Expect(ctx.Get(types.NamespacedName{Namespace: "default", Name: "migrate-precopy"}, job)).To(Succeed())
+	c := job.Spec.Template.Spec.Containers[0]
+	g.Expect(c.Env).To(ContainElements(
+		corev1.EnvVar{Name: "SOURCE_BUCKET", Value: "bucket"},
+		corev1.EnvVar{Name: "SOURCE_PREFIX", Value: "data/"},
+		corev1.EnvVar{Name: "TARGET_URL", Value: "s3://new-bucket/data"},
+		HaveField("Name", "SOURCE_AWS_ACCESS_KEY_ID"),
+		HaveField("Name", "TARGET_AWS_SECRET_ACCESS_KEY"),
+	))
+	g.Expect(c.Env).NotTo(ContainElement(HaveField("Name", common.AWSAccessKeyID)))
+	g.Expect(c.VolumeMounts).To(Equal([]corev1.VolumeMount{{Name: migrationVolume, MountPath: migrationMountPath}}))
+	g.Expect(job.Spec.Template.Labels).To(HaveKeyWithValue(migrationStageLabel, migrationStagePreCopy))
+	// the verification reads the objects checksummed by the pre-copy
+	verifyJob, err := buildMigrationJob(sm, "dump", migrationStageVerify)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(verifyJob.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("ConfigMap.Name", "migrate-checksummed")))
+	g.Expect(verifyJob.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(HaveField("MountPath", migrationChecksummedMountPath)))
+
+	// the CNs are drained before the TN is paused
+	sm.Status.Phase = v1alpha1.StorageMigrationPhaseQuiescing
+	sm.Status.QuiesceTime = &metav1.Time{Time: time.Now()}
+	g.Expect(actor.quiesce(ctx)).To(BeAssignableToTypeOf(&recon.ReSync{}))
+	g.Expect(ctx.Get(types.NamespacedName{Namespace: "default", Name: mo.Name}, mo)).To(Succeed())
+	g.Expect(mo.Annotations).To(HaveKeyWithValue(v1alpha1.ClusterQuiesceAnno, v1alpha1.QuiesceCN))
+	g.Expect(ctx.Delete(cnPod)).To(Succeed())
+	g.Expect(actor.quiesce(ctx)).To(Succeed())
+	g.Expect(ctx.Get(types.NamespacedName{Namespace: "default", Name: mo.Name}, mo)).To(Succeed())
+	g.Expect(mo.Annotations).To(HaveKeyWithValue(v1alpha1.ClusterQuiesceAnno, v1alpha1.QuiesceAll))
+	g.Expect(sm.Status.Phase).To(Equal(v1alpha1.StorageMigrationPhaseSyncing))
+
+	// the cluster resumes on the source if the migration fails
+	g.Expect(actor.fail(ctx, "VerificationFailed", "mismatch")).To(Succeed())
+	g.Expect(sm.Status.Phase).To(Equal(v1alpha1.JobPhaseFailed))
+	g.Expect(ctx.Get(types.NamespacedName{Namespace: "default", Name: mo.Name}, mo)).To(Succeed())
+	g.Expect(mo.Annotations).NotTo(HaveKey(v1alpha1.ClusterQuiesceAnno))
+}
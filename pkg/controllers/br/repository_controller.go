@@ -0,0 +1,281 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/controller-runtime/pkg/util"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/cmd"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// scanRetryInterval is the interval to retry a failed scan of the repository
+	scanRetryInterval = time.Minute
+
+	// repositoryBackupHashLength is the length of the id hash in the name of the imported Backup
+	repositoryBackupHashLength = 10
+)
+
+// RepositoryActor scans the catalog of the BackupRepository and imports the backups as Backups
+type RepositoryActor struct {
+	image     string
+	toolImage string
+}
+
+func NewRepositoryActor(image string, toolImage string) *RepositoryActor {
+	return &RepositoryActor{image: image, toolImage: toolImage}
+}
+
+var _ recon.Actor[*v1alpha1.BackupRepository] = &RepositoryActor{}
+
+func (r *RepositoryActor) Observe(ctx *recon.Context[*v1alpha1.BackupRepository]) (recon.Action[*v1alpha1.BackupRepository], error) {
+	repo := ctx.Obj
+	if repo.Status.LastScanTime != nil && repo.Status.ObservedGeneration == repo.Generation {
+		next := repo.Status.LastScanTime.Add(repo.GetInterval())
+		if wait := time.Until(next); wait > 0 {
+			return nil, recon.ErrReSync("wait next scan", wait)
+		}
+	}
+	return r.scan, nil
+}
+
+// scan runs the scan job and imports the backups listed by the job
+func (r *RepositoryActor) scan(ctx *recon.Context[*v1alpha1.BackupRepository]) error {
+	repo := ctx.Obj
+	job := &batchv1.Job{}
+	err := ctx.Get(types.NamespacedName{Namespace: repo.Namespace, Name: repo.Name}, job)
+	if apierrors.IsNotFound(err) {
+		if err := checkStorage(&repo.Spec.Source); err != nil {
+			return r.scanFailed(ctx, "InvalidSource", err.Error())
+		}
+		if err := util.Ignore(apierrors.IsAlreadyExists, ctx.CreateOwned(r.buildScanJob(repo))); err != nil {
+			return errors.WrapPrefix(err, "error create scan job", 0)
+		}
+		if err := util.Ignore(apierrors.IsAlreadyExists, ctx.CreateOwned(buildSvc(repo))); err != nil {
+			return errors.WrapPrefix(err, "error ensure service", 0)
+		}
+		return recon.ErrReSync("wait scan complete", catalogPollInterval)
+	}
+	if err != nil {
+		return errors.WrapPrefix(err, "error get scan job", 0)
+	}
+	if job.Status.Failed > 0 {
+		return r.scanFailed(ctx, "JobFailed", fmt.Sprintf("scan job %s failed", job.Name))
+	}
+	svc := buildSvc(repo)
+	status, err := cmd.GetCmdStatus(fmt.Sprintf("%s.%s", svc.Name, svc.Namespace), defaultCMDRestPort)
+	if err != nil {
+		return errors.WrapPrefix(err, "error get scan status", 0)
+	}
+	if !status.Completed {
+		return recon.ErrReSync("wait scan complete", catalogPollInterval)
+	}
+	if status.ExitCode != 0 {
+		return r.scanFailed(ctx, "ScanFailed", status.Stderr)
+	}
+	entries := parseCatalog(status.Stdout)
+	imported, err := importBackups(ctx, repo, entries)
+	if err != nil {
+		return r.scanFailed(ctx, "ImportFailed", err.Error())
+	}
+	if err := r.cleanupScan(ctx); err != nil {
+		return err
+	}
+	repo.Status.ObservedGeneration = repo.Generation
+	repo.Status.LastScanTime = &metav1.Time{Time: time.Now()}
+	repo.Status.Backups = int32(len(entries))
+	repo.Status.Imported = int32(imported)
+	repo.SetCondition(metav1.Condition{
+		Type:    v1alpha1.BackupRepositoryConditionTypeSynced,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Scanned",
+		Message: fmt.Sprintf("%d backups found, %d imported", len(entries), imported),
+	})
+	return recon.ErrReSync("wait next scan", repo.GetInterval())
+}
+
+// scanFailed reports the failure of the scan and retries later, the scan job is deleted so that the
+// next scan starts over
+func (r *RepositoryActor) scanFailed(ctx *recon.Context[*v1alpha1.BackupRepository], reason string, message string) error {
+	if err := r.cleanupScan(ctx); err != nil {
+		return err
+	}
+	ctx.Obj.SetCondition(metav1.Condition{
+		Type:    v1alpha1.BackupRepositoryConditionTypeSynced,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+	return recon.ErrReSync("retry scan", scanRetryInterval)
+}
+
+func (r *RepositoryActor) cleanupScan(ctx *recon.Context[*v1alpha1.BackupRepository]) error {
+	repo := ctx.Obj
+	if err := util.Ignore(apierrors.IsNotFound, ctx.Delete(
+		&batchv1.Job{ObjectMeta: common.ObjMetaTemplate(repo, repo.Name)},
+		client.PropagationPolicy(metav1.DeletePropagationBackground),
+	)); err != nil {
+		return errors.WrapPrefix(err, "error delete scan job", 0)
+	}
+	if err := util.Ignore(apierrors.IsNotFound, ctx.Delete(buildSvc(repo))); err != nil {
+		return errors.WrapPrefix(err, "error delete scan service", 0)
+	}
+	return nil
+}
+
+// buildScanJob builds the job that lists the catalog of the repository, the listed catalog is
+// collected from the stdout of the job
+func (r *RepositoryActor) buildScanJob(repo *v1alpha1.BackupRepository) *batchv1.Job {
+	job := buildJobWithMeta(common.ObjMetaTemplate(repo, repo.Name), repo.Spec.Overlay, r.image, []string{"/cmdrest", "--", listCatalogScript()}, func(c *corev1.Container) {})
+	fetchCatalog(job, r.toolImage, sourceVolume, &repo.Spec.Source)
+	return job
+}
+
+// importBackups creates the Backups of the catalog entries that are not known to the cluster yet
+// and returns the number of the imported backups
+func importBackups(cli recon.KubeClient, repo *v1alpha1.BackupRepository, entries []string) (int, error) {
+	backupList := &v1alpha1.BackupList{}
+	if err := cli.List(backupList); err != nil {
+		return 0, errors.WrapPrefix(err, "error list backups", 0)
+	}
+	names := map[string]string{}
+	for _, b := range backupList.Items {
+		names[b.Meta.ID] = b.Name
+	}
+	// the names of the new backups are decided first so that the chains can refer to them
+	var toImport []*v1alpha1.Backup
+	var chains [][]string
+	for _, entry := range entries {
+		lines := strings.Split(entry, "\n")
+		raw := strings.TrimSpace(lines[len(lines)-1])
+		info, err := parseBackupMeta(raw)
+		if err != nil {
+			return 0, err
+		}
+		if _, ok := names[info.ID]; ok {
+			continue
+		}
+		names[info.ID] = repositoryBackupName(repo, info.ID)
+		var parents []string
+		for _, line := range lines[:len(lines)-1] {
+			if line = strings.TrimSpace(line); line == "" {
+				continue
+			}
+			parent, err := parseBackupMeta(line)
+			if err != nil {
+				return 0, err
+			}
+			parents = append(parents, parent.ID)
+		}
+		toImport = append(toImport, importedBackup(repo, names[info.ID], info, raw))
+		chains = append(chains, parents)
+	}
+	for i, b := range toImport {
+		for _, id := range chains[i] {
+			name, ok := names[id]
+			if !ok {
+				name = repositoryBackupName(repo, id)
+			}
+			b.Meta.Chain = append(b.Meta.Chain, name)
+		}
+		if err := cli.Create(b); err != nil {
+			if !apierrors.IsAlreadyExists(err) {
+				return 0, errors.WrapPrefix(err, "error import backup "+b.Meta.ID, 0)
+			}
+			// the backup might be imported by a former scan that failed halfway
+			existing := &v1alpha1.Backup{}
+			if err := cli.Get(client.ObjectKeyFromObject(b), existing); err != nil {
+				return 0, errors.WrapPrefix(err, "error get backup "+b.Name, 0)
+			}
+			if existing.Meta.ID != b.Meta.ID {
+				return 0, errors.Errorf("cannot import backup %s, the name %s is taken by backup %s", b.Meta.ID, b.Name, existing.Meta.ID)
+			}
+		}
+	}
+	return len(toImport), nil
+}
+
+func importedBackup(repo *v1alpha1.BackupRepository, name string, info *backupMetaInfo, raw string) *v1alpha1.Backup {
+	b := &v1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				common.BackupRepositoryLabelKey:          repo.Name,
+				common.BackupRepositoryNamespaceLabelKey: repo.Namespace,
+				common.BackupSourceNamespaceLabelKey:     repo.Namespace,
+			},
+		},
+		Meta: v1alpha1.BackupMeta{
+			Location:  repo.Spec.Source,
+			ID:        info.ID,
+			Size:      info.Size,
+			SourceRef: repo.GetSourceRef(),
+			Version:   info.Version,
+			Type:      info.Type,
+			Raw:       raw,
+		},
+	}
+	if info.AtTime != nil {
+		b.Meta.AtTime = metav1.NewTime(*info.AtTime)
+	}
+	if info.CompleteTime != nil {
+		b.Meta.CompleteTime = metav1.NewTime(*info.CompleteTime)
+	}
+	return b
+}
+
+// repositoryBackupName returns the name of the Backup imported by the repository, the Backup is
+// cluster-scoped so the namespace of the repository and the hash of the full id are included
+func repositoryBackupName(repo *v1alpha1.BackupRepository, id string) string {
+	sum := sha256.Sum256([]byte(id))
+	return fmt.Sprintf("%s-%s-%s", repo.Namespace, repo.Name, hex.EncodeToString(sum[:])[:repositoryBackupHashLength])
+}
+
+func (r *RepositoryActor) Finalize(ctx *recon.Context[*v1alpha1.BackupRepository]) (bool, error) {
+	// the imported backups are kept
+	repo := ctx.Obj
+	err := ctx.Delete(&batchv1.Job{ObjectMeta: common.ObjMetaTemplate(repo, repo.Name)}, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err == nil {
+		// check next time
+		return false, nil
+	}
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	return false, errors.WrapPrefix(err, "error delete job", 0)
+}
+
+func (r *RepositoryActor) Reconcile(mgr manager.Manager) error {
+	return recon.Setup[*v1alpha1.BackupRepository](&v1alpha1.BackupRepository{}, "backuprepository", mgr, r, recon.WithBuildFn(func(b *builder.Builder) {
+		b.Owns(&batchv1.Job{})
+	}))
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/matrixorigin/controller-runtime/pkg/fake"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_importBackups(t *testing.T) {
+	g := NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(v1alpha1.AddToScheme(s))
+
+	existing := &v1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "daily-11111"},
+		Meta:       v1alpha1.BackupMeta{ID: "11111111-aaaa"},
+	}
+	repo := &v1alpha1.BackupRepository{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "dr", Name: "remote"},
+		Spec: v1alpha1.BackupRepositorySpec{
+			Source: v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/backup"}},
+		},
+	}
+	cli := &fake.Client{Client: fake.KubeClientBuilder().WithScheme(s).WithObjects(existing, repo).Build()}
+	ctx := fake.NewContext(repo, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+	entries := []string{
+		"11111111-aaaa,1024,s3:bucket/backup,2025-03-01 02:00:00 +0000 UTC,1m,2025-03-01 02:01:00 +0000 UTC,1,full",
+		"22222222-bbbb,2048,s3:bucket/backup,2025-03-02 02:00:00 +0000 UTC,1m,2025-03-02 02:01:00 +0000 UTC,2,full",
+		"22222222-bbbb,2048,s3:bucket/backup,2025-03-02 02:00:00 +0000 UTC,1m,2025-03-02 02:01:00 +0000 UTC,2,full\n" +
+			"33333333-cccc,512,s3:bucket/backup,2025-03-03 02:00:00 +0000 UTC,1m,2025-03-03 02:01:00 +0000 UTC,3,incremental",
+	}
+
+	// the known backup is skipped and the chain refers to the imported parent
+	imported, err := importBackups(ctx, repo, entries)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(imported).To(Equal(2))
+	full := &v1alpha1.Backup{}
+	fullName := repositoryBackupName(repo, "22222222-bbbb")
+	g.Expect(fullName).To(HavePrefix("dr-remote-"))
+	g.Expect(ctx.Get(types.NamespacedName{Name: fullName}, full)).To(Succeed())
+	g.Expect(full.Meta.SourceRef).To(Equal("backuprepository/dr/remote"))
+	g.Expect(full.Meta.Location).To(Equal(repo.Spec.Source))
+	g.Expect(full.Meta.Size.String()).To(Equal("2Ki"))
+	g.Expect(full.Labels).To(HaveKeyWithValue(common.BackupRepositoryLabelKey, "remote"))
+	g.Expect(full.GetDeletionPolicy()).To(Equal(v1alpha1.PVCRetentionPolicyRetain))
+	incremental := &v1alpha1.Backup{}
+	g.Expect(ctx.Get(types.NamespacedName{Name: repositoryBackupName(repo, "33333333-cccc")}, incremental)).To(Succeed())
+	g.Expect(incremental.IsIncremental()).To(BeTrue())
+	g.Expect(incremental.Meta.Chain).To(Equal([]string{fullName}))
+	g.Expect(incremental.Meta.Raw).To(HavePrefix("33333333-cccc,"))
+
+	// rescan imports nothing
+	imported, err = importBackups(ctx, repo, entries)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(imported).To(BeZero())
+	backupList := &v1alpha1.BackupList{}
+	g.Expect(ctx.List(backupList, client.HasLabels{common.BackupRepositoryLabelKey})).To(Succeed())
+	g.Expect(backupList.Items).To(HaveLen(2))
+
+	// the name taken by another backup is reported instead of skipped
+	entry := "44444444-dddd,512,s3:bucket/backup,2025-03-04 02:00:00 +0000 UTC,1m,2025-03-04 02:01:00 +0000 UTC,4,full"
+	g.Expect(ctx.Create(&v1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: repositoryBackupName(repo, "44444444-dddd")},
+		Meta:       v1alpha1.BackupMeta{ID: "55555555-eeee"},
+	})).To(Succeed())
+	_, err = importBackups(ctx, repo, []string{entry})
+	g.Expect(err).To(HaveOccurred())
+}
@@ -16,7 +16,7 @@ package br
 
 import (
 	"fmt"
-	"strings"
+	"time"
 
 	"github.com/go-errors/errors"
 	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
@@ -37,10 +37,13 @@ import (
 
 type RestoreActor struct {
 	restoreImage string
+	toolImage    string
+	// sqlImage runs the restore statement of the in place PITR recovery
+	sqlImage string
 }
 
-func NewRestoreActor(image string) *RestoreActor {
-	return &RestoreActor{restoreImage: image}
+func NewRestoreActor(image string, toolImage string, sqlImage string) *RestoreActor {
+	return &RestoreActor{restoreImage: image, toolImage: toolImage, sqlImage: sqlImage}
 }
 
 var _ recon.Actor[*v1alpha1.RestoreJob] = &RestoreActor{}
@@ -59,6 +62,9 @@ func (c *RestoreActor) Observe(ctx *recon.Context[*v1alpha1.RestoreJob]) (recon.
 
 func (c *RestoreActor) waitJob(ctx *recon.Context[*v1alpha1.RestoreJob]) error {
 	rj := ctx.Obj
+	if rj.Status.DeadlineExceeded(rj.Spec.ActiveDeadline, time.Now()) {
+		return c.failDeadlineExceeded(ctx)
+	}
 	job := &batchv1.Job{}
 	if err := ctx.Get(types.NamespacedName{Namespace: rj.Namespace, Name: rj.Name}, job); err != nil {
 		if apierrors.IsNotFound(err) {
@@ -67,7 +73,7 @@ func (c *RestoreActor) waitJob(ctx *recon.Context[*v1alpha1.RestoreJob]) error {
 		return errors.WrapPrefix(err, "error get backup job", 0)
 	}
 	if job.Status.Failed > 0 {
-		return c.failRestore(ctx, "backup job is failed")
+		return c.retryOrFail(ctx, "backup job is failed")
 	}
 	svc := buildSvc(rj)
 	status, err := cmd.GetCmdStatus(fmt.Sprintf("%s.%s", svc.Name, svc.Namespace), defaultCMDRestPort)
@@ -81,26 +87,66 @@ func (c *RestoreActor) waitJob(ctx *recon.Context[*v1alpha1.RestoreJob]) error {
 	if status.ExitCode == 0 {
 		return c.successRestore(ctx)
 	}
-	return c.failRestore(ctx, status.Stderr)
+	return c.retryOrFail(ctx, status.Stderr)
 }
 
 func (c *RestoreActor) failRestore(ctx *recon.Context[*v1alpha1.RestoreJob], msg string) error {
+	return c.endWithFailure(ctx, "JobFailed", msg)
+}
+
+func (c *RestoreActor) endWithFailure(ctx *recon.Context[*v1alpha1.RestoreJob], reason string, msg string) error {
 	ctx.Obj.Status.Phase = v1alpha1.JobPhaseFailed
 	meta.SetStatusCondition(&ctx.Obj.Status.Conditions, metav1.Condition{
 		Type:    v1alpha1.JobConditionTypeEnded,
 		Status:  metav1.ConditionTrue,
-		Reason:  "JobFailed",
+		Reason:  reason,
 		Message: msg,
 	})
 	return ctx.UpdateStatus(ctx.Obj)
 }
 
-func (c *RestoreActor) successRestore(ctx *recon.Context[*v1alpha1.RestoreJob]) error {
+// failDeadlineExceeded stops the running attempt and fails the restore job
+func (c *RestoreActor) failDeadlineExceeded(ctx *recon.Context[*v1alpha1.RestoreJob]) error {
+	rj := ctx.Obj
+	if err := c.deleteJob(ctx); err != nil {
+		return err
+	}
+	return c.endWithFailure(ctx, reasonDeadlineExceeded, fmt.Sprintf("restore job is not completed within the active deadline %s", rj.Spec.ActiveDeadline.Duration))
+}
+
+// retryOrFail retries the failed attempt if allowed by the retry policy, otherwise fails the restore job
+func (c *RestoreActor) retryOrFail(ctx *recon.Context[*v1alpha1.RestoreJob], msg string) error {
+	rj := ctx.Obj
+	if rj.Status.DeadlineExceeded(rj.Spec.ActiveDeadline, time.Now()) {
+		return c.failDeadlineExceeded(ctx)
+	}
+	if !scheduleRetry(rj.Spec.RetryPolicy, &rj.Status.JobAttemptStatus, time.Now()) {
+		return c.failRestore(ctx, msg)
+	}
+	// the next attempt starts over with a new job
+	if err := c.deleteJob(ctx); err != nil {
+		return err
+	}
+	ctx.Log.Info("restore attempt failed, retry later", "attempt", rj.Status.Attempts, "nextRetryTime", rj.Status.NextRetryTime)
+	rj.Status.Phase = v1alpha1.JobPhasePending
+	meta.SetStatusCondition(&rj.Status.Conditions, retryCondition(rj.Spec.RetryPolicy, &rj.Status.JobAttemptStatus, msg))
+	return ctx.UpdateStatus(rj)
+}
+
+func (c *RestoreActor) deleteJob(ctx *recon.Context[*v1alpha1.RestoreJob]) error {
 	rj := ctx.Obj
 	if err := util.Ignore(apierrors.IsNotFound, ctx.Delete(
 		&batchv1.Job{ObjectMeta: common.ObjMetaTemplate(rj, rj.Name)},
 		client.PropagationPolicy(metav1.DeletePropagationBackground),
 	)); err != nil {
+		return errors.WrapPrefix(err, "error delete restore job", 0)
+	}
+	return nil
+}
+
+func (c *RestoreActor) successRestore(ctx *recon.Context[*v1alpha1.RestoreJob]) error {
+	rj := ctx.Obj
+	if err := c.deleteJob(ctx); err != nil {
 		return errors.WrapPrefix(err, "error finalize restore job", 0)
 	}
 	rj.Status.Phase = v1alpha1.JobPhaseCompleted
@@ -117,64 +163,172 @@ func (c *RestoreActor) syncJob(ctx *recon.Context[*v1alpha1.RestoreJob]) error {
 	if rj.Status.Phase == "" {
 		rj.Status.Phase = v1alpha1.JobPhasePending
 	}
+	now := time.Now()
+	if rj.Status.DeadlineExceeded(rj.Spec.ActiveDeadline, now) {
+		return c.failDeadlineExceeded(ctx)
+	}
+	if t := rj.Status.NextRetryTime; t != nil && now.Before(t.Time) {
+		return recon.ErrReSync("wait retry backoff", t.Sub(now))
+	}
+	if rj.Status.Attempts > 0 {
+		// the job of the previous attempt must be gone before the next attempt starts
+		err := ctx.Get(types.NamespacedName{Namespace: rj.Namespace, Name: rj.Name}, &batchv1.Job{})
+		if err == nil {
+			return recon.ErrReSync("wait the job of the previous attempt deleted", catalogPollInterval)
+		}
+		if !apierrors.IsNotFound(err) {
+			return errors.WrapPrefix(err, "error get restore job", 0)
+		}
+	}
+	if rj.IsInPlacePITR() {
+		return c.restorePITR(ctx)
+	}
 	restoreCmd := &RestoreCommand{}
-	backup := &v1alpha1.Backup{}
-	if err := ctx.Get(types.NamespacedName{Name: rj.Spec.BackupName}, backup); err != nil {
-		return errors.WrapPrefix(err, "error get backup", 0)
-	}
-	restoreCmd.BackupID = backup.Meta.ID
-	optionalSourceSecret := backup.Meta.Location.S3.SecretRef
-	restoreCmd.ReadSourceEnvSecret = optionalSourceSecret != nil
-	restoreCmd.Target.Endpoint = rj.Spec.Target.S3.Endpoint
-	restoreCmd.Target.Type = string(rj.Spec.Target.S3.GetProviderType())
-	parts := strings.SplitN(rj.Spec.Target.S3.Path, "/", 2)
-	restoreCmd.Target.Bucket = parts[0]
-	if len(parts) > 1 {
-		restoreCmd.Target.Path = parts[1]
-	}
-	optionalTargetSecret := rj.Spec.Target.S3.SecretRef
-	restoreCmd.Target.ReadEnvSecret = optionalTargetSecret != nil
-	job := buildJob(rj, c.restoreImage, restoreCmd.String(), func(c *corev1.Container) {
-		c.Env = []corev1.EnvVar{{
-			Name:  RawMetaEnv,
-			Value: backup.Meta.Raw,
-		}}
-		if optionalSourceSecret != nil {
-			for _, key := range []string{common.AWSAccessKeyID, common.AWSSecretAccessKey} {
-				c.Env = util.UpsertByKey(c.Env, corev1.EnvVar{Name: key, ValueFrom: &corev1.EnvVarSource{
-					SecretKeyRef: &corev1.SecretKeySelector{
-						LocalObjectReference: *optionalSourceSecret,
-						Key:                  key,
-					},
-				}}, util.EnvVarKey)
+	var source *v1alpha1.SharedStorageProvider
+	var rawMeta string
+	decryption := rj.Spec.Decryption
+	if rj.Spec.ExternalSource != nil {
+		// the metas of the backup chain are read from the catalog of the external source
+		source = rj.Spec.ExternalSource
+		if source.S3 != nil && rj.Spec.ExternalBackupID == "" {
+			return c.endWithFailure(ctx, "InvalidSource", "externalBackupID must be set to restore from S3")
+		}
+		restoreCmd.BackupID = rj.Spec.ExternalBackupID
+		restoreCmd.CatalogDir = catalogMountPath
+	} else {
+		backup, err := c.getBackup(ctx)
+		if err != nil {
+			return err
+		}
+		// incremental backup is restored along with its parents
+		chain, err := resolveChain(ctx, backup)
+		if err != nil {
+			if isChainError(err) {
+				return c.failRestore(ctx, err.Error())
 			}
+			return err
 		}
-		if optionalTargetSecret != nil {
-			c.Env = util.UpsertByKey(c.Env, corev1.EnvVar{Name: RestoreAccessEnvKey, ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: *optionalSourceSecret,
-					Key:                  common.AWSAccessKeyID,
-				},
-			}}, util.EnvVarKey)
-			c.Env = util.UpsertByKey(c.Env, corev1.EnvVar{Name: RestoreSecretEnvKey, ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: *optionalSourceSecret,
-					Key:                  common.AWSSecretAccessKey,
-				},
-			}}, util.EnvVarKey)
+		source = &backup.Meta.Location
+		restoreCmd.BackupID = backup.Meta.ID
+		rawMeta = chainRawMeta(chain)
+		if decryption == nil {
+			decryption = backup.Meta.Encryption
+		}
+	}
+	if decryption != nil {
+		// fail early instead of failing the br job
+		if msg := encryptionUnsupported(c.restoreImage); msg != "" {
+			return c.endWithFailure(ctx, "DecryptionUnsupported", msg)
+		}
+		msg, err := missingKey(ctx, rj.Namespace, decryption)
+		if err != nil {
+			return err
+		}
+		if msg != "" {
+			return c.endWithFailure(ctx, "DecryptionKeyUnavailable", msg)
+		}
+		restoreCmd.Decrypt = true
+	}
+	if err := checkStorage(source); err != nil {
+		return errors.WrapPrefix(err, "bad backup location", 0)
+	}
+	if err := checkStorage(&rj.Spec.Target); err != nil {
+		return errors.WrapPrefix(err, "bad restore target", 0)
+	}
+	restoreCmd.ReadSourceEnvSecret = source.S3 != nil && source.S3.WebIdentity == nil && source.S3.SecretRef != nil
+	if s3 := rj.Spec.Target.S3; s3 != nil {
+		restoreCmd.Target = newS3(s3)
+	} else {
+		restoreCmd.TargetFileSystemPath = rj.Spec.Target.FileSystem.Path
+	}
+	job := buildJob(rj, c.restoreImage, restoreCmd.String(), func(c *corev1.Container) {
+		if rawMeta != "" {
+			c.Env = []corev1.EnvVar{{
+				Name:  RawMetaEnv,
+				Value: rawMeta,
+			}}
 		}
 	})
-	svc := buildSvc(rj)
+	brContainer := &job.Spec.Template.Spec.Containers[0]
+	if restoreCmd.ReadSourceEnvSecret {
+		// the keys of the source are passed to mo_br separately, so that the env of aws only carries the
+		// credential of the target, e.g. the web identity of the target is never paired with the source keys
+		common.NewS3SecretSource(source.S3, BackupAccessEnvKey, BackupSecretEnvKey).Inject(&job.Spec.Template.Spec, brContainer)
+	} else {
+		injectStorageCredential(job, brContainer, source)
+	}
+	if decryption != nil {
+		mountEncryptionKey(&job.Spec.Template.Spec, brContainer, decryption)
+	}
+	if s3 := rj.Spec.Target.S3; s3 != nil && s3.SecretRef != nil {
+		// the keys of the target are passed to mo_br separately from the keys of the source
+		common.NewS3SecretSource(s3, RestoreAccessEnvKey, RestoreSecretEnvKey).Inject(&job.Spec.Template.Spec, brContainer)
+	} else {
+		injectStorageCredential(job, brContainer, &rj.Spec.Target)
+	}
+	mountStorage(job, sourceVolume, source)
+	mountStorage(job, targetVolume, &rj.Spec.Target)
+	if rj.Spec.ExternalSource != nil {
+		fetchCatalog(job, c.toolImage, sourceVolume, source)
+	}
+	return c.startJob(ctx, job, now)
+}
+
+// startJob starts an attempt of the restore with the job and the service to poll the job status
+func (c *RestoreActor) startJob(ctx *recon.Context[*v1alpha1.RestoreJob], job *batchv1.Job, now time.Time) error {
+	rj := ctx.Obj
+	boundByDeadline(job, rj.Spec.ActiveDeadline, &rj.Status.JobAttemptStatus, now)
 	if err := util.Ignore(apierrors.IsAlreadyExists, ctx.CreateOwned(job)); err != nil {
 		return errors.WrapPrefix(err, "error ensure job", 0)
 	}
-	if err := util.Ignore(apierrors.IsAlreadyExists, ctx.CreateOwned(svc)); err != nil {
+	if err := util.Ignore(apierrors.IsAlreadyExists, ctx.CreateOwned(buildSvc(rj))); err != nil {
 		return errors.WrapPrefix(err, "error ensure service", 0)
 	}
+	startAttempt(&rj.Status.JobAttemptStatus, now)
 	rj.Status.Phase = v1alpha1.JobPhaseRunning
 	return ctx.UpdateStatus(rj)
 }
 
+// getBackup returns the backup to restore and records it in status
+func (c *RestoreActor) getBackup(ctx *recon.Context[*v1alpha1.RestoreJob]) (*v1alpha1.Backup, error) {
+	rj := ctx.Obj
+	backup := &v1alpha1.Backup{}
+	if err := ctx.Get(types.NamespacedName{Name: rj.Spec.BackupName}, backup); err != nil {
+		return nil, errors.WrapPrefix(err, "error get backup", 0)
+	}
+	rj.Status.Backup = backup.Name
+	return backup, nil
+}
+
+// restorePITR recovers the cluster in place to the point in time with the PITR of the cluster
+func (c *RestoreActor) restorePITR(ctx *recon.Context[*v1alpha1.RestoreJob]) error {
+	rj := ctx.Obj
+	pit := rj.Spec.PointInTime
+	mo := &v1alpha1.MatrixOneCluster{}
+	if err := ctx.Get(types.NamespacedName{Namespace: rj.Namespace, Name: pit.ClusterRef}, mo); err != nil {
+		if apierrors.IsNotFound(err) {
+			return c.failRestore(ctx, fmt.Sprintf("cluster %s not found", pit.ClusterRef))
+		}
+		return errors.WrapPrefix(err, "error get cluster", 0)
+	}
+	if mo.Status.PITR == nil {
+		return c.failRestore(ctx, fmt.Sprintf("PITR is not enabled for cluster %s", mo.Name))
+	}
+	now := time.Now()
+	earliest := mo.Status.PITR.EarliestRecoverableTime(now)
+	if pit.Time.Time.Before(earliest) || pit.Time.After(now) {
+		return c.failRestore(ctx, fmt.Sprintf("%s is out of the recoverable window of cluster %s [%s, %s]",
+			pit.Time.UTC().Format(time.RFC3339), mo.Name, earliest.UTC().Format(time.RFC3339), now.UTC().Format(time.RFC3339)))
+	}
+	if !recon.IsReady(&mo.Status) || mo.Status.CredentialRef == nil {
+		return recon.ErrReSync("wait cluster ready", pollInterval)
+	}
+	// the statement returns after the data is restored, which is tracked as a job
+	stmt := fmt.Sprintf("RESTORE CLUSTER FROM PITR `%s` \"%s\"", mo.Status.PITR.Name, pit.Time.UTC().Format(time.DateTime))
+	ctx.Log.Info("recover cluster from PITR", "cluster", mo.Name, "time", pit.Time)
+	return c.startJob(ctx, buildSQLJob(rj, c.sqlImage, mo, stmt), now)
+}
+
 func (c *RestoreActor) Finalize(ctx *recon.Context[*v1alpha1.RestoreJob]) (bool, error) {
 	rj := ctx.Obj
 	err := ctx.Delete(&batchv1.Job{ObjectMeta: common.ObjMetaTemplate(rj, rj.Name)}, client.PropagationPolicy(metav1.DeletePropagationBackground))
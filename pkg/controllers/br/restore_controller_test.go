@@ -0,0 +1,209 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/matrixorigin/controller-runtime/pkg/fake"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestRestoreActor_restorePITR(t *testing.T) {
+	g := NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(v1alpha1.AddToScheme(s))
+
+	now := time.Now()
+	mo := &v1alpha1.MatrixOneCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mo"},
+		Status: v1alpha1.MatrixOneClusterStatus{
+			ConditionalStatus: v1alpha1.ConditionalStatus{Conditions: []metav1.Condition{{
+				Type:   recon.ConditionTypeReady,
+				Status: metav1.ConditionTrue,
+			}}},
+			CredentialRef: &corev1.LocalObjectReference{Name: "mo-credential"},
+			Host:          "mo-tp-cn",
+			Port:          6001,
+			PITR: &v1alpha1.PITRStatus{
+				Name:        "mo_operator_pitr",
+				Range:       1,
+				Unit:        v1alpha1.PITRUnitDay,
+				CreatedTime: metav1.NewTime(now.Add(-48 * time.Hour)),
+			},
+		},
+	}
+	newJob := func(t time.Time) *v1alpha1.RestoreJob {
+		return &v1alpha1.RestoreJob{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "pitr"},
+			Spec: v1alpha1.RestoreJobSpec{
+				PointInTime: &v1alpha1.PointInTimeRestore{ClusterRef: "mo", Time: metav1.NewTime(t)},
+			},
+		}
+	}
+	actor := NewRestoreActor("br", "aws-cli", "dump")
+
+	// within the recoverable window, the restore statement is run by a job
+	rj := newJob(now.Add(-time.Hour))
+	cli := &fake.Client{Client: fake.KubeClientBuilder().WithScheme(s).WithObjects(mo, rj).WithStatusSubresource(rj).Build()}
+	ctx := fake.NewContext(rj, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+	g.Expect(actor.syncJob(ctx)).To(Succeed())
+	g.Expect(rj.Status.Phase).To(Equal(v1alpha1.JobPhaseRunning))
+	job := &batchv1.Job{}
+	g.Expect(ctx.Get(types.NamespacedName{Namespace: "default", Name: "pitr"}, job)).To(Succeed())
+	c := job.Spec.Template.Spec.Containers[0]
+	g.Expect(c.Image).To(Equal("dump"))
+	g.Expect(c.Command[2]).To(Equal(`mysql -h mo-tp-cn -P 6001 -u "$MO_USER" -p"$MO_PASSWORD" -e "$MO_SQL"`))
+	g.Expect(c.Env).To(ContainElement(corev1.EnvVar{
+		Name:  MOSQLEnvKey,
+		Value: fmt.Sprintf("RESTORE CLUSTER FROM PITR `mo_operator_pitr` \"%s\"", rj.Spec.PointInTime.Time.UTC().Format(time.DateTime)),
+	}))
+
+	// out of the recoverable window
+	rj = newJob(now.Add(-30 * time.Hour))
+	cli = &fake.Client{Client: fake.KubeClientBuilder().WithScheme(s).WithObjects(mo, rj).WithStatusSubresource(rj).Build()}
+	ctx = fake.NewContext(rj, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+	g.Expect(actor.syncJob(ctx)).To(Succeed())
+	g.Expect(rj.Status.Phase).To(Equal(v1alpha1.JobPhaseFailed))
+}
+
+func TestRestoreActor_syncJob_externalSource(t *testing.T) {
+	g := NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(v1alpha1.AddToScheme(s))
+
+	rj := &v1alpha1.RestoreJob{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "dr"},
+		Spec: v1alpha1.RestoreJobSpec{
+			ExternalSource: &v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{
+				Path:      "bucket/backup",
+				SecretRef: &corev1.LocalObjectReference{Name: "aws"},
+			}},
+			ExternalBackupID: "abcdefg",
+			Target: v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{
+				Path:        "bucket/data",
+				WebIdentity: &v1alpha1.S3WebIdentity{RoleARN: "arn:aws:iam::123456789012:role/mo"},
+			}},
+		},
+	}
+	cli := &fake.Client{Client: fake.KubeClientBuilder().WithScheme(s).WithObjects(rj).WithStatusSubresource(rj).Build()}
+	ctx := fake.NewContext(rj, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+	actor := NewRestoreActor("br", "aws-cli", "dump")
+
+	// the metas are read from the catalog of the source instead of a Backup
+	g.Expect(actor.syncJob(ctx)).To(Succeed())
+	g.Expect(rj.Status.Phase).To(Equal(v1alpha1.JobPhaseRunning))
+	job := &batchv1.Job{}
+	g.Expect(ctx.Get(types.NamespacedName{Namespace: "default", Name: "dr"}, job)).To(Succeed())
+	podSpec := job.Spec.Template.Spec
+	g.Expect(podSpec.InitContainers).To(HaveLen(1))
+	g.Expect(podSpec.InitContainers[0].Command[2]).To(Equal("aws s3 cp s3://bucket/backup/mo_br.meta/ /catalog/ --recursive"))
+	g.Expect(podSpec.InitContainers[0].Env).To(HaveLen(2))
+	g.Expect(podSpec.Containers[0].Command[2]).To(HavePrefix("cat /catalog/* > /mo_br.meta"))
+	g.Expect(podSpec.Containers[0].Command[2]).To(ContainSubstring("/mo_br restore abcdefg"))
+	for _, env := range podSpec.Containers[0].Env {
+		g.Expect(env.Name).NotTo(Equal(RawMetaEnv))
+	}
+	// the target is accessed with its web identity instead of the keys of the source
+	g.Expect(podSpec.Containers[0].Command[2]).To(ContainSubstring("--backup_access_key_id=$BACKUP_ACCESS_KEY_ID"))
+	g.Expect(podSpec.Containers[0].Env).To(ContainElements(HaveField("Name", BackupAccessEnvKey), HaveField("Name", common.AWSRoleARN)))
+	g.Expect(podSpec.Containers[0].Env).NotTo(ContainElement(HaveField("Name", common.AWSAccessKeyID)))
+}
+
+func TestRestoreActor_syncJob_decryption(t *testing.T) {
+	g := NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(v1alpha1.AddToScheme(s))
+
+	encryption := &v1alpha1.BackupEncryption{SecretRef: corev1.LocalObjectReference{Name: "backup-key"}, KeyID: "2025-01"}
+	backup := &v1alpha1.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "daily-abcde"},
+		Meta: v1alpha1.BackupMeta{
+			Location:   v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/backup"}},
+			ID:         "abcdefg",
+			Encryption: encryption,
+			Raw:        "abcdefg,meta",
+		},
+	}
+	newJob := func() *v1alpha1.RestoreJob {
+		return &v1alpha1.RestoreJob{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "restore"},
+			Spec: v1alpha1.RestoreJobSpec{
+				BackupName: backup.Name,
+				Target:     v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/data"}},
+			},
+		}
+	}
+
+	// the restore fails early since no mo_br version is verified to decrypt the backup
+	for _, image := range []string{"br:v2.1.0", "br:v2.2.0", "br:latest"} {
+		rj := newJob()
+		cli := &fake.Client{Client: fake.KubeClientBuilder().WithScheme(s).WithObjects(backup, rj).WithStatusSubresource(rj).Build()}
+		ctx := fake.NewContext(rj, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+		g.Expect(NewRestoreActor(image, "aws-cli", "dump").syncJob(ctx)).To(Succeed())
+		g.Expect(rj.Status.Phase).To(Equal(v1alpha1.JobPhaseFailed))
+		cond, ok := recon.GetCondition(rj, v1alpha1.JobConditionTypeEnded)
+		g.Expect(ok).To(BeTrue())
+		g.Expect(cond.Reason).To(Equal("DecryptionUnsupported"))
+	}
+}
+
+func Test_encryptionKey(t *testing.T) {
+	g := NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(v1alpha1.AddToScheme(s))
+	encryption := &v1alpha1.BackupEncryption{SecretRef: corev1.LocalObjectReference{Name: "backup-key"}, KeyID: "2025-01"}
+
+	rj := &v1alpha1.RestoreJob{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "restore"}}
+	cli := &fake.Client{Client: fake.KubeClientBuilder().WithScheme(s).Build()}
+	ctx := fake.NewContext(rj, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+	msg, err := missingKey(ctx, "default", encryption)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(msg).To(ContainSubstring("not found"))
+
+	// the key recorded in the backup is kept after rotation
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "backup-key"},
+		Data:       map[string][]byte{"2025-01": []byte("old"), "2025-06": []byte("new")},
+	}
+	cli = &fake.Client{Client: fake.KubeClientBuilder().WithScheme(s).WithObjects(secret).Build()}
+	ctx = fake.NewContext(rj, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+	msg, err = missingKey(ctx, "default", encryption)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(msg).To(BeEmpty())
+
+	// the key is read from a file that is not exposed in the command line
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "br"}}}
+	mountEncryptionKey(podSpec, &podSpec.Containers[0], encryption)
+	g.Expect(podSpec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: encryptionKeyVolume, MountPath: "/etc/mo-br/encryption", ReadOnly: true}))
+	g.Expect(podSpec.Volumes).To(ContainElement(HaveField("VolumeSource.Secret.Items", []corev1.KeyToPath{{Key: "2025-01", Path: "key"}})))
+}
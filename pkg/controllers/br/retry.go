@@ -0,0 +1,76 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	reasonRetrying         = "Retrying"
+	reasonDeadlineExceeded = "DeadlineExceeded"
+)
+
+// boundByDeadline bounds the br job of the attempt by the remaining active deadline, so that a stuck
+// attempt is stopped even if the operator is not running
+func boundByDeadline(job *batchv1.Job, deadline *metav1.Duration, status *v1alpha1.JobAttemptStatus, now time.Time) {
+	if deadline == nil {
+		return
+	}
+	remaining := deadline.Duration
+	if status.StartTime != nil {
+		remaining -= now.Sub(status.StartTime.Time)
+	}
+	seconds := int64(remaining.Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+	job.Spec.ActiveDeadlineSeconds = &seconds
+}
+
+// startAttempt records the start of a new attempt of the job
+func startAttempt(status *v1alpha1.JobAttemptStatus, now time.Time) {
+	if status.StartTime == nil {
+		status.StartTime = &metav1.Time{Time: now}
+	}
+	status.Attempts++
+	status.NextRetryTime = nil
+}
+
+// scheduleRetry schedules the retry of the failed attempt by the retry policy, false is returned
+// if the attempts are exhausted
+func scheduleRetry(policy *v1alpha1.RetryPolicy, status *v1alpha1.JobAttemptStatus, now time.Time) bool {
+	if status.Attempts >= policy.GetMaxAttempts() {
+		return false
+	}
+	status.NextRetryTime = &metav1.Time{Time: now.Add(policy.GetBackoff(status.Attempts))}
+	return true
+}
+
+// retryCondition is the Ended condition of a job that waits to retry the failed attempt
+func retryCondition(policy *v1alpha1.RetryPolicy, status *v1alpha1.JobAttemptStatus, msg string) metav1.Condition {
+	return metav1.Condition{
+		Type:   v1alpha1.JobConditionTypeEnded,
+		Status: metav1.ConditionFalse,
+		Reason: reasonRetrying,
+		Message: fmt.Sprintf("attempt %d/%d failed, retry at %s: %s", status.Attempts, policy.GetMaxAttempts(),
+			status.NextRetryTime.UTC().Format(time.RFC3339), msg),
+	}
+}
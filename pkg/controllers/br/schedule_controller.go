@@ -0,0 +1,360 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/controller-runtime/pkg/util"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	manager "sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// maxMissedSchedules bounds the iterations of catching up the missed schedules
+	maxMissedSchedules = 1000
+)
+
+// ScheduleActor reconciles BackupSchedule
+type ScheduleActor struct{}
+
+var _ recon.Actor[*v1alpha1.BackupSchedule] = &ScheduleActor{}
+
+func (c *ScheduleActor) Observe(ctx *recon.Context[*v1alpha1.BackupSchedule]) (recon.Action[*v1alpha1.BackupSchedule], error) {
+	bs := ctx.Obj
+	loc, sched, err := parseSchedule(bs)
+	if err != nil {
+		bs.SetCondition(metav1.Condition{
+			Type:    recon.ConditionTypeReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  "InvalidSchedule",
+			Message: err.Error(),
+		})
+		return nil, nil
+	}
+
+	jobs, err := c.listJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	active := syncScheduleStatus(bs, jobs)
+
+	now := time.Now()
+	earliest := bs.CreationTimestamp.Time
+	if bs.Status.LastScheduleTime != nil {
+		earliest = bs.Status.LastScheduleTime.Time
+	}
+	missed, next := scheduleTimes(sched, earliest, now)
+	if missed != nil && !bs.Spec.Suspend {
+		if err := c.runSchedule(ctx, active, *missed); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.pruneBackups(ctx, loc); err != nil {
+		return nil, err
+	}
+	bs.Status.NextScheduleTime = &metav1.Time{Time: next}
+	bs.SetCondition(metav1.Condition{
+		Type:   recon.ConditionTypeReady,
+		Status: metav1.ConditionTrue,
+		Reason: "Scheduled",
+	})
+	// the backup jobs are owned by the schedule so we only need to wake up at the next schedule time
+	return nil, recon.ErrReSync("wait next schedule", next.Sub(now))
+}
+
+func (c *ScheduleActor) listJobs(ctx *recon.Context[*v1alpha1.BackupSchedule]) ([]v1alpha1.BackupJob, error) {
+	jobList := &v1alpha1.BackupJobList{}
+	if err := ctx.List(jobList, client.InNamespace(ctx.Obj.Namespace), client.MatchingLabels{
+		common.BackupScheduleLabelKey: ctx.Obj.Name,
+	}); err != nil {
+		return nil, errors.WrapPrefix(err, "error list backup jobs", 0)
+	}
+	return jobList.Items, nil
+}
+
+// runSchedule creates the backup job of the scheduled time according to the concurrency policy
+func (c *ScheduleActor) runSchedule(ctx *recon.Context[*v1alpha1.BackupSchedule], active []v1alpha1.BackupJob, scheduled time.Time) error {
+	bs := ctx.Obj
+	switch bs.GetConcurrencyPolicy() {
+	case v1alpha1.ConcurrencyPolicyForbid:
+		if len(active) > 0 {
+			ctx.Log.Info("skip the scheduled backup since the previous one is still running", "scheduledTime", scheduled)
+			bs.Status.LastScheduleTime = &metav1.Time{Time: scheduled}
+			return nil
+		}
+	case v1alpha1.ConcurrencyPolicyReplace:
+		for i := range active {
+			if err := util.Ignore(apierrors.IsNotFound, ctx.Delete(&active[i])); err != nil {
+				return errors.WrapPrefix(err, "error delete running backup job", 0)
+			}
+		}
+		bs.Status.Active = nil
+	}
+	bj := &v1alpha1.BackupJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: bs.Namespace,
+			// the name is determined by the scheduled time, so that a schedule never runs twice
+			Name: fmt.Sprintf("%s-%d", bs.Name, scheduled.Unix()/60),
+			Labels: map[string]string{
+				common.BackupScheduleLabelKey: bs.Name,
+			},
+		},
+		Spec: *bs.Spec.Template.DeepCopy(),
+	}
+	if bj.Spec.Type == v1alpha1.BackupTypeIncremental && bj.Spec.ParentBackup == "" {
+		parent, err := c.latestBackup(ctx)
+		if err != nil {
+			return err
+		}
+		if parent == nil || (bs.Spec.MaxIncrementalChain != nil && len(parent.Meta.Chain) >= int(*bs.Spec.MaxIncrementalChain)) ||
+			!equality.Semantic.DeepEqual(parent.Meta.Encryption, bj.Spec.Encryption) {
+			// start a new chain, a rotated key also starts a new chain
+			bj.Spec.Type = v1alpha1.BackupTypeFull
+		} else {
+			bj.Spec.ParentBackup = parent.Name
+		}
+	}
+	if err := util.Ignore(apierrors.IsAlreadyExists, ctx.CreateOwned(bj)); err != nil {
+		return errors.WrapPrefix(err, "error create backup job", 0)
+	}
+	if !slices.Contains(bs.Status.Active, bj.Name) {
+		bs.Status.Active = append(bs.Status.Active, bj.Name)
+	}
+	bs.Status.LastScheduleTime = &metav1.Time{Time: scheduled}
+	return nil
+}
+
+// latestBackup returns the latest backup of the schedule that can be used as the parent
+// of an incremental backup, nil is returned if there is no such backup
+func (c *ScheduleActor) latestBackup(ctx *recon.Context[*v1alpha1.BackupSchedule]) (*v1alpha1.Backup, error) {
+	name := ctx.Obj.Status.LastBackup
+	if name == "" {
+		return nil, nil
+	}
+	backup := &v1alpha1.Backup{}
+	if err := ctx.Get(types.NamespacedName{Name: name}, backup); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.WrapPrefix(err, "error get latest backup", 0)
+	}
+	if backup.DeletionTimestamp != nil || !equality.Semantic.DeepEqual(backup.Meta.Location, ctx.Obj.Spec.Template.Target) {
+		return nil, nil
+	}
+	return backup, nil
+}
+
+// pruneBackups deletes the backups of the schedule that are not kept by the retention rules
+func (c *ScheduleActor) pruneBackups(ctx *recon.Context[*v1alpha1.BackupSchedule], loc *time.Location) error {
+	bs := ctx.Obj
+	if !bs.Spec.Retention.Enabled() {
+		return nil
+	}
+	backupList := &v1alpha1.BackupList{}
+	if err := ctx.List(backupList, client.MatchingLabels{
+		common.BackupScheduleLabelKey:          bs.Name,
+		common.BackupScheduleNamespaceLabelKey: bs.Namespace,
+	}); err != nil {
+		return errors.WrapPrefix(err, "error list backups", 0)
+	}
+	prune := backupsToPrune(backupList.Items, bs.Spec.Retention, loc)
+	if len(prune) == 0 {
+		return nil
+	}
+	// never prune the backups that the other backups depend on, including the ones not produced by the schedule
+	allBackups := &v1alpha1.BackupList{}
+	if err := ctx.List(allBackups); err != nil {
+		return errors.WrapPrefix(err, "error list backups", 0)
+	}
+	pruned := map[string]bool{}
+	for _, b := range prune {
+		pruned[b.Name] = true
+	}
+	depended := dependedBackups(slices.DeleteFunc(allBackups.Items, func(b v1alpha1.Backup) bool {
+		return pruned[b.Name]
+	}))
+	for _, b := range prune {
+		if depended[b.Name] {
+			continue
+		}
+		ctx.Log.Info("prune backup out of retention", "backup", b.Name)
+		if err := util.Ignore(apierrors.IsNotFound, ctx.Delete(b)); err != nil {
+			return errors.WrapPrefix(err, "error prune backup", 0)
+		}
+	}
+	return nil
+}
+
+func (c *ScheduleActor) Finalize(ctx *recon.Context[*v1alpha1.BackupSchedule]) (bool, error) {
+	// the backup jobs are garbage collected by the owner references and the backups are kept
+	return true, nil
+}
+
+func (c *ScheduleActor) Reconcile(mgr manager.Manager) error {
+	return recon.Setup[*v1alpha1.BackupSchedule](&v1alpha1.BackupSchedule{}, "backupschedule", mgr, c, recon.WithBuildFn(func(b *builder.Builder) {
+		b.Owns(&v1alpha1.BackupJob{})
+	}))
+}
+
+func parseSchedule(bs *v1alpha1.BackupSchedule) (*time.Location, cron.Schedule, error) {
+	return parseCron(bs.Spec.Schedule, bs.GetTimeZone())
+}
+
+// parseCron parses the cron expression that is interpreted in the time zone
+func parseCron(schedule string, timeZone string) (*time.Location, cron.Schedule, error) {
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, nil, errors.WrapPrefix(err, "invalid time zone", 0)
+	}
+	sched, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return nil, nil, errors.WrapPrefix(err, "invalid schedule", 0)
+	}
+	if spec, ok := sched.(*cron.SpecSchedule); ok {
+		spec.Location = loc
+	}
+	return loc, sched, nil
+}
+
+// scheduleTimes returns the latest schedule time in (earliest, now] if any, and the next schedule time after now.
+// Only the latest missed schedule is run, the others are skipped like the CronJob does.
+func scheduleTimes(sched cron.Schedule, earliest time.Time, now time.Time) (*time.Time, time.Time) {
+	var missed *time.Time
+	t := sched.Next(earliest)
+	for i := 0; !t.After(now) && i < maxMissedSchedules; i++ {
+		scheduled := t
+		missed = &scheduled
+		t = sched.Next(t)
+	}
+	if !t.After(now) {
+		// too many missed schedules, start over from now
+		t = sched.Next(now)
+	}
+	return missed, t
+}
+
+// syncScheduleStatus records the results of the ended backup jobs to the status and returns the active jobs
+func syncScheduleStatus(bs *v1alpha1.BackupSchedule, jobs []v1alpha1.BackupJob) []v1alpha1.BackupJob {
+	var active []v1alpha1.BackupJob
+	var activeNames []string
+	for i := range jobs {
+		bj := &jobs[i]
+		if bj.DeletionTimestamp != nil {
+			continue
+		}
+		cond, ok := recon.GetCondition(bj, v1alpha1.JobConditionTypeEnded)
+		if !ok || cond.Status != metav1.ConditionTrue {
+			active = append(active, *bj)
+			activeNames = append(activeNames, bj.Name)
+			continue
+		}
+		endTime := cond.LastTransitionTime
+		switch bj.Status.Phase {
+		case v1alpha1.JobPhaseCompleted:
+			if bs.Status.LastSuccessfulTime == nil || bs.Status.LastSuccessfulTime.Before(&endTime) {
+				bs.Status.LastSuccessfulTime = &endTime
+				bs.Status.LastBackup = bj.Status.Backup
+			}
+		case v1alpha1.JobPhaseFailed:
+			if bs.Status.LastFailureTime == nil || bs.Status.LastFailureTime.Before(&endTime) {
+				bs.Status.LastFailureTime = &endTime
+				bs.Status.LastFailedJob = bj.Name
+				bs.Status.LastFailureMessage = cond.Message
+			}
+		}
+	}
+	sort.Strings(activeNames)
+	bs.Status.Active = activeNames
+	return active
+}
+
+// backupsToPrune returns the backups that are not kept by any of the retention rules,
+// the periods of the daily, weekly and monthly rules are calculated in the given location
+func backupsToPrune(backups []v1alpha1.Backup, r *v1alpha1.BackupRetention, loc *time.Location) []*v1alpha1.Backup {
+	sorted := make([]*v1alpha1.Backup, 0, len(backups))
+	for i := range backups {
+		sorted = append(sorted, &backups[i])
+	}
+	// latest first
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ti, tj := sorted[i].Meta.AtTime.Time, sorted[j].Meta.AtTime.Time
+		if ti.Equal(tj) {
+			return sorted[i].Name > sorted[j].Name
+		}
+		return ti.After(tj)
+	})
+	keep := map[string]bool{}
+	if r.KeepLast != nil {
+		for i := 0; i < len(sorted) && i < int(*r.KeepLast); i++ {
+			keep[sorted[i].Name] = true
+		}
+	}
+	keepByPeriod := func(n *int32, period func(t time.Time) string) {
+		if n == nil {
+			return
+		}
+		seen := map[string]bool{}
+		for _, b := range sorted {
+			if len(seen) >= int(*n) {
+				return
+			}
+			p := period(b.Meta.AtTime.In(loc))
+			if seen[p] {
+				continue
+			}
+			seen[p] = true
+			keep[b.Name] = true
+		}
+	}
+	keepByPeriod(r.KeepDaily, func(t time.Time) string {
+		return t.Format("2006-01-02")
+	})
+	keepByPeriod(r.KeepWeekly, func(t time.Time) string {
+		y, w := t.ISOWeek()
+		return fmt.Sprintf("%d-%d", y, w)
+	})
+	keepByPeriod(r.KeepMonthly, func(t time.Time) string {
+		return t.Format("2006-01")
+	})
+	// the backups that the kept incremental backups depend on are kept as well
+	for _, b := range sorted {
+		if keep[b.Name] {
+			for _, name := range b.Meta.Chain {
+				keep[name] = true
+			}
+		}
+	}
+	var prune []*v1alpha1.Backup
+	for _, b := range sorted {
+		if !keep[b.Name] && b.DeletionTimestamp == nil {
+			prune = append(prune, b)
+		}
+	}
+	return prune
+}
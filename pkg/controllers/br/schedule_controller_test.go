@@ -0,0 +1,167 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func Test_scheduleTimes(t *testing.T) {
+	g := NewGomegaWithT(t)
+	bs := &v1alpha1.BackupSchedule{Spec: v1alpha1.BackupScheduleSpec{
+		Schedule: "0 2 * * *",
+		TimeZone: pointer.String("Asia/Shanghai"),
+	}}
+	loc, sched, err := parseSchedule(bs)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	earliest := time.Date(2025, 1, 1, 3, 0, 0, 0, loc)
+	// not yet scheduled
+	missed, next := scheduleTimes(sched, earliest, earliest.Add(time.Hour))
+	g.Expect(missed).To(BeNil())
+	g.Expect(next).To(BeTemporally("==", time.Date(2025, 1, 2, 2, 0, 0, 0, loc)))
+
+	// only the latest missed schedule is returned
+	missed, next = scheduleTimes(sched, earliest, time.Date(2025, 1, 4, 3, 0, 0, 0, loc))
+	g.Expect(missed).NotTo(BeNil())
+	g.Expect(*missed).To(BeTemporally("==", time.Date(2025, 1, 4, 2, 0, 0, 0, loc)))
+	g.Expect(next).To(BeTemporally("==", time.Date(2025, 1, 5, 2, 0, 0, 0, loc)))
+
+	bs.Spec.TimeZone = pointer.String("Mars/Olympus")
+	_, _, err = parseSchedule(bs)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func Test_backupsToPrune(t *testing.T) {
+	// one backup every 12 hours from 2025-01-31 12:00 back to 2024-12-01 00:00
+	start := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	var backups []v1alpha1.Backup
+	for i := 0; i < 124; i++ {
+		backups = append(backups, v1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("b-%d", i)},
+			Meta: v1alpha1.BackupMeta{
+				AtTime: metav1.NewTime(start.Add(-time.Duration(i) * 12 * time.Hour)),
+			},
+		})
+	}
+	tests := []struct {
+		name      string
+		retention *v1alpha1.BackupRetention
+		keep      []string
+	}{{
+		name:      "keep last",
+		retention: &v1alpha1.BackupRetention{KeepLast: pointer.Int32(3)},
+		keep:      []string{"b-0", "b-1", "b-2"},
+	}, {
+		name:      "keep daily",
+		retention: &v1alpha1.BackupRetention{KeepDaily: pointer.Int32(2)},
+		keep:      []string{"b-0", "b-2"},
+	}, {
+		name:      "keep weekly",
+		retention: &v1alpha1.BackupRetention{KeepWeekly: pointer.Int32(2)},
+		// 2025-01-31 is Friday, the previous week ends at 2025-01-26 12:00
+		keep: []string{"b-0", "b-10"},
+	}, {
+		name:      "keep monthly",
+		retention: &v1alpha1.BackupRetention{KeepMonthly: pointer.Int32(3)},
+		keep:      []string{"b-0", "b-62"},
+	}, {
+		name: "rules are combined",
+		retention: &v1alpha1.BackupRetention{
+			KeepLast:    pointer.Int32(1),
+			KeepDaily:   pointer.Int32(2),
+			KeepMonthly: pointer.Int32(2),
+		},
+		keep: []string{"b-0", "b-2", "b-62"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			pruned := backupsToPrune(backups, tt.retention, time.UTC)
+			g.Expect(pruned).To(HaveLen(len(backups) - len(tt.keep)))
+			for _, b := range pruned {
+				g.Expect(tt.keep).NotTo(ContainElement(b.Name))
+			}
+		})
+	}
+}
+
+func Test_syncScheduleStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+	bs := &v1alpha1.BackupSchedule{}
+	ended := func(name, phase string, at time.Time, msg string) v1alpha1.BackupJob {
+		bj := v1alpha1.BackupJob{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		bj.Status.Phase = phase
+		bj.Status.Backup = name + "-backup"
+		bj.Status.Conditions = []metav1.Condition{{
+			Type:               v1alpha1.JobConditionTypeEnded,
+			Status:             metav1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(at),
+			Message:            msg,
+		}}
+		return bj
+	}
+	now := time.Now().Truncate(time.Second)
+	active := syncScheduleStatus(bs, []v1alpha1.BackupJob{
+		ended("j1", v1alpha1.JobPhaseCompleted, now.Add(-2*time.Hour), ""),
+		ended("j2", v1alpha1.JobPhaseCompleted, now.Add(-time.Hour), ""),
+		ended("j3", v1alpha1.JobPhaseFailed, now.Add(-30*time.Minute), "boom"),
+		{ObjectMeta: metav1.ObjectMeta{Name: "j4"}},
+	})
+	g.Expect(active).To(HaveLen(1))
+	g.Expect(bs.Status.Active).To(Equal([]string{"j4"}))
+	g.Expect(bs.Status.LastBackup).To(Equal("j2-backup"))
+	g.Expect(bs.Status.LastSuccessfulTime.Time).To(BeTemporally("==", now.Add(-time.Hour)))
+	g.Expect(bs.Status.LastFailedJob).To(Equal("j3"))
+	g.Expect(bs.Status.LastFailureMessage).To(Equal("boom"))
+}
+
+func Test_backupsToPrune_chain(t *testing.T) {
+	g := NewGomegaWithT(t)
+	now := time.Now()
+	backup := func(name string, age time.Duration, chain ...string) v1alpha1.Backup {
+		b := v1alpha1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Meta: v1alpha1.BackupMeta{
+				AtTime: metav1.NewTime(now.Add(-age)),
+				Chain:  chain,
+			},
+		}
+		if len(chain) > 0 {
+			b.Meta.Type = v1alpha1.BackupTypeIncremental
+		}
+		return b
+	}
+	backups := []v1alpha1.Backup{
+		backup("full-1", 4*time.Hour),
+		backup("full-2", 3*time.Hour),
+		backup("inc-1", 2*time.Hour, "full-2"),
+		backup("inc-2", time.Hour, "full-2", "inc-1"),
+	}
+	// the base and the intermediate backups of the kept incremental backup are kept
+	pruned := backupsToPrune(backups, &v1alpha1.BackupRetention{KeepLast: pointer.Int32(1)}, time.UTC)
+	g.Expect(pruned).To(HaveLen(1))
+	g.Expect(pruned[0].Name).To(Equal("full-1"))
+
+	depended := dependedBackups(backups)
+	g.Expect(depended).To(Equal(map[string]bool{"full-2": true, "inc-1": true}))
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/mosql"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// sysAccount is the account that the operator connects as, the database and table level objects
+// are resolved in it
+const sysAccount = "sys"
+
+// snapshotName returns the name of the snapshot taken by the schedule at the time
+func snapshotName(ss *v1alpha1.SnapshotSchedule, t time.Time) string {
+	return fmt.Sprintf("%s_%s", strings.NewReplacer("-", "_", ".", "_").Replace(ss.Name), t.UTC().Format("20060102150405"))
+}
+
+func createSnapshotSQL(name string, o *v1alpha1.SnapshotObject) string {
+	sql := fmt.Sprintf("CREATE SNAPSHOT IF NOT EXISTS %s FOR ", quoteIdentifier(name))
+	switch o.Level {
+	case v1alpha1.SnapshotLevelAccount:
+		return sql + "ACCOUNT " + quoteIdentifier(o.ObjectName)
+	case v1alpha1.SnapshotLevelDatabase:
+		return sql + "DATABASE " + quoteIdentifier(o.Database())
+	case v1alpha1.SnapshotLevelTable:
+		return sql + fmt.Sprintf("TABLE %s %s", quoteIdentifier(o.Database()), quoteIdentifier(o.Table()))
+	default:
+		return sql + "CLUSTER"
+	}
+}
+
+func dropSnapshotSQL(name string) string {
+	return "DROP SNAPSHOT IF EXISTS " + quoteIdentifier(name)
+}
+
+func restoreSnapshotSQL(r *v1alpha1.SnapshotRestoreSpec) string {
+	if r.Level == v1alpha1.SnapshotLevelCluster {
+		return "RESTORE CLUSTER FROM SNAPSHOT " + quoteIdentifier(r.Snapshot)
+	}
+	sb := strings.Builder{}
+	switch r.Level {
+	case v1alpha1.SnapshotLevelAccount:
+		sb.WriteString("RESTORE ACCOUNT " + quoteIdentifier(r.ObjectName))
+	case v1alpha1.SnapshotLevelDatabase:
+		sb.WriteString(fmt.Sprintf("RESTORE ACCOUNT %s DATABASE %s", quoteIdentifier(sysAccount), quoteIdentifier(r.Database())))
+	case v1alpha1.SnapshotLevelTable:
+		sb.WriteString(fmt.Sprintf("RESTORE ACCOUNT %s DATABASE %s TABLE %s", quoteIdentifier(sysAccount), quoteIdentifier(r.Database()), quoteIdentifier(r.Table())))
+	}
+	sb.WriteString(" FROM SNAPSHOT " + quoteIdentifier(r.Snapshot))
+	if r.ToAccount != "" {
+		sb.WriteString(" TO ACCOUNT " + quoteIdentifier(r.ToAccount))
+	}
+	return sb.String()
+}
+
+// quoteIdentifier quotes the name as a SQL identifier, the backticks in the name are escaped by doubling
+// them so that the name cannot break out of the quotes. The names are validated by the webhook, this
+// guards the statements that are run as root against the objects admitted without the webhook
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// clusterSQLClient returns the SQL client of the cluster, a resync is requested if the cluster is not ready.
+// The client must be closed after use
+func clusterSQLClient[T client.Object](ctx *recon.Context[T], mo *v1alpha1.MatrixOneCluster) (mosql.Client, error) {
+	if !recon.IsReady(&mo.Status) || mo.Status.CredentialRef == nil {
+		return nil, recon.ErrReSync(fmt.Sprintf("wait cluster %s ready", mo.Name), pollInterval)
+	}
+	return mosql.NewClient(fmt.Sprintf("%s:%d", mo.Status.Host, mo.Status.Port), ctx.Client, types.NamespacedName{Namespace: mo.Namespace, Name: mo.Status.CredentialRef.Name}), nil
+}
+
+// getCluster returns the cluster in the namespace, nil if the cluster is not found
+func getCluster(cli recon.KubeClient, ns string, name string) (*v1alpha1.MatrixOneCluster, error) {
+	mo := &v1alpha1.MatrixOneCluster{}
+	if err := cli.Get(types.NamespacedName{Namespace: ns, Name: name}, mo); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.WrapPrefix(err, "error get cluster", 0)
+	}
+	return mo, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/controller-runtime/pkg/util"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// snapshotRestoreTimeout is the timeout of restoring from a snapshot
+const snapshotRestoreTimeout = 30 * time.Minute
+
+// SnapshotRestoreActor reconciles SnapshotRestore
+type SnapshotRestoreActor struct {
+	// image runs the restore statement with the mysql client
+	image string
+}
+
+func NewSnapshotRestoreActor(image string) *SnapshotRestoreActor {
+	return &SnapshotRestoreActor{image: image}
+}
+
+var _ recon.Actor[*v1alpha1.SnapshotRestore] = &SnapshotRestoreActor{}
+
+func (c *SnapshotRestoreActor) Observe(ctx *recon.Context[*v1alpha1.SnapshotRestore]) (recon.Action[*v1alpha1.SnapshotRestore], error) {
+	switch ctx.Obj.Status.Phase {
+	case v1alpha1.JobPhaseCompleted, v1alpha1.JobPhaseFailed:
+		return nil, nil
+	case v1alpha1.JobPhaseRunning:
+		return c.waitJob, nil
+	default:
+		return c.restore, nil
+	}
+}
+
+// restore starts the job that runs the restore statement, which returns after the data is restored
+// and may take a long time
+func (c *SnapshotRestoreActor) restore(ctx *recon.Context[*v1alpha1.SnapshotRestore]) error {
+	sr := ctx.Obj
+	if sr.Status.Phase == "" {
+		sr.Status.Phase = v1alpha1.JobPhasePending
+	}
+	mo, err := getCluster(ctx, sr.Namespace, sr.Spec.ClusterRef)
+	if err != nil {
+		return err
+	}
+	if mo == nil {
+		return c.end(ctx, v1alpha1.JobPhaseFailed, "ClusterNotFound", fmt.Sprintf("cluster %s not found", sr.Spec.ClusterRef))
+	}
+	if !recon.IsReady(&mo.Status) || mo.Status.CredentialRef == nil {
+		return recon.ErrReSync(fmt.Sprintf("wait cluster %s ready", mo.Name), pollInterval)
+	}
+	job := buildJobWithMeta(common.ObjMetaTemplate(sr, sr.Name), nil, c.image, []string{"sh", "-c", sqlCommand(mo)}, sqlEnv(mo, restoreSnapshotSQL(&sr.Spec)))
+	job.Spec.ActiveDeadlineSeconds = pointer.Int64(int64(snapshotRestoreTimeout.Seconds()))
+	if err := util.Ignore(apierrors.IsAlreadyExists, ctx.CreateOwned(job)); err != nil {
+		return errors.WrapPrefix(err, "error ensure job", 0)
+	}
+	sr.Status.Phase = v1alpha1.JobPhaseRunning
+	return ctx.UpdateStatus(sr)
+}
+
+// waitJob waits the restore job to complete
+func (c *SnapshotRestoreActor) waitJob(ctx *recon.Context[*v1alpha1.SnapshotRestore]) error {
+	sr := ctx.Obj
+	job := &batchv1.Job{}
+	if err := ctx.Get(types.NamespacedName{Namespace: sr.Namespace, Name: sr.Name}, job); err != nil {
+		if apierrors.IsNotFound(err) {
+			return c.end(ctx, v1alpha1.JobPhaseFailed, "RestoreFailed", "job is cleaned externally")
+		}
+		return errors.WrapPrefix(err, "error get job", 0)
+	}
+	if job.Status.Failed > 0 {
+		return c.end(ctx, v1alpha1.JobPhaseFailed, "RestoreFailed", fmt.Sprintf("error restore from snapshot %s, check the logs of job %s for details", sr.Spec.Snapshot, job.Name))
+	}
+	if job.Status.Succeeded == 0 {
+		return recon.ErrReSync("wait restore job complete", pollInterval)
+	}
+	ctx.Log.Info("restored from snapshot", "cluster", sr.Spec.ClusterRef, "snapshot", sr.Spec.Snapshot)
+	sr.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	return c.end(ctx, v1alpha1.JobPhaseCompleted, "RestoreComplete", "")
+}
+
+func (c *SnapshotRestoreActor) end(ctx *recon.Context[*v1alpha1.SnapshotRestore], phase string, reason string, msg string) error {
+	sr := ctx.Obj
+	sr.Status.Phase = phase
+	meta.SetStatusCondition(&sr.Status.Conditions, metav1.Condition{
+		Type:    v1alpha1.JobConditionTypeEnded,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: msg,
+	})
+	return ctx.UpdateStatus(sr)
+}
+
+func (c *SnapshotRestoreActor) Finalize(_ *recon.Context[*v1alpha1.SnapshotRestore]) (bool, error) {
+	return true, nil
+}
+
+func (c *SnapshotRestoreActor) Reconcile(mgr manager.Manager) error {
+	return recon.Setup[*v1alpha1.SnapshotRestore](&v1alpha1.SnapshotRestore{}, "snapshotrestore", mgr, c, recon.WithBuildFn(func(b *builder.Builder) {
+		b.Owns(&batchv1.Job{})
+	}))
+}
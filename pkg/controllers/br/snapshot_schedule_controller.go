@@ -0,0 +1,130 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// SnapshotScheduleActor reconciles SnapshotSchedule
+type SnapshotScheduleActor struct{}
+
+var _ recon.Actor[*v1alpha1.SnapshotSchedule] = &SnapshotScheduleActor{}
+
+func (c *SnapshotScheduleActor) Observe(ctx *recon.Context[*v1alpha1.SnapshotSchedule]) (recon.Action[*v1alpha1.SnapshotSchedule], error) {
+	ss := ctx.Obj
+	_, sched, err := parseCron(ss.Spec.Schedule, ss.GetTimeZone())
+	if err != nil {
+		ss.SetCondition(metav1.Condition{
+			Type:    recon.ConditionTypeReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  "InvalidSchedule",
+			Message: err.Error(),
+		})
+		return nil, nil
+	}
+	now := time.Now()
+	earliest := ss.CreationTimestamp.Time
+	if ss.Status.LastScheduleTime != nil {
+		earliest = ss.Status.LastScheduleTime.Time
+	}
+	missed, next := scheduleTimes(sched, earliest, now)
+	if (missed != nil && !ss.Spec.Suspend) || len(ss.Status.Snapshots) > ss.GetRetention() {
+		mo, err := getCluster(ctx, ss.Namespace, ss.Spec.ClusterRef)
+		if err != nil {
+			return nil, err
+		}
+		if mo == nil {
+			ss.SetCondition(metav1.Condition{
+				Type:    recon.ConditionTypeReady,
+				Status:  metav1.ConditionFalse,
+				Reason:  "ClusterNotFound",
+				Message: "cluster " + ss.Spec.ClusterRef + " not found",
+			})
+			return nil, recon.ErrReSync("wait cluster created", pollInterval)
+		}
+		sqlcli, err := clusterSQLClient(ctx, mo)
+		if err != nil {
+			return nil, err
+		}
+		defer sqlcli.Close()
+		if missed != nil && !ss.Spec.Suspend {
+			name := snapshotName(ss, *missed)
+			if _, err := sqlcli.Query(context.TODO(), createSnapshotSQL(name, &ss.Spec.SnapshotObject)); err != nil {
+				return nil, errors.WrapPrefix(err, "create snapshot "+name, 0)
+			}
+			ctx.Log.Info("snapshot created", "snapshot", name)
+			ss.Status.Snapshots = append(ss.Status.Snapshots, v1alpha1.SnapshotRecord{Name: name, CreatedTime: metav1.NewTime(*missed)})
+		}
+		// the oldest snapshots out of retention are dropped
+		for len(ss.Status.Snapshots) > ss.GetRetention() {
+			name := ss.Status.Snapshots[0].Name
+			if _, err := sqlcli.Query(context.TODO(), dropSnapshotSQL(name)); err != nil {
+				return nil, errors.WrapPrefix(err, "drop snapshot "+name, 0)
+			}
+			ctx.Log.Info("snapshot out of retention dropped", "snapshot", name)
+			ss.Status.Snapshots = ss.Status.Snapshots[1:]
+		}
+	}
+	if missed != nil {
+		ss.Status.LastScheduleTime = &metav1.Time{Time: *missed}
+	}
+	ss.Status.NextScheduleTime = &metav1.Time{Time: next}
+	ss.SetCondition(metav1.Condition{
+		Type:   recon.ConditionTypeReady,
+		Status: metav1.ConditionTrue,
+		Reason: "Scheduled",
+	})
+	return nil, recon.ErrReSync("wait next schedule", next.Sub(now))
+}
+
+func (c *SnapshotScheduleActor) Finalize(ctx *recon.Context[*v1alpha1.SnapshotSchedule]) (bool, error) {
+	ss := ctx.Obj
+	if len(ss.Status.Snapshots) == 0 {
+		return true, nil
+	}
+	mo, err := getCluster(ctx, ss.Namespace, ss.Spec.ClusterRef)
+	if err != nil {
+		return false, err
+	}
+	if mo == nil || mo.DeletionTimestamp != nil {
+		// the snapshots are gone with the cluster
+		return true, nil
+	}
+	sqlcli, err := clusterSQLClient(ctx, mo)
+	if err != nil {
+		return false, err
+	}
+	defer sqlcli.Close()
+	for len(ss.Status.Snapshots) > 0 {
+		name := ss.Status.Snapshots[0].Name
+		if _, err := sqlcli.Query(context.TODO(), dropSnapshotSQL(name)); err != nil {
+			return false, errors.WrapPrefix(err, "drop snapshot "+name, 0)
+		}
+		ss.Status.Snapshots = ss.Status.Snapshots[1:]
+	}
+	return true, nil
+}
+
+func (c *SnapshotScheduleActor) Reconcile(mgr manager.Manager) error {
+	return recon.Setup[*v1alpha1.SnapshotSchedule](&v1alpha1.SnapshotSchedule{}, "snapshotschedule", mgr, c)
+}
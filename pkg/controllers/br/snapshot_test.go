@@ -0,0 +1,220 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/matrixorigin/controller-runtime/pkg/fake"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/mosql"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type recordClient struct {
+	mosql.Client
+	queries []string
+}
+
+func (c *recordClient) Close() error {
+	return nil
+}
+
+func (c *recordClient) Query(_ context.Context, query string, _ ...any) (*sql.Rows, error) {
+	c.queries = append(c.queries, query)
+	return nil, nil
+}
+
+func TestSnapshotSQL(t *testing.T) {
+	tests := []struct {
+		name        string
+		object      v1alpha1.SnapshotObject
+		toAccount   string
+		wantCreate  string
+		wantRestore string
+	}{{
+		name:        "cluster",
+		object:      v1alpha1.SnapshotObject{Level: v1alpha1.SnapshotLevelCluster},
+		wantCreate:  "CREATE SNAPSHOT IF NOT EXISTS `sp` FOR CLUSTER",
+		wantRestore: "RESTORE CLUSTER FROM SNAPSHOT `sp`",
+	}, {
+		name:        "account",
+		object:      v1alpha1.SnapshotObject{Level: v1alpha1.SnapshotLevelAccount, ObjectName: "acc1"},
+		toAccount:   "acc2",
+		wantCreate:  "CREATE SNAPSHOT IF NOT EXISTS `sp` FOR ACCOUNT `acc1`",
+		wantRestore: "RESTORE ACCOUNT `acc1` FROM SNAPSHOT `sp` TO ACCOUNT `acc2`",
+	}, {
+		name:        "database",
+		object:      v1alpha1.SnapshotObject{Level: v1alpha1.SnapshotLevelDatabase, ObjectName: "db"},
+		wantCreate:  "CREATE SNAPSHOT IF NOT EXISTS `sp` FOR DATABASE `db`",
+		wantRestore: "RESTORE ACCOUNT `sys` DATABASE `db` FROM SNAPSHOT `sp`",
+	}, {
+		name:        "table",
+		object:      v1alpha1.SnapshotObject{Level: v1alpha1.SnapshotLevelTable, ObjectName: "db.t"},
+		wantCreate:  "CREATE SNAPSHOT IF NOT EXISTS `sp` FOR TABLE `db` `t`",
+		wantRestore: "RESTORE ACCOUNT `sys` DATABASE `db` TABLE `t` FROM SNAPSHOT `sp`",
+	}, {
+		name:        "backticks are escaped",
+		object:      v1alpha1.SnapshotObject{Level: v1alpha1.SnapshotLevelAccount, ObjectName: "a`; DROP DATABASE d; --"},
+		toAccount:   "b`c",
+		wantCreate:  "CREATE SNAPSHOT IF NOT EXISTS `sp` FOR ACCOUNT `a``; DROP DATABASE d; --`",
+		wantRestore: "RESTORE ACCOUNT `a``; DROP DATABASE d; --` FROM SNAPSHOT `sp` TO ACCOUNT `b``c`",
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			g.Expect(createSnapshotSQL("sp", &tt.object)).To(Equal(tt.wantCreate))
+			spec := &v1alpha1.SnapshotRestoreSpec{Snapshot: "sp", SnapshotObject: tt.object, ToAccount: tt.toAccount}
+			g.Expect(restoreSnapshotSQL(spec)).To(Equal(tt.wantRestore))
+		})
+	}
+}
+
+func TestSnapshotScheduleActor(t *testing.T) {
+	g := NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(v1alpha1.AddToScheme(s))
+	sqlcli := &recordClient{}
+	newClient := mosql.NewClient
+	defer func() { mosql.NewClient = newClient }()
+	mosql.NewClient = func(_ string, _ client.Client, _ types.NamespacedName) mosql.Client {
+		return sqlcli
+	}
+
+	mo := &v1alpha1.MatrixOneCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mo"}}
+	now := time.Now()
+	ss := &v1alpha1.SnapshotSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              "hourly-db",
+			CreationTimestamp: metav1.NewTime(now.Add(-2 * time.Hour)),
+		},
+		Spec: v1alpha1.SnapshotScheduleSpec{
+			ClusterRef:     mo.Name,
+			SnapshotObject: v1alpha1.SnapshotObject{Level: v1alpha1.SnapshotLevelDatabase, ObjectName: "db"},
+			Schedule:       "0 * * * *",
+			Retention:      2,
+		},
+		Status: v1alpha1.SnapshotScheduleStatus{
+			Snapshots: []v1alpha1.SnapshotRecord{{Name: "hourly_db_1"}, {Name: "hourly_db_2"}},
+		},
+	}
+	cli := &fake.Client{Client: fake.KubeClientBuilder().WithScheme(s).WithObjects(mo, ss).WithStatusSubresource(mo, ss).Build()}
+	ctx := fake.NewContext(ss, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+	actor := &SnapshotScheduleActor{}
+
+	// wait the cluster to be ready
+	_, err := actor.Observe(ctx)
+	g.Expect(err).To(BeAssignableToTypeOf(&recon.ReSync{}))
+	g.Expect(ss.Status.LastScheduleTime).To(BeNil())
+	mo.Status = v1alpha1.MatrixOneClusterStatus{
+		ConditionalStatus: v1alpha1.ConditionalStatus{Conditions: []metav1.Condition{{
+			Type:   recon.ConditionTypeReady,
+			Status: metav1.ConditionTrue,
+		}}},
+		CredentialRef: &corev1.LocalObjectReference{Name: "mo-credential"},
+		Host:          "mo-tp-cn",
+		Port:          6001,
+	}
+	g.Expect(ctx.Update(mo)).To(Succeed())
+
+	// the latest missed schedule is taken and the oldest snapshot out of retention is dropped
+	_, err = actor.Observe(ctx)
+	g.Expect(err).To(BeAssignableToTypeOf(&recon.ReSync{}))
+	latest := now.Truncate(time.Hour)
+	name := snapshotName(ss, latest)
+	g.Expect(sqlcli.queries).To(Equal([]string{
+		"CREATE SNAPSHOT IF NOT EXISTS `" + name + "` FOR DATABASE `db`",
+		"DROP SNAPSHOT IF EXISTS `hourly_db_1`",
+	}))
+	g.Expect(ss.Status.Snapshots).To(HaveLen(2))
+	g.Expect(ss.Status.Snapshots[1].Name).To(Equal(name))
+	g.Expect(ss.Status.LastScheduleTime.Time).To(BeTemporally("==", latest))
+	g.Expect(ss.Status.NextScheduleTime.Time).To(BeTemporally("==", latest.Add(time.Hour)))
+	g.Expect(recon.IsReady(&ss.Status)).To(BeTrue())
+
+	// the live snapshots are dropped on deletion
+	sqlcli.queries = nil
+	done, err := actor.Finalize(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(done).To(BeTrue())
+	g.Expect(sqlcli.queries).To(Equal([]string{
+		"DROP SNAPSHOT IF EXISTS `hourly_db_2`",
+		"DROP SNAPSHOT IF EXISTS `" + name + "`",
+	}))
+	g.Expect(ss.Status.Snapshots).To(BeEmpty())
+}
+
+func TestSnapshotRestoreActor(t *testing.T) {
+	g := NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(v1alpha1.AddToScheme(s))
+
+	mo := &v1alpha1.MatrixOneCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mo"},
+		Status: v1alpha1.MatrixOneClusterStatus{
+			ConditionalStatus: v1alpha1.ConditionalStatus{Conditions: []metav1.Condition{{
+				Type:   recon.ConditionTypeReady,
+				Status: metav1.ConditionTrue,
+			}}},
+			CredentialRef: &corev1.LocalObjectReference{Name: "mo-credential"},
+			Host:          "mo-tp-cn",
+			Port:          6001,
+		},
+	}
+	sr := &v1alpha1.SnapshotRestore{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "restore-db"},
+		Spec: v1alpha1.SnapshotRestoreSpec{
+			ClusterRef:     mo.Name,
+			Snapshot:       "sp",
+			SnapshotObject: v1alpha1.SnapshotObject{Level: v1alpha1.SnapshotLevelDatabase, ObjectName: "db"},
+		},
+	}
+	cli := &fake.Client{Client: fake.KubeClientBuilder().WithScheme(s).WithObjects(mo, sr).WithStatusSubresource(sr).Build()}
+	ctx := fake.NewContext(sr, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+	actor := NewSnapshotRestoreActor("dump")
+
+	// the restore statement runs in a job instead of blocking the reconciliation
+	action, err := actor.Observe(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(action(ctx)).To(Succeed())
+	g.Expect(sr.Status.Phase).To(Equal(v1alpha1.JobPhaseRunning))
+	job := &batchv1.Job{}
+	g.Expect(ctx.Get(types.NamespacedName{Namespace: "default", Name: sr.Name}, job)).To(Succeed())
+	g.Expect(*job.Spec.ActiveDeadlineSeconds).To(Equal(int64(snapshotRestoreTimeout.Seconds())))
+	g.Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: MOSQLEnvKey, Value: restoreSnapshotSQL(&sr.Spec)}))
+	g.Expect(actor.waitJob(ctx)).To(BeAssignableToTypeOf(&recon.ReSync{}))
+
+	job.Status.Succeeded = 1
+	g.Expect(cli.Status().Update(ctx, job)).To(Succeed())
+	g.Expect(actor.waitJob(ctx)).To(Succeed())
+	g.Expect(sr.Status.Phase).To(Equal(v1alpha1.JobPhaseCompleted))
+	g.Expect(sr.Status.CompletionTime).NotTo(BeNil())
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"fmt"
+
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// MOSQLEnvKey passes the statement to the SQL job, so that the statement needs no shell quoting
+const MOSQLEnvKey = "MO_SQL"
+
+// moCredentialEnv returns the env of the MO user and password stored in the Secret
+func moCredentialEnv(secret string) []corev1.EnvVar {
+	return []corev1.EnvVar{{
+		Name: MOUserEnvKey,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secret},
+				Key:                  "username",
+			},
+		},
+	}, {
+		Name: MOPasswordEnvKey,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secret},
+				Key:                  "password",
+			},
+		},
+	}}
+}
+
+// buildSQLJob builds the job that runs the statement in the ready cluster with the mysql client, a
+// long-running statement like restore is tracked as a job instead of blocking the reconciliation
+func buildSQLJob(o JobObject, image string, mo *v1alpha1.MatrixOneCluster, stmt string) *batchv1.Job {
+	return buildJob(o, image, sqlCommand(mo), sqlEnv(mo, stmt))
+}
+
+// sqlCommand returns the mysql client command that runs the statement in MOSQLEnvKey in the cluster
+func sqlCommand(mo *v1alpha1.MatrixOneCluster) string {
+	return fmt.Sprintf(`mysql -h %s -P %d -u "$%s" -p"$%s" -e "$%s"`, mo.Status.Host, mo.Status.Port, MOUserEnvKey, MOPasswordEnvKey, MOSQLEnvKey)
+}
+
+// sqlEnv returns the function that injects the credential of the cluster and the statement to run
+func sqlEnv(mo *v1alpha1.MatrixOneCluster, stmt string) func(c *corev1.Container) {
+	return func(c *corev1.Container) {
+		c.Env = append(moCredentialEnv(mo.Status.CredentialRef.Name), corev1.EnvVar{Name: MOSQLEnvKey, Value: stmt})
+	}
+}
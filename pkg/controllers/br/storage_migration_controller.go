@@ -0,0 +1,503 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/controller-runtime/pkg/util"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/cmd"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// StorageMigrationActor reconciles StorageMigration
+type StorageMigrationActor struct {
+	image string
+}
+
+func NewStorageMigrationActor(image string) *StorageMigrationActor {
+	return &StorageMigrationActor{image: image}
+}
+
+var _ recon.Actor[*v1alpha1.StorageMigration] = &StorageMigrationActor{}
+
+func (c *StorageMigrationActor) Observe(ctx *recon.Context[*v1alpha1.StorageMigration]) (recon.Action[*v1alpha1.StorageMigration], error) {
+	switch ctx.Obj.Status.Phase {
+	case v1alpha1.JobPhaseCompleted, v1alpha1.JobPhaseFailed:
+		return nil, nil
+	case v1alpha1.StorageMigrationPhasePreCopying:
+		return c.preCopy, nil
+	case v1alpha1.StorageMigrationPhaseQuiescing:
+		return c.quiesce, nil
+	case v1alpha1.StorageMigrationPhaseSyncing:
+		return c.sync, nil
+	case v1alpha1.StorageMigrationPhaseVerifying:
+		return c.verify, nil
+	case v1alpha1.StorageMigrationPhaseSwitching:
+		return c.switchStorage, nil
+	default:
+		return c.start, nil
+	}
+}
+
+// start checks the cluster and starts to copy the objects while the cluster is serving
+func (c *StorageMigrationActor) start(ctx *recon.Context[*v1alpha1.StorageMigration]) error {
+	sm := ctx.Obj
+	if sm.Status.Phase == "" {
+		sm.Status.Phase = v1alpha1.JobPhasePending
+	}
+	mo, err := getCluster(ctx, sm.Namespace, sm.Spec.ClusterRef)
+	if err != nil {
+		return err
+	}
+	if mo == nil {
+		return c.fail(ctx, "ClusterNotFound", fmt.Sprintf("cluster %s not found", sm.Spec.ClusterRef))
+	}
+	if reason := migrationUnsupported(mo, &sm.Spec.Target); reason != "" {
+		return c.fail(ctx, "Unsupported", reason)
+	}
+	smList := &v1alpha1.StorageMigrationList{}
+	if err := ctx.List(smList, client.InNamespace(sm.Namespace)); err != nil {
+		return errors.WrapPrefix(err, "error list storage migrations", 0)
+	}
+	for _, other := range smList.Items {
+		if other.UID == sm.UID || other.Spec.ClusterRef != sm.Spec.ClusterRef || other.Ended() || other.Status.Phase == "" || other.Status.Phase == v1alpha1.JobPhasePending {
+			continue
+		}
+		return c.fail(ctx, "Conflict", fmt.Sprintf("cluster %s is being migrated by %s", mo.Name, other.Name))
+	}
+	if !recon.IsReady(&mo.Status) {
+		return recon.ErrReSync(fmt.Sprintf("wait cluster %s ready", mo.Name), pollInterval)
+	}
+	sm.Status.Source = mo.Spec.LogService.SharedStorage.DeepCopy()
+	sm.Status.Phase = v1alpha1.StorageMigrationPhasePreCopying
+	meta.SetStatusCondition(&sm.Status.Conditions, metav1.Condition{
+		Type:   v1alpha1.JobConditionTypeEnded,
+		Status: metav1.ConditionFalse,
+		Reason: "Migrating",
+	})
+	return ctx.UpdateStatus(sm)
+}
+
+// preCopy copies the objects while the cluster is serving, so that only the objects written after the
+// pre-copy are copied when the writes are quiesced
+func (c *StorageMigrationActor) preCopy(ctx *recon.Context[*v1alpha1.StorageMigration]) error {
+	sm := ctx.Obj
+	status, err := c.runStage(ctx, migrationStagePreCopy)
+	if err != nil || status == nil {
+		return err
+	}
+	res, err := parseCopyResult(status.Stdout)
+	if err != nil {
+		return c.fail(ctx, "BadOutput", err.Error())
+	}
+	if err := syncChecksummed(ctx, buildChecksummed(sm, status.Stdout)); err != nil {
+		return errors.WrapPrefix(err, "error sync checksummed objects", 0)
+	}
+	sm.Status.PreCopy = res
+	sm.Status.Phase = v1alpha1.StorageMigrationPhaseQuiescing
+	sm.Status.QuiesceTime = &metav1.Time{Time: time.Now()}
+	return ctx.UpdateStatus(sm)
+}
+
+// quiesce drains the CNs and then pauses the TN, the TN flushes the data to the shared storage before exit
+func (c *StorageMigrationActor) quiesce(ctx *recon.Context[*v1alpha1.StorageMigration]) error {
+	sm := ctx.Obj
+	if err := c.deleteStage(ctx, migrationStagePreCopy); err != nil {
+		return err
+	}
+	mo, err := getCluster(ctx, sm.Namespace, sm.Spec.ClusterRef)
+	if err != nil {
+		return err
+	}
+	if mo == nil {
+		return c.fail(ctx, "ClusterNotFound", fmt.Sprintf("cluster %s not found", sm.Spec.ClusterRef))
+	}
+	if mo.Annotations[v1alpha1.ClusterQuiesceAnno] != v1alpha1.QuiesceAll {
+		if err := setQuiesce(ctx, mo, v1alpha1.QuiesceCN); err != nil {
+			return err
+		}
+		if n, err := clusterPods(ctx, mo, "CNSet"); err != nil || n > 0 {
+			return orResync(err, fmt.Sprintf("wait %d CN pods drained", n))
+		}
+		if err := setQuiesce(ctx, mo, v1alpha1.QuiesceAll); err != nil {
+			return err
+		}
+	}
+	if n, err := clusterPods(ctx, mo, "DNSet"); err != nil || n > 0 {
+		return orResync(err, fmt.Sprintf("wait %d TN pods paused", n))
+	}
+	sm.Status.Phase = v1alpha1.StorageMigrationPhaseSyncing
+	return ctx.UpdateStatus(sm)
+}
+
+// sync copies the objects that are changed after the pre-copy
+func (c *StorageMigrationActor) sync(ctx *recon.Context[*v1alpha1.StorageMigration]) error {
+	sm := ctx.Obj
+	status, err := c.runStage(ctx, migrationStageSync)
+	if err != nil || status == nil {
+		return err
+	}
+	res, err := parseCopyResult(status.Stdout)
+	if err != nil {
+		return c.fail(ctx, "BadOutput", err.Error())
+	}
+	sm.Status.FinalSync = res
+	sm.Status.Phase = v1alpha1.StorageMigrationPhaseVerifying
+	return ctx.UpdateStatus(sm)
+}
+
+// verify compares the objects in the target with the source, the cluster is resumed on the source if
+// any of the objects mismatches
+func (c *StorageMigrationActor) verify(ctx *recon.Context[*v1alpha1.StorageMigration]) error {
+	sm := ctx.Obj
+	if err := c.deleteStage(ctx, migrationStageSync); err != nil {
+		return err
+	}
+	status, err := c.runStage(ctx, migrationStageVerify)
+	if err != nil || status == nil {
+		return err
+	}
+	res, err := parseVerification(status.Stdout)
+	if err != nil {
+		return c.fail(ctx, "BadOutput", err.Error())
+	}
+	sm.Status.Verification = res
+	if res.Mismatched > 0 {
+		return c.fail(ctx, "VerificationFailed", fmt.Sprintf("%d objects mismatch in the target, e.g. %s", res.Mismatched, strings.Join(res.MismatchedKeys, ", ")))
+	}
+	// the phase is persisted before the cluster is switched, which allows the shared storage of the cluster to be changed
+	sm.Status.Phase = v1alpha1.StorageMigrationPhaseSwitching
+	return ctx.UpdateStatus(sm)
+}
+
+// switchStorage switches the shared storage of the cluster to the target, resumes the cluster and releases the
+// BucketClaim of the source after the cluster is rolled
+func (c *StorageMigrationActor) switchStorage(ctx *recon.Context[*v1alpha1.StorageMigration]) error {
+	sm := ctx.Obj
+	if err := c.deleteStage(ctx, migrationStageVerify); err != nil {
+		return err
+	}
+	mo, err := getCluster(ctx, sm.Namespace, sm.Spec.ClusterRef)
+	if err != nil {
+		return err
+	}
+	if mo == nil {
+		return c.fail(ctx, "ClusterNotFound", fmt.Sprintf("cluster %s not found", sm.Spec.ClusterRef))
+	}
+	source, err := v1alpha1.ClaimedBucket(ctx.Client, sm.Status.Source.S3)
+	if err != nil {
+		return errors.WrapPrefix(err, "error get bucketclaim of the source", 0)
+	}
+	lsMeta := v1alpha1.LogSetKey(mo)
+	if source != nil && source.Status.BindTo == v1alpha1.BucketBindToMark(lsMeta) {
+		if err := c.claimTarget(ctx, source); err != nil {
+			return err
+		}
+	}
+
+	shared := &mo.Spec.LogService.SharedStorage
+	if shared.S3 == nil || v1alpha1.UniqueBucketLabel(shared.S3) != v1alpha1.UniqueBucketLabel(sm.Spec.Target.S3) || mo.Annotations[v1alpha1.ClusterQuiesceAnno] != "" {
+		*shared = *sm.Spec.Target.DeepCopy()
+		delete(mo.Annotations, v1alpha1.ClusterQuiesceAnno)
+		if err := ctx.Update(mo); err != nil {
+			return errors.WrapPrefix(err, "error switch shared storage of the cluster", 0)
+		}
+		return recon.ErrReSync("wait cluster rolled", pollInterval)
+	}
+	rolled, err := clusterRolled(ctx, mo)
+	if err != nil || !rolled {
+		return orResync(err, fmt.Sprintf("wait cluster %s rolled", mo.Name))
+	}
+
+	if source != nil && source.Status.BindTo == v1alpha1.BucketBindToMark(lsMeta) {
+		// the source is no longer used by the cluster, the finalizers of the sets are removed since they
+		// only care about the bucket that the sets are using
+		source.Status.State = v1alpha1.StatusReleased
+		source.Status.BindTo = ""
+		for _, f := range source.Finalizers {
+			if strings.HasPrefix(f, v1alpha1.BucketDNFinalizerPrefix) || strings.HasPrefix(f, v1alpha1.BucketCNFinalizerPrefix) {
+				controllerutil.RemoveFinalizer(source, f)
+			}
+		}
+		if err := ctx.Update(source); err != nil {
+			return errors.WrapPrefix(err, "error release bucketclaim of the source", 0)
+		}
+		sm.Status.ReleasedBucketClaim = source.Name
+	}
+	sm.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	return c.end(ctx, v1alpha1.JobPhaseCompleted, "MigrationComplete", "")
+}
+
+// claimTarget claims the target for the LogSet of the cluster before the switch, otherwise the LogSet would
+// claim the target with the name of the claim of the source
+func (c *StorageMigrationActor) claimTarget(ctx *recon.Context[*v1alpha1.StorageMigration], source *v1alpha1.BucketClaim) error {
+	sm := ctx.Obj
+	target, err := v1alpha1.ClaimedBucket(ctx.Client, sm.Spec.Target.S3)
+	if err != nil {
+		return errors.WrapPrefix(err, "error get bucketclaim of the target", 0)
+	}
+	if target != nil {
+		if target.Status.BindTo != "" && target.Status.BindTo != source.Status.BindTo {
+			// the cluster is not resumed on the source since the target has been verified, retry until the claim is released
+			return errors.Errorf("bucketclaim %s of the target is bound to %s", target.Name, target.Status.BindTo)
+		}
+		return nil
+	}
+	bucket := &v1alpha1.BucketClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       fmt.Sprintf("bucket-%s", sm.UID),
+			Namespace:  source.Namespace,
+			Finalizers: []string{v1alpha1.BucketDataFinalizer},
+			Labels:     map[string]string{v1alpha1.BucketUniqLabel: v1alpha1.UniqueBucketLabel(sm.Spec.Target.S3)},
+		},
+		Spec: v1alpha1.BucketClaimSpec{
+			S3:             sm.Spec.Target.S3,
+			LogSetTemplate: source.Spec.LogSetTemplate,
+		},
+		Status: v1alpha1.BucketClaimStatus{
+			BindTo: source.Status.BindTo,
+			State:  v1alpha1.StatusInUse,
+		},
+	}
+	return util.Ignore(apierrors.IsAlreadyExists, ctx.Create(bucket))
+}
+
+// runStage starts the job of the stage if not started and polls the command, nil status is returned if the
+// migration is failed
+func (c *StorageMigrationActor) runStage(ctx *recon.Context[*v1alpha1.StorageMigration], stage string) (*cmd.Status, error) {
+	sm := ctx.Obj
+	stageMeta := migrationMeta(sm, stage)
+	job := &batchv1.Job{}
+	err := ctx.Get(client.ObjectKey{Namespace: stageMeta.Namespace, Name: stageMeta.Name}, job)
+	switch {
+	case apierrors.IsNotFound(err):
+		job, err = buildMigrationJob(sm, c.image, stage)
+		if err != nil {
+			return nil, errors.WrapPrefix(err, "error build job", 0)
+		}
+		if err := util.Ignore(apierrors.IsAlreadyExists, ctx.CreateOwned(job)); err != nil {
+			return nil, errors.WrapPrefix(err, "error ensure job", 0)
+		}
+		if err := util.Ignore(apierrors.IsAlreadyExists, ctx.CreateOwned(buildSvcWithMeta(stageMeta))); err != nil {
+			return nil, errors.WrapPrefix(err, "error ensure service", 0)
+		}
+		return nil, recon.ErrReSync(fmt.Sprintf("wait %s complete", stage), pollInterval)
+	case err != nil:
+		return nil, errors.WrapPrefix(err, "error get job", 0)
+	}
+	if job.Status.Failed > 0 {
+		return nil, c.fail(ctx, "JobFailed", fmt.Sprintf("job %s is failed", job.Name))
+	}
+	status, err := cmd.GetCmdStatus(fmt.Sprintf("%s.%s", stageMeta.Name, stageMeta.Namespace), defaultCMDRestPort)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "error get command status", 0)
+	}
+	if !status.Completed {
+		if err := c.reportProgress(ctx, parseProgress(stage, status.Stdout)); err != nil {
+			return nil, err
+		}
+		return nil, recon.ErrReSync(fmt.Sprintf("wait %s complete", stage), pollInterval)
+	}
+	sm.Status.Progress = nil
+	if status.ExitCode != 0 {
+		return nil, c.fail(ctx, "JobFailed", status.Stderr)
+	}
+	return status, nil
+}
+
+// reportProgress updates the progress of the running stage in status if the progress is changed
+func (c *StorageMigrationActor) reportProgress(ctx *recon.Context[*v1alpha1.StorageMigration], progress *v1alpha1.StorageMigrationProgress) error {
+	sm := ctx.Obj
+	if progress == nil {
+		return nil
+	}
+	if last := sm.Status.Progress; last != nil && last.Stage == progress.Stage && last.Step == progress.Step &&
+		last.Done == progress.Done && last.Total == progress.Total {
+		return nil
+	}
+	progress.UpdateTime = &metav1.Time{Time: time.Now()}
+	sm.Status.Progress = progress
+	return ctx.UpdateStatus(sm)
+}
+
+// syncChecksummed creates or updates the ConfigMap of the objects checksummed by the pre-copy, the ConfigMap
+// left by a former pre-copy is outdated
+func syncChecksummed(ctx *recon.Context[*v1alpha1.StorageMigration], desired *corev1.ConfigMap) error {
+	cm := &corev1.ConfigMap{}
+	err := ctx.Get(client.ObjectKeyFromObject(desired), cm)
+	switch {
+	case apierrors.IsNotFound(err):
+		return ctx.CreateOwned(desired)
+	case err != nil:
+		return err
+	}
+	cm.Data = desired.Data
+	return ctx.Update(cm)
+}
+
+// deleteStage deletes the job and the service of the finished stage
+func (c *StorageMigrationActor) deleteStage(ctx *recon.Context[*v1alpha1.StorageMigration], stage string) error {
+	stageMeta := migrationMeta(ctx.Obj, stage)
+	if err := ctx.Delete(&batchv1.Job{ObjectMeta: stageMeta}, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+		return errors.WrapPrefix(err, "error delete job", 0)
+	}
+	if err := ctx.Delete(&corev1.Service{ObjectMeta: stageMeta}); client.IgnoreNotFound(err) != nil {
+		return errors.WrapPrefix(err, "error delete service", 0)
+	}
+	return nil
+}
+
+// fail ends the migration with failure, the cluster resumes on the source
+func (c *StorageMigrationActor) fail(ctx *recon.Context[*v1alpha1.StorageMigration], reason string, msg string) error {
+	if err := c.resume(ctx); err != nil {
+		return err
+	}
+	return c.end(ctx, v1alpha1.JobPhaseFailed, reason, msg)
+}
+
+func (c *StorageMigrationActor) end(ctx *recon.Context[*v1alpha1.StorageMigration], phase string, reason string, msg string) error {
+	sm := ctx.Obj
+	sm.Status.Phase = phase
+	meta.SetStatusCondition(&sm.Status.Conditions, metav1.Condition{
+		Type:    v1alpha1.JobConditionTypeEnded,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: msg,
+	})
+	return ctx.UpdateStatus(sm)
+}
+
+// resume removes the quiesce of the cluster if the cluster is not switched to the target
+func (c *StorageMigrationActor) resume(ctx *recon.Context[*v1alpha1.StorageMigration]) error {
+	sm := ctx.Obj
+	if sm.Status.QuiesceTime == nil || sm.Status.Phase == v1alpha1.StorageMigrationPhaseSwitching {
+		return nil
+	}
+	mo, err := getCluster(ctx, sm.Namespace, sm.Spec.ClusterRef)
+	if err != nil || mo == nil {
+		return err
+	}
+	if _, ok := mo.Annotations[v1alpha1.ClusterQuiesceAnno]; !ok {
+		return nil
+	}
+	delete(mo.Annotations, v1alpha1.ClusterQuiesceAnno)
+	if err := ctx.Update(mo); err != nil {
+		return errors.WrapPrefix(err, "error resume cluster", 0)
+	}
+	return nil
+}
+
+func (c *StorageMigrationActor) Finalize(ctx *recon.Context[*v1alpha1.StorageMigration]) (bool, error) {
+	// the jobs are owned by the migration, while the cluster should be resumed if the migration is aborted
+	if ctx.Obj.Ended() {
+		return true, nil
+	}
+	return true, c.resume(ctx)
+}
+
+func (c *StorageMigrationActor) Reconcile(mgr manager.Manager) error {
+	return recon.Setup[*v1alpha1.StorageMigration](&v1alpha1.StorageMigration{}, "storagemigration", mgr, c, recon.WithBuildFn(func(b *builder.Builder) {
+		b.Owns(&batchv1.Job{})
+	}))
+}
+
+func setQuiesce(ctx *recon.Context[*v1alpha1.StorageMigration], mo *v1alpha1.MatrixOneCluster, value string) error {
+	if mo.Annotations[v1alpha1.ClusterQuiesceAnno] == value {
+		return nil
+	}
+	if mo.Annotations == nil {
+		mo.Annotations = map[string]string{}
+	}
+	mo.Annotations[v1alpha1.ClusterQuiesceAnno] = value
+	if err := ctx.Update(mo); err != nil {
+		return errors.WrapPrefix(err, "error quiesce cluster", 0)
+	}
+	return nil
+}
+
+// clusterPods returns the number of the pods of the component in the cluster
+func clusterPods(cli recon.KubeClient, mo *v1alpha1.MatrixOneCluster, component string) (int, error) {
+	pods, err := listClusterPods(cli, mo, component)
+	return len(pods), err
+}
+
+func listClusterPods(cli recon.KubeClient, mo *v1alpha1.MatrixOneCluster, component string) ([]corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := cli.List(podList, client.InNamespace(mo.Namespace), client.MatchingLabels{
+		common.MatrixoneClusterLabelKey: mo.Name,
+		common.ComponentLabelKey:        component,
+	}); err != nil {
+		return nil, errors.WrapPrefix(err, "error list pods", 0)
+	}
+	return podList.Items, nil
+}
+
+// clusterRolled returns whether the cluster has observed the switch and the CNs and the TN are up again
+func clusterRolled(cli recon.KubeClient, mo *v1alpha1.MatrixOneCluster) (bool, error) {
+	ready := meta.FindStatusCondition(mo.Status.Conditions, recon.ConditionTypeReady)
+	if ready == nil || ready.Status != metav1.ConditionTrue || ready.ObservedGeneration != mo.Generation || !recon.IsSynced(&mo.Status) {
+		return false, nil
+	}
+	// the status of the sets might not reflect the resume yet, so the pods are checked as well
+	replicas := map[string]int32{"DNSet": mo.GetTN().Replicas}
+	for _, g := range mo.Spec.CNGroups {
+		replicas["CNSet"] += g.Replicas
+	}
+	if mo.Spec.TP != nil {
+		replicas["CNSet"] += mo.Spec.TP.Replicas
+	}
+	if mo.Spec.AP != nil {
+		replicas["CNSet"] += mo.Spec.AP.Replicas
+	}
+	for component, n := range replicas {
+		pods, err := listClusterPods(cli, mo, component)
+		if err != nil {
+			return false, err
+		}
+		var readyPods int32
+		for _, pod := range pods {
+			if pod.DeletionTimestamp == nil && util.IsPodReady(&pod) {
+				readyPods++
+			}
+		}
+		if readyPods < n {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// orResync returns the error if not nil, or requests another reconciliation with the message
+func orResync(err error, msg string) error {
+	if err != nil {
+		return err
+	}
+	return recon.ErrReSync(msg, pollInterval)
+}
@@ -0,0 +1,266 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/controller-runtime/pkg/util"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	"github.com/matrixorigin/matrixone-operator/pkg/mosql"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// VerificationActor verifies a backup by restoring it to a temporary cluster and running SQL assertions
+type VerificationActor struct{}
+
+var _ recon.Actor[*v1alpha1.BackupVerification] = &VerificationActor{}
+
+func (v *VerificationActor) Observe(ctx *recon.Context[*v1alpha1.BackupVerification]) (recon.Action[*v1alpha1.BackupVerification], error) {
+	switch ctx.Obj.Status.Phase {
+	case v1alpha1.JobPhaseCompleted, v1alpha1.JobPhaseFailed:
+		// ensure the temporary cluster is torn down
+		return nil, v.deleteCluster(ctx)
+	case v1alpha1.JobPhaseRunning:
+		return v.verify, nil
+	default:
+		return v.createCluster, nil
+	}
+}
+
+func (v *VerificationActor) createCluster(ctx *recon.Context[*v1alpha1.BackupVerification]) error {
+	bv := ctx.Obj
+	backup := &v1alpha1.Backup{}
+	if err := ctx.Get(types.NamespacedName{Name: bv.Spec.BackupName}, backup); err != nil {
+		if apierrors.IsNotFound(err) {
+			return v.finish(ctx, false, "BackupNotFound", fmt.Sprintf("backup %s not found", bv.Spec.BackupName))
+		}
+		return errors.WrapPrefix(err, "error get backup", 0)
+	}
+	if backup.DeletionTimestamp != nil {
+		return v.finish(ctx, false, "BackupDeleting", fmt.Sprintf("backup %s is being deleted", backup.Name))
+	}
+	mo := v.buildCluster(bv, backup)
+	if err := ctx.Create(mo); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return errors.WrapPrefix(err, "error create temporary cluster", 0)
+		}
+		existing := &v1alpha1.MatrixOneCluster{}
+		if err := ctx.Get(client.ObjectKeyFromObject(mo), existing); err != nil {
+			return errors.WrapPrefix(err, "error get temporary cluster", 0)
+		}
+		if !createdFor(existing, bv) {
+			return v.finish(ctx, false, "ClusterConflict", fmt.Sprintf("cluster %s/%s is not created by this verification", mo.Namespace, mo.Name))
+		}
+	}
+	ref := fmt.Sprintf("%s/%s", mo.Namespace, mo.Name)
+	ctx.Log.Info("temporary cluster created to verify backup", "cluster", ref, "backup", backup.Name)
+	bv.Status.Phase = v1alpha1.JobPhaseRunning
+	bv.Status.ClusterRef = ref
+	bv.Status.StartTime = &metav1.Time{Time: time.Now()}
+	return ctx.UpdateStatus(bv)
+}
+
+func (v *VerificationActor) buildCluster(bv *v1alpha1.BackupVerification, backup *v1alpha1.Backup) *v1alpha1.MatrixOneCluster {
+	mo := &v1alpha1.MatrixOneCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: bv.Spec.Namespace,
+			Name:      verificationClusterName(bv),
+			Labels: map[string]string{
+				common.BackupVerificationLabelKey:          bv.Name,
+				common.BackupVerificationNamespaceLabelKey: bv.Namespace,
+			},
+		},
+		Spec: *bv.Spec.ClusterTemplate.DeepCopy(),
+	}
+	mo.Spec.RestoreFrom = &backup.Name
+	// restore to a clean location and delete the data along with the cluster
+	suffix := fmt.Sprintf("%s-%s", bv.Namespace, bv.Name)
+	sp := &mo.Spec.LogService.SharedStorage
+	if sp.S3 != nil {
+		sp.S3.Path = path.Join(sp.S3.Path, suffix)
+		if sp.S3.S3RetentionPolicy == nil {
+			policy := v1alpha1.PVCRetentionPolicyDelete
+			sp.S3.S3RetentionPolicy = &policy
+		}
+	}
+	if sp.FileSystem != nil {
+		sp.FileSystem.Path = path.Join(sp.FileSystem.Path, suffix)
+	}
+	return mo
+}
+
+func (v *VerificationActor) verify(ctx *recon.Context[*v1alpha1.BackupVerification]) error {
+	bv := ctx.Obj
+	mo := &v1alpha1.MatrixOneCluster{}
+	if err := ctx.Get(types.NamespacedName{Namespace: bv.Spec.Namespace, Name: verificationClusterName(bv)}, mo); err != nil {
+		if apierrors.IsNotFound(err) {
+			return v.finish(ctx, false, "ClusterDeleted", "temporary cluster is deleted externally")
+		}
+		return errors.WrapPrefix(err, "error get temporary cluster", 0)
+	}
+	if !createdFor(mo, bv) {
+		return v.finish(ctx, false, "ClusterConflict", fmt.Sprintf("cluster %s/%s is not created by this verification", mo.Namespace, mo.Name))
+	}
+	if cond, ok := recon.GetCondition(mo, recon.ConditionTypeReady); ok && cond.Reason == "RestoreFailed" {
+		return v.finish(ctx, false, "RestoreFailed", "failed to restore the backup to the temporary cluster")
+	}
+	if !recon.IsReady(&mo.Status) || mo.Status.Host == "" || mo.Status.CredentialRef == nil {
+		if bv.Status.StartTime != nil && time.Since(bv.Status.StartTime.Time) > bv.GetTimeout() {
+			return v.finish(ctx, false, "Timeout", fmt.Sprintf("temporary cluster is not ready in %s", bv.GetTimeout()))
+		}
+		return recon.ErrReSync("wait temporary cluster ready", pollInterval)
+	}
+	sqlcli := mosql.NewClient(fmt.Sprintf("%s:%d", mo.Status.Host, mo.Status.Port), ctx.Client, types.NamespacedName{Namespace: mo.Namespace, Name: mo.Status.CredentialRef.Name})
+	defer sqlcli.Close()
+	bv.Status.Results = runAssertions(ctx, sqlcli, bv.Spec.Assertions)
+	failed := 0
+	for _, r := range bv.Status.Results {
+		if !r.Passed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return v.finish(ctx, false, "AssertionFailed", fmt.Sprintf("%d of %d assertions failed", failed, len(bv.Status.Results)))
+	}
+	return v.finish(ctx, true, "Passed", fmt.Sprintf("backup is restored and %d assertions passed", len(bv.Status.Results)))
+}
+
+func runAssertions(ctx *recon.Context[*v1alpha1.BackupVerification], sqlcli mosql.Client, assertions []v1alpha1.SQLAssertion) []v1alpha1.SQLAssertionResult {
+	var results []v1alpha1.SQLAssertionResult
+	for _, a := range assertions {
+		r := v1alpha1.SQLAssertionResult{Name: a.Name}
+		actual, err := sqlcli.QueryValue(ctx, a.Query)
+		if err != nil {
+			r.Message = err.Error()
+		} else {
+			r.Actual = actual
+			r.Passed = actual == a.Expected
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// finish records the result of the verification on the verification and the backup, and tears
+// the temporary cluster down
+func (v *VerificationActor) finish(ctx *recon.Context[*v1alpha1.BackupVerification], passed bool, reason string, msg string) error {
+	bv := ctx.Obj
+	now := metav1.Now()
+	backup := &v1alpha1.Backup{}
+	err := ctx.Get(types.NamespacedName{Name: bv.Spec.BackupName}, backup)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.WrapPrefix(err, "error get backup", 0)
+	}
+	if err == nil {
+		status := metav1.ConditionFalse
+		if passed {
+			status = metav1.ConditionTrue
+		}
+		backup.SetCondition(metav1.Condition{
+			Type:    v1alpha1.BackupConditionTypeVerified,
+			Status:  status,
+			Reason:  reason,
+			Message: fmt.Sprintf("verified by %s/%s: %s", bv.Namespace, bv.Name, msg),
+		})
+		backup.Status.LastVerification = &v1alpha1.BackupVerificationRecord{
+			Verification:   fmt.Sprintf("%s/%s", bv.Namespace, bv.Name),
+			Passed:         passed,
+			CompletionTime: now,
+		}
+		if err := ctx.UpdateStatus(backup); err != nil {
+			return errors.WrapPrefix(err, "error record verification result on backup", 0)
+		}
+	}
+	if err := v.deleteCluster(ctx); err != nil {
+		return err
+	}
+	bv.Status.Phase = v1alpha1.JobPhaseFailed
+	if passed {
+		bv.Status.Phase = v1alpha1.JobPhaseCompleted
+	}
+	bv.Status.CompletionTime = &now
+	bv.SetCondition(metav1.Condition{
+		Type:    v1alpha1.JobConditionTypeEnded,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: msg,
+	})
+	return ctx.UpdateStatus(bv)
+}
+
+// deleteCluster deletes the temporary cluster, a cluster not created by the verification is left untouched
+func (v *VerificationActor) deleteCluster(ctx *recon.Context[*v1alpha1.BackupVerification]) error {
+	bv := ctx.Obj
+	mo := &v1alpha1.MatrixOneCluster{}
+	if err := ctx.Get(types.NamespacedName{Namespace: bv.Spec.Namespace, Name: verificationClusterName(bv)}, mo); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.WrapPrefix(err, "error get temporary cluster", 0)
+	}
+	if !createdFor(mo, bv) || mo.DeletionTimestamp != nil {
+		return nil
+	}
+	if err := util.Ignore(apierrors.IsNotFound, ctx.Delete(mo)); err != nil {
+		return errors.WrapPrefix(err, "error delete temporary cluster", 0)
+	}
+	return nil
+}
+
+func (v *VerificationActor) Finalize(ctx *recon.Context[*v1alpha1.BackupVerification]) (bool, error) {
+	bv := ctx.Obj
+	mo := &v1alpha1.MatrixOneCluster{}
+	err := ctx.Get(types.NamespacedName{Namespace: bv.Spec.Namespace, Name: verificationClusterName(bv)}, mo)
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, errors.WrapPrefix(err, "error get temporary cluster", 0)
+	}
+	if !createdFor(mo, bv) {
+		return true, nil
+	}
+	if err := v.deleteCluster(ctx); err != nil {
+		return false, err
+	}
+	// wait the cluster to be deleted
+	return false, nil
+}
+
+// verificationClusterName returns the name of the temporary cluster, the namespace of the verification
+// is included since the clusters of the verifications in different namespaces might share a namespace
+func verificationClusterName(bv *v1alpha1.BackupVerification) string {
+	return fmt.Sprintf("verify-%s-%s", bv.Namespace, bv.Name)
+}
+
+// createdFor returns whether the cluster is the temporary cluster created for the verification
+func createdFor(mo *v1alpha1.MatrixOneCluster, bv *v1alpha1.BackupVerification) bool {
+	return mo.Labels[common.BackupVerificationLabelKey] == bv.Name && mo.Labels[common.BackupVerificationNamespaceLabelKey] == bv.Namespace
+}
+
+func (v *VerificationActor) Reconcile(mgr manager.Manager) error {
+	// the temporary cluster might be in another namespace thus cannot be owned, the cluster is polled instead
+	return recon.Setup[*v1alpha1.BackupVerification](&v1alpha1.BackupVerification{}, "backupverification", mgr, v)
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package br
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/matrixorigin/controller-runtime/pkg/fake"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/mosql"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type valueClient struct {
+	mosql.Client
+	values map[string]string
+}
+
+func (c *valueClient) Close() error {
+	return nil
+}
+
+func (c *valueClient) QueryValue(_ context.Context, query string, _ ...any) (string, error) {
+	v, ok := c.values[query]
+	if !ok {
+		return "", sql.ErrNoRows
+	}
+	return v, nil
+}
+
+func TestVerificationActor(t *testing.T) {
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(v1alpha1.AddToScheme(s))
+	newClient := mosql.NewClient
+	defer func() { mosql.NewClient = newClient }()
+	mosql.NewClient = func(_ string, _ client.Client, _ types.NamespacedName) mosql.Client {
+		return &valueClient{values: map[string]string{
+			"SELECT COUNT(*) FROM db.t": "100",
+		}}
+	}
+
+	tests := []struct {
+		name      string
+		expected  string
+		wantPhase string
+	}{{
+		name:      "passed",
+		expected:  "100",
+		wantPhase: v1alpha1.JobPhaseCompleted,
+	}, {
+		name:      "assertion failed",
+		expected:  "99",
+		wantPhase: v1alpha1.JobPhaseFailed,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			backup := &v1alpha1.Backup{ObjectMeta: metav1.ObjectMeta{Name: "daily-abcde"}}
+			bv := &v1alpha1.BackupVerification{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "audit"},
+				Spec: v1alpha1.BackupVerificationSpec{
+					BackupName: backup.Name,
+					Namespace:  "verify",
+					ClusterTemplate: v1alpha1.MatrixOneClusterSpec{
+						LogService: v1alpha1.LogSetSpec{
+							SharedStorage: v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/verify"}},
+						},
+					},
+					Assertions: []v1alpha1.SQLAssertion{{
+						Name:     "rows",
+						Query:    "SELECT COUNT(*) FROM db.t",
+						Expected: tt.expected,
+					}},
+				},
+			}
+			cli := &fake.Client{Client: fake.KubeClientBuilder().WithScheme(s).WithObjects(backup, bv).WithStatusSubresource(backup, bv).Build()}
+			ctx := fake.NewContext(bv, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+			actor := &VerificationActor{}
+
+			// the backup is restored to a temporary cluster in a clean location
+			g.Expect(actor.createCluster(ctx)).To(Succeed())
+			g.Expect(bv.Status.Phase).To(Equal(v1alpha1.JobPhaseRunning))
+			mo := &v1alpha1.MatrixOneCluster{}
+			key := types.NamespacedName{Namespace: "verify", Name: "verify-default-audit"}
+			g.Expect(ctx.Get(key, mo)).To(Succeed())
+			g.Expect(*mo.Spec.RestoreFrom).To(Equal(backup.Name))
+			g.Expect(mo.Spec.LogService.SharedStorage.S3.Path).To(Equal("bucket/verify/default-audit"))
+
+			// wait the cluster to be ready
+			err := actor.verify(ctx)
+			g.Expect(err).To(BeAssignableToTypeOf(&recon.ReSync{}))
+			mo.Status = v1alpha1.MatrixOneClusterStatus{
+				ConditionalStatus: v1alpha1.ConditionalStatus{Conditions: []metav1.Condition{{
+					Type:   recon.ConditionTypeReady,
+					Status: metav1.ConditionTrue,
+				}}},
+				CredentialRef: &corev1.LocalObjectReference{Name: "verify-audit-credential"},
+				Host:          "verify-audit-tp-cn",
+				Port:          6001,
+			}
+			g.Expect(ctx.Update(mo)).To(Succeed())
+
+			// the result is recorded and the cluster is torn down
+			g.Expect(actor.verify(ctx)).To(Succeed())
+			g.Expect(bv.Status.Phase).To(Equal(tt.wantPhase))
+			g.Expect(bv.Status.Results).To(HaveLen(1))
+			g.Expect(bv.Status.Results[0].Actual).To(Equal("100"))
+			g.Expect(ctx.Get(types.NamespacedName{Name: backup.Name}, backup)).To(Succeed())
+			g.Expect(backup.Status.LastVerification.Verification).To(Equal("default/audit"))
+			g.Expect(backup.Status.LastVerification.Passed).To(Equal(tt.wantPhase == v1alpha1.JobPhaseCompleted))
+			g.Expect(apierrors.IsNotFound(ctx.Get(key, mo))).To(BeTrue())
+
+			// a cluster of the same name that is not created by the verification is not adopted
+			other := &v1alpha1.MatrixOneCluster{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}}
+			g.Expect(ctx.Create(other)).To(Succeed())
+			bv.Status = v1alpha1.BackupVerificationStatus{}
+			g.Expect(actor.createCluster(ctx)).To(Succeed())
+			g.Expect(bv.Status.Phase).To(Equal(v1alpha1.JobPhaseFailed))
+			g.Expect(ctx.Get(key, other)).To(Succeed())
+		})
+	}
+}
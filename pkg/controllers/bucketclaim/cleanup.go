@@ -0,0 +1,153 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bucketclaim
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	previewSummaryKey = "summary"
+	previewObjectsKey = "objects"
+)
+
+// unsafeCleanupReason returns a non-empty reason if deleting the data under the claimed path is unsafe
+func unsafeCleanupReason(ctx *recon.Context[*v1alpha1.BucketClaim]) (string, error) {
+	bucket := ctx.Obj
+	path := cleanupPath(bucket.Spec.S3)
+	if !strings.Contains(path, "/") {
+		return fmt.Sprintf("path %q is the root of a bucket, refuse to delete the whole bucket", bucket.Spec.S3.Path), nil
+	}
+
+	bcList := &v1alpha1.BucketClaimList{}
+	if err := ctx.List(bcList); err != nil {
+		return "", errors.WrapPrefix(err, "error list bucketclaims", 0)
+	}
+	for _, other := range bcList.Items {
+		if other.UID == bucket.UID || other.Status.State != v1alpha1.StatusInUse || other.Spec.S3 == nil {
+			continue
+		}
+		if other.Spec.S3.GetEndpoint() != bucket.Spec.S3.GetEndpoint() {
+			continue
+		}
+		otherPath := cleanupPath(other.Spec.S3)
+		// 'aws s3 rm --recursive' deletes all keys that start with the path, so the paths are overlapped
+		// as long as one is the string prefix of the other
+		if strings.HasPrefix(otherPath, path) || strings.HasPrefix(path, otherPath) {
+			return fmt.Sprintf("path %q is shared with bucketclaim %v in use", bucket.Spec.S3.Path, client.ObjectKeyFromObject(&other)), nil
+		}
+	}
+	return "", nil
+}
+
+func cleanupPath(s3 *v1alpha1.S3Provider) string {
+	return strings.Trim(s3.Path, "/")
+}
+
+// previewCleanup lists the objects that would be deleted into a configmap instead of deleting them
+func (bca *Actor) previewCleanup(ctx *recon.Context[*v1alpha1.BucketClaim]) error {
+	bucket := ctx.Obj
+	if bucket.Status.CleanupPreview != "" {
+		// preview is done, wait for the dry-run to be turned off
+		return nil
+	}
+	job := &batchv1.Job{}
+	err := ctx.Get(client.ObjectKey{Namespace: bucket.Namespace, Name: previewJobName(bucket)}, job)
+	switch {
+	case apierrors.IsNotFound(err):
+		cm, err := bca.syncScripts(ctx)
+		if err != nil {
+			return err
+		}
+		return ctx.CreateOwned(bca.newScriptJob(bucket, cm, previewJobName(bucket), listScript))
+	case err != nil:
+		return errors.WrapPrefix(err, "error get preview job", 0)
+	}
+
+	if isJobFailure(job) {
+		return setFailCondition(ctx, "PreviewJobFailure", fmt.Sprintf("s3 preview job failure: %v", client.ObjectKeyFromObject(job)))
+	}
+	if !isJobSuccess(job) {
+		return nil
+	}
+	res, err := bca.readListResult(ctx, job)
+	if err != nil {
+		return err
+	}
+	summary := fmt.Sprintf("path: %s\nobjects: %d\nbytes: %d\n", bucket.Spec.S3.Path, res.objects, res.bytes)
+	if res.listed < res.objects {
+		// the termination message that carries the listing is limited to 4KB
+		summary += fmt.Sprintf("truncated: only the first %d objects are listed\n", res.listed)
+	}
+	preview := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      previewCmName(bucket),
+			Namespace: bucket.Namespace,
+		},
+		Data: map[string]string{
+			previewSummaryKey: summary,
+			previewObjectsKey: res.listing,
+		},
+	}
+	if err := syncPreview(ctx, preview); err != nil {
+		return errors.WrapPrefix(err, "error sync cleanup preview", 0)
+	}
+	bucket.Status.Usage = res.usage()
+	bucket.Status.CleanupPreview = preview.Name
+	dryRunCondition := newFailCondition("DryRun", fmt.Sprintf("objects to be deleted are listed in configmap %s, unset spec.dryRunCleanup to delete them", preview.Name))
+	bucket.Status.ConditionalStatus.Conditions = []metav1.Condition{*dryRunCondition}
+	if err := ctx.Update(bucket); err != nil {
+		return err
+	}
+	err = ctx.Delete(job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	return client.IgnoreNotFound(err)
+}
+
+func syncPreview(ctx *recon.Context[*v1alpha1.BucketClaim], preview *corev1.ConfigMap) error {
+	existing := &corev1.ConfigMap{}
+	err := ctx.Get(client.ObjectKeyFromObject(preview), existing)
+	switch {
+	case apierrors.IsNotFound(err):
+		return ctx.CreateOwned(preview)
+	case err != nil:
+		return err
+	}
+	// the preview left by a former dry-run is outdated
+	existing.Data = preview.Data
+	return ctx.Update(existing)
+}
+
+// setFailCondition sets the fail condition, the update is skipped if the condition is not changed to avoid
+// updating the bucketclaim on each retry
+func setFailCondition(ctx *recon.Context[*v1alpha1.BucketClaim], reason, message string) error {
+	bucket := ctx.Obj
+	for _, c := range bucket.Status.ConditionalStatus.Conditions {
+		if c.Reason == reason && c.Message == message {
+			return nil
+		}
+	}
+	bucket.Status.ConditionalStatus.Conditions = []metav1.Condition{*newFailCondition(reason, message)}
+	return ctx.Update(bucket)
+}
@@ -17,6 +17,7 @@ package bucketclaim
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
 	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
@@ -39,11 +40,14 @@ type Actor struct {
 
 	// image related config
 	image string
+	// usageInterval is the interval of reporting the usage of in-use buckets
+	usageInterval time.Duration
 }
 
 func New(options ...Option) *Actor {
 	a := &Actor{
-		image: defaultImage,
+		image:         defaultImage,
+		usageInterval: defaultUsageInterval,
 	}
 	for _, opt := range options {
 		opt(a)
@@ -58,7 +62,10 @@ type task struct {
 
 func (bca *Actor) Observe(ctx *recon.Context[*v1alpha1.BucketClaim]) (recon.Action[*v1alpha1.BucketClaim], error) {
 	ctx.Log.Info(fmt.Sprintf("observe bucketclaim %v", client.ObjectKeyFromObject(ctx.Obj)))
-	return nil, nil
+	if ctx.Obj.Status.State != v1alpha1.StatusInUse || bca.usageInterval <= 0 {
+		return nil, nil
+	}
+	return nil, bca.syncUsage(ctx)
 }
 
 func (bca *Actor) Finalize(ctx *recon.Context[*v1alpha1.BucketClaim]) (bool, error) {
@@ -106,6 +113,17 @@ func (bca *Actor) Finalize(ctx *recon.Context[*v1alpha1.BucketClaim]) (bool, err
 	err := ctx.Get(client.ObjectKeyFromObject(job), job)
 	switch {
 	case apierrors.IsNotFound(err):
+		// check before starting the job, a started job is never interrupted
+		reason, err := unsafeCleanupReason(ctx)
+		if err != nil {
+			return false, err
+		}
+		if reason != "" {
+			return false, setFailCondition(ctx, "UnsafeCleanup", reason)
+		}
+		if bucket.Spec.DryRunCleanup {
+			return false, bca.previewCleanup(ctx)
+		}
 		return false, bca.createNewJob(ctx)
 	case err != nil:
 		return false, err
@@ -132,24 +150,8 @@ func (bca *Actor) createNewJob(ctx *recon.Context[*v1alpha1.BucketClaim]) error
 	bucket := ctx.Obj
 
 	// create configmap before creating job, which includes the entrypoint of job container
-	cm := &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      cmName(bucket),
-			Namespace: bucket.Namespace,
-		},
-	}
-	err := ctx.Get(client.ObjectKeyFromObject(cm), cm)
-	switch {
-	case apierrors.IsNotFound(err):
-		// create configmap if not exist, then try to create job
-		cm, err = bca.NewCmTpl(bucket)
-		if err != nil {
-			return err
-		}
-		if err = ctx.CreateOwned(cm); err != nil {
-			return err
-		}
-	case err != nil:
+	cm, err := bca.syncScripts(ctx)
+	if err != nil {
 		return err
 	}
 
@@ -19,8 +19,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-errors/errors"
 	"github.com/golang/mock/gomock"
 	"github.com/matrixorigin/controller-runtime/pkg/fake"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
 	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -119,3 +121,245 @@ fi
 	g.Expect(err).To(Succeed())
 	g.Expect(endpoint).To(Equal(expect))
 }
+
+func TestParseEndpointOSS(t *testing.T) {
+	oss := v1alpha1.S3ProviderTypeOSS
+	s3 := v1alpha1.S3Provider{
+		Path:   "mo/test",
+		Type:   &oss,
+		Region: "cn-hangzhou",
+	}
+	endpoint, err := parseEntrypoint(&s3)
+	g := NewGomegaWithT(t)
+	g.Expect(err).To(Succeed())
+	g.Expect(endpoint).To(ContainSubstring("endpoint=https://oss-cn-hangzhou.aliyuncs.com\n"))
+	g.Expect(endpoint).To(ContainSubstring("aws configure set default.s3.addressing_style virtual\n\nif"))
+}
+
+func TestActor_Finalize_RefuseUnsafeCleanup(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		others []v1alpha1.BucketClaim
+	}{{
+		name: "bucket root",
+		path: "mo-bucket/",
+	}, {
+		name: "shared with in-use claim",
+		path: "mo-bucket/test",
+		others: []v1alpha1.BucketClaim{{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other", UID: "other"},
+			Spec:       v1alpha1.BucketClaimSpec{S3: &v1alpha1.S3Provider{Path: "mo-bucket/test/cluster"}},
+			Status:     v1alpha1.BucketClaimStatus{State: v1alpha1.StatusInUse},
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			bucket := deletingBucket(tt.path)
+			objs := []client.Object{bucket}
+			for i := range tt.others {
+				objs = append(objs, &tt.others[i])
+			}
+			cli := newFakeClient(objs...)
+			ctx := fake.NewContext(bucket, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+
+			ok, err := New().Finalize(ctx)
+			g.Expect(err).To(Succeed())
+			g.Expect(ok).To(BeFalse())
+			g.Expect(bucket.Status.Conditions).To(HaveLen(1))
+			g.Expect(bucket.Status.Conditions[0].Reason).To(Equal("UnsafeCleanup"))
+
+			jobs := &batchv1.JobList{}
+			g.Expect(cli.List(context.TODO(), jobs, client.InNamespace(bucket.Namespace))).To(Succeed())
+			g.Expect(jobs.Items).To(BeEmpty())
+		})
+	}
+}
+
+func TestActor_Finalize_DryRunCleanup(t *testing.T) {
+	g := NewGomegaWithT(t)
+	bucket := deletingBucket("mo-bucket/test")
+	bucket.Spec.DryRunCleanup = true
+	// a released claim with an overlapped path does not block the cleanup
+	released := &v1alpha1.BucketClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "released", Namespace: "default", UID: "released"},
+		Spec:       v1alpha1.BucketClaimSpec{S3: &v1alpha1.S3Provider{Path: "mo-bucket/test"}},
+		Status:     v1alpha1.BucketClaimStatus{State: v1alpha1.StatusReleased},
+	}
+	cli := newFakeClient(bucket, released)
+	ctx := fake.NewContext(bucket, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+	actor := New()
+
+	ok, err := actor.Finalize(ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(ok).To(BeFalse())
+	job := &batchv1.Job{}
+	g.Expect(cli.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: previewJobName(bucket)}, job)).To(Succeed())
+	g.Expect(job.Spec.Template.Spec.Containers[0].Command).To(Equal([]string{"/bin/sh", "/etc/aws-cli-config/list.sh"}))
+	g.Expect(cli.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: jobName(bucket)}, &batchv1.Job{})).ToNot(Succeed())
+
+	completeJob(g, cli, job, "\nTotal Objects: 2\n   Total Size: 30\n2025-01-01 00:00:00         10 test/data/a\n2025-01-01 00:00:00         20 test/etl/b\n")
+	ok, err = actor.Finalize(ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(ok).To(BeFalse())
+	g.Expect(bucket.Status.CleanupPreview).To(Equal(previewCmName(bucket)))
+	g.Expect(bucket.Status.Usage.Objects).To(Equal(int64(2)))
+	g.Expect(bucket.Status.Usage.Bytes).To(Equal(int64(30)))
+	preview := &corev1.ConfigMap{}
+	g.Expect(cli.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: previewCmName(bucket)}, preview)).To(Succeed())
+	g.Expect(preview.Data[previewSummaryKey]).To(Equal("path: mo-bucket/test\nobjects: 2\nbytes: 30\n"))
+	g.Expect(preview.Data[previewObjectsKey]).To(Equal("2025-01-01 00:00:00         10 test/data/a\n2025-01-01 00:00:00         20 test/etl/b"))
+
+	// the data is kept until the dry-run is turned off
+	ok, err = actor.Finalize(ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(ok).To(BeFalse())
+	g.Expect(cli.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: jobName(bucket)}, &batchv1.Job{})).ToNot(Succeed())
+
+	bucket.Spec.DryRunCleanup = false
+	ok, err = actor.Finalize(ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(ok).To(BeFalse())
+	g.Expect(cli.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: jobName(bucket)}, &batchv1.Job{})).To(Succeed())
+}
+
+func TestActor_Observe_ReportUsage(t *testing.T) {
+	g := NewGomegaWithT(t)
+	bucket := &v1alpha1.BucketClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-bucket", Namespace: "default", UID: "test-bucket"},
+		Spec:       v1alpha1.BucketClaimSpec{S3: &v1alpha1.S3Provider{Path: "mo-bucket/test"}, LogSetTemplate: logSetTemplate()},
+		Status:     v1alpha1.BucketClaimStatus{State: v1alpha1.StatusInUse},
+	}
+	cli := newFakeClient(bucket)
+	ctx := fake.NewContext(bucket, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+	actor := New()
+
+	_, err := actor.Observe(ctx)
+	g.Expect(err).To(Succeed())
+	job := &batchv1.Job{}
+	g.Expect(cli.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: usageJobName(bucket)}, job)).To(Succeed())
+
+	completeJob(g, cli, job, "\nTotal Objects: 0\n   Total Size: 0\n")
+	_, err = actor.Observe(ctx)
+	var resync *recon.ReSync
+	g.Expect(errors.As(err, &resync)).To(BeTrue())
+	g.Expect(resync.RequeueAfter).To(Equal(defaultUsageInterval))
+	g.Expect(bucket.Status.Usage).ToNot(BeNil())
+	g.Expect(bucket.Status.Usage.Objects).To(Equal(int64(0)))
+	g.Expect(cli.Get(context.TODO(), client.ObjectKeyFromObject(job), &batchv1.Job{})).ToNot(Succeed())
+
+	// the usage is fresh, wait for the next report
+	_, err = actor.Observe(ctx)
+	g.Expect(errors.As(err, &resync)).To(BeTrue())
+	g.Expect(cli.Get(context.TODO(), client.ObjectKeyFromObject(job), &batchv1.Job{})).ToNot(Succeed())
+}
+
+func TestActor_Observe_UsageFailureBackoff(t *testing.T) {
+	g := NewGomegaWithT(t)
+	bucket := &v1alpha1.BucketClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-bucket", Namespace: "default", UID: "test-bucket"},
+		Spec:       v1alpha1.BucketClaimSpec{S3: &v1alpha1.S3Provider{Path: "mo-bucket/test"}, LogSetTemplate: logSetTemplate()},
+		Status:     v1alpha1.BucketClaimStatus{State: v1alpha1.StatusInUse},
+	}
+	cli := newFakeClient(bucket)
+	ctx := fake.NewContext(bucket, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+	actor := New()
+
+	_, err := actor.Observe(ctx)
+	g.Expect(err).To(Succeed())
+	job := &batchv1.Job{}
+	g.Expect(cli.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: usageJobName(bucket)}, job)).To(Succeed())
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+	g.Expect(cli.Status().Update(context.TODO(), job)).To(Succeed())
+	_, err = actor.Observe(ctx)
+	var resync *recon.ReSync
+	g.Expect(errors.As(err, &resync)).To(BeTrue())
+	g.Expect(bucket.Status.Usage).To(BeNil())
+	g.Expect(bucket.Status.UsageAttemptTime).ToNot(BeNil())
+	g.Expect(cli.Get(context.TODO(), client.ObjectKeyFromObject(job), &batchv1.Job{})).ToNot(Succeed())
+
+	// the failed report is retried after the usage interval
+	_, err = actor.Observe(ctx)
+	g.Expect(errors.As(err, &resync)).To(BeTrue())
+	g.Expect(cli.Get(context.TODO(), client.ObjectKeyFromObject(job), &batchv1.Job{})).ToNot(Succeed())
+}
+
+func TestParseListResult(t *testing.T) {
+	g := NewGomegaWithT(t)
+	_, err := parseListResult("An error occurred (AccessDenied)")
+	g.Expect(err).ToNot(Succeed())
+
+	res, err := parseListResult("\nTotal Objects: 1\n   Total Size: 1024\n2025-01-01 00:00:00       1024 test/data/a")
+	g.Expect(err).To(Succeed())
+	g.Expect(res.objects).To(Equal(int64(1)))
+	g.Expect(res.bytes).To(Equal(int64(1024)))
+	g.Expect(res.listing).To(Equal("2025-01-01 00:00:00       1024 test/data/a"))
+	g.Expect(res.listed).To(Equal(int64(1)))
+
+	// the listing is truncated by the termination message limit
+	res, err = parseListResult("\nTotal Objects: 3\n   Total Size: 30\n2025-01-01 00:00:00         10 test/data/a")
+	g.Expect(err).To(Succeed())
+	g.Expect(res.objects).To(Equal(int64(3)))
+	g.Expect(res.listed).To(Equal(int64(1)))
+}
+
+func deletingBucket(path string) *v1alpha1.BucketClaim {
+	now := metav1.NewTime(time.Now())
+	return &v1alpha1.BucketClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-bucket",
+			Namespace:         "default",
+			UID:               "test-bucket",
+			Finalizers:        []string{v1alpha1.BucketDataFinalizer},
+			DeletionTimestamp: &now,
+			Annotations: map[string]string{
+				v1alpha1.AnnAnyInstanceRunning: "true",
+			},
+		},
+		Spec: v1alpha1.BucketClaimSpec{
+			S3:             &v1alpha1.S3Provider{Path: path},
+			LogSetTemplate: logSetTemplate(),
+		},
+		Status: v1alpha1.BucketClaimStatus{
+			State: v1alpha1.StatusDeleting,
+		},
+	}
+}
+
+func logSetTemplate() corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: v1alpha1.ContainerMain, Image: "mo"}},
+		},
+	}
+}
+
+func newFakeClient(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(batchv1.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	return fake.KubeClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+// completeJob marks the job as succeeded and creates the pod that reports the message
+func completeJob(g *WithT, cli client.Client, job *batchv1.Job, msg string) {
+	job.Status.Succeeded = 1
+	g.Expect(cli.Status().Update(context.TODO(), job)).To(Succeed())
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      job.Name + "-pod",
+			Namespace: job.Namespace,
+			Labels:    map[string]string{jobNameLabel: job.Name},
+		},
+		Spec: job.Spec.Template.Spec,
+	}
+	g.Expect(cli.Create(context.TODO(), pod)).To(Succeed())
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:  v1alpha1.ContainerMain,
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: msg}},
+	}}
+	g.Expect(cli.Status().Update(context.TODO(), pod)).To(Succeed())
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/matrixorigin/controller-runtime/pkg/util"
 	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
 	"github.com/matrixorigin/matrixone-operator/pkg/utils"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -33,7 +34,9 @@ const (
 	defaultImage = "amazon/aws-cli"
 
 	// entrypoint of job pod
-	entrypoint  = "start.sh"
+	entrypoint = "start.sh"
+	// listScript reports the summary and the objects under the path in the termination message
+	listScript  = "list.sh"
 	cmMountPath = "/etc/aws-cli-config"
 )
 
@@ -55,6 +58,11 @@ endpoint={{ .EndPoint }}
 if [ -n "${region}" ]; then
   export AWS_REGION="${region}"
 fi
+{{- if .VirtualHostedStyle }}
+
+# aws cli addresses the bucket in path for custom endpoints, which is rejected by oss
+aws configure set default.s3.addressing_style virtual
+{{- end }}
 
 if [ -n "${endpoint}" ]; then
   aws --endpoint-url "${endpoint}" s3api head-bucket --bucket {{ .Bucket }} || bucketNotExist=true
@@ -73,24 +81,69 @@ else
 fi
 `))
 
+/*
+command example:
+aws --endpoint-url https://play.minio.io:9000 --region us-east-1 s3 ls s3://test0407/ddf --recursive --summarize
+
+The listing matches the same keys as 'aws s3 rm --recursive' does. The termination message of a pod is limited to 4KB,
+so the summary goes first and the object list is truncated.
+*/
+var listCmd = template.Must(template.New("list-bucket-script").Parse(`
+#!/bin/sh
+
+set -ex
+
+region={{ .Region }}
+endpoint={{ .EndPoint }}
+
+if [ -n "${region}" ]; then
+  export AWS_REGION="${region}"
+fi
+{{- if .VirtualHostedStyle }}
+
+# aws cli addresses the bucket in path for custom endpoints, which is rejected by oss
+aws configure set default.s3.addressing_style virtual
+{{- end }}
+
+# aws s3 ls exits with 1 if no object found, check the summary instead
+if [ -n "${endpoint}" ]; then
+  aws --endpoint-url "${endpoint}" s3 ls s3://{{ .Path }} --recursive --summarize > /tmp/objects || true
+else
+  aws s3 ls s3://{{ .Path }} --recursive --summarize > /tmp/objects || true
+fi
+grep -q "Total Objects:" /tmp/objects
+
+{ tail -n 2 /tmp/objects; head -n -3 /tmp/objects; } | head -c 4000 > /dev/termination-log
+`))
+
 type s3Param struct {
-	Path     string
-	EndPoint string
-	Region   string
-	Bucket   string
+	Path               string
+	EndPoint           string
+	Region             string
+	Bucket             string
+	VirtualHostedStyle bool
 }
 
 func parseEntrypoint(s3 *v1alpha1.S3Provider) (string, error) {
+	return parseScript(delCmd, s3)
+}
+
+func parseListScript(s3 *v1alpha1.S3Provider) (string, error) {
+	return parseScript(listCmd, s3)
+}
+
+func parseScript(tpl *template.Template, s3 *v1alpha1.S3Provider) (string, error) {
 	bucketPath := strings.Split(s3.Path, "/")
 	if len(bucketPath) < 0 {
 		return "", fmt.Errorf("unexpected bucket path: %s", s3.Path)
 	}
 	buf := new(bytes.Buffer)
-	err := delCmd.Execute(buf, &s3Param{
-		Path:     s3.Path,
-		EndPoint: s3.Endpoint,
-		Region:   s3.Region,
-		Bucket:   bucketPath[0],
+	err := tpl.Execute(buf, &s3Param{
+		Path:               s3.Path,
+		EndPoint:           s3.GetEndpoint(),
+		Region:             s3.Region,
+		Bucket:             bucketPath[0],
+		VirtualHostedStyle: s3.GetProviderType() == v1alpha1.S3ProviderTypeOSS,
 	})
 	if err != nil {
 		return "", err
@@ -103,6 +156,10 @@ func (bca *Actor) NewCmTpl(bucket *v1alpha1.BucketClaim) (*corev1.ConfigMap, err
 	if err != nil {
 		return nil, err
 	}
+	list, err := parseListScript(bucket.Spec.S3)
+	if err != nil {
+		return nil, err
+	}
 
 	cm := &corev1.ConfigMap{
 		ObjectMeta: v1.ObjectMeta{
@@ -111,19 +168,28 @@ func (bca *Actor) NewCmTpl(bucket *v1alpha1.BucketClaim) (*corev1.ConfigMap, err
 		},
 		Data: map[string]string{
 			entrypoint: entry,
+			listScript: list,
 		},
 	}
 	return cm, nil
 }
 
 func (bca *Actor) NewJobTpl(bucket *v1alpha1.BucketClaim, cm *corev1.ConfigMap) *batchv1.Job {
+	return bca.newScriptJob(bucket, cm, jobName(bucket), entrypoint)
+}
+
+// newScriptJob builds a job that runs the script in the configmap with the pod template of the logset
+func (bca *Actor) newScriptJob(bucket *v1alpha1.BucketClaim, cm *corev1.ConfigMap, name, script string) *batchv1.Job {
 	podTpl := bucket.Spec.LogSetTemplate
 	mainContainer := util.FindFirst(podTpl.Spec.Containers, func(c corev1.Container) bool {
 		return c.Name == v1alpha1.ContainerMain
 	})
 	mainContainer.Image = bca.image
-	mainContainer.Command = []string{"/bin/sh", filepath.Join(cmMountPath, entrypoint)}
+	mainContainer.Command = []string{"/bin/sh", filepath.Join(cmMountPath, script)}
 	mainContainer.Args = []string{}
+	// the list script reports the result in the termination message
+	mainContainer.TerminationMessagePath = corev1.TerminationMessagePathDefault
+	mainContainer.TerminationMessagePolicy = corev1.TerminationMessageReadFile
 	podTpl.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
 
 	// remove all labels inherit from logset pod
@@ -149,10 +215,12 @@ func (bca *Actor) NewJobTpl(bucket *v1alpha1.BucketClaim, cm *corev1.ConfigMap)
 			},
 		},
 	}}
+	// the credentials are injected again since the volumes of the logset are dropped
+	common.InjectS3Credential(bucket.Spec.S3, &podTpl.Spec, &podTpl.Spec.Containers[0])
 
 	job := &batchv1.Job{
 		ObjectMeta: v1.ObjectMeta{
-			Name:      jobName(bucket),
+			Name:      name,
 			Namespace: bucket.Namespace,
 		},
 		Spec: batchv1.JobSpec{
@@ -171,3 +239,15 @@ func jobName(bucket *v1alpha1.BucketClaim) string {
 func cmName(bucket *v1alpha1.BucketClaim) string {
 	return fmt.Sprintf("cm-%s", bucket.Name)
 }
+
+func usageJobName(bucket *v1alpha1.BucketClaim) string {
+	return fmt.Sprintf("usage-%s", bucket.Name)
+}
+
+func previewJobName(bucket *v1alpha1.BucketClaim) string {
+	return fmt.Sprintf("preview-job-%s", bucket.Name)
+}
+
+func previewCmName(bucket *v1alpha1.BucketClaim) string {
+	return fmt.Sprintf("preview-%s", bucket.Name)
+}
@@ -14,6 +14,10 @@
 
 package bucketclaim
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 type Option func(actor *Actor)
 
 func WithImage(image string) Option {
@@ -23,3 +27,12 @@ func WithImage(image string) Option {
 		}
 	}
 }
+
+// WithUsageInterval sets the interval of reporting the usage of in-use buckets, 0 disables the report
+func WithUsageInterval(interval *metav1.Duration) Option {
+	return func(actor *Actor) {
+		if interval != nil {
+			actor.usageInterval = interval.Duration
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bucketclaim
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultUsageInterval = time.Hour
+
+	// jobNameLabel is the label set by the job controller to the pods of a job
+	jobNameLabel = "job-name"
+)
+
+var (
+	totalObjectsPattern = regexp.MustCompile(`Total Objects:\s*(\d+)`)
+	totalSizePattern    = regexp.MustCompile(`Total Size:\s*(\d+)`)
+)
+
+// listResult is the output of the list script
+type listResult struct {
+	objects int64
+	bytes   int64
+	// listing is the object lines of 'aws s3 ls', which might be truncated
+	listing string
+	// listed is the number of objects in the listing
+	listed int64
+}
+
+// syncUsage starts a job to list the claimed path periodically and records the usage in status
+func (bca *Actor) syncUsage(ctx *recon.Context[*v1alpha1.BucketClaim]) error {
+	bucket := ctx.Obj
+	job := &batchv1.Job{}
+	err := ctx.Get(client.ObjectKey{Namespace: bucket.Namespace, Name: usageJobName(bucket)}, job)
+	switch {
+	case apierrors.IsNotFound(err):
+		if last := lastUsageAttempt(bucket); last != nil {
+			if elapsed := time.Since(last.Time); elapsed < bca.usageInterval {
+				return recon.ErrReSync("wait for next usage report", bca.usageInterval-elapsed)
+			}
+		}
+		cm, err := bca.syncScripts(ctx)
+		if err != nil {
+			return err
+		}
+		return ctx.CreateOwned(bca.newScriptJob(bucket, cm, usageJobName(bucket), listScript))
+	case err != nil:
+		return errors.WrapPrefix(err, "error get usage job", 0)
+	}
+
+	if isJobFailure(job) {
+		// record the attempt so that the failure is retried in the next report instead of immediately
+		ctx.Log.Info(fmt.Sprintf("usage job %v failed", client.ObjectKeyFromObject(job)))
+		now := metav1.Now()
+		bucket.Status.UsageAttemptTime = &now
+		if err := ctx.Update(bucket); err != nil {
+			return err
+		}
+		return bca.deleteJob(ctx, job, bca.usageInterval)
+	}
+	if !isJobSuccess(job) {
+		// wait job finished, the job is owned by the bucketclaim so the completion will trigger a reconciliation
+		return nil
+	}
+	res, err := bca.readListResult(ctx, job)
+	if err != nil {
+		return err
+	}
+	bucket.Status.Usage = res.usage()
+	bucket.Status.UsageAttemptTime = &bucket.Status.Usage.LastUpdateTime
+	if err := ctx.Update(bucket); err != nil {
+		return err
+	}
+	return bca.deleteJob(ctx, job, bca.usageInterval)
+}
+
+// lastUsageAttempt returns the time of the last usage report, the attempt time is missing in the
+// status reported by older versions
+func lastUsageAttempt(bucket *v1alpha1.BucketClaim) *metav1.Time {
+	if bucket.Status.UsageAttemptTime != nil {
+		return bucket.Status.UsageAttemptTime
+	}
+	if u := bucket.Status.Usage; u != nil {
+		return &u.LastUpdateTime
+	}
+	return nil
+}
+
+// deleteJob deletes the finished job and requests another reconciliation after the given duration
+func (bca *Actor) deleteJob(ctx *recon.Context[*v1alpha1.BucketClaim], job *batchv1.Job, requeueAfter time.Duration) error {
+	err := ctx.Delete(job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.WrapPrefix(err, "error delete job", 0)
+	}
+	return recon.ErrReSync("job finished", requeueAfter)
+}
+
+// syncScripts creates or updates the configmap that includes the scripts of the jobs
+func (bca *Actor) syncScripts(ctx *recon.Context[*v1alpha1.BucketClaim]) (*corev1.ConfigMap, error) {
+	desired, err := bca.NewCmTpl(ctx.Obj)
+	if err != nil {
+		return nil, err
+	}
+	cm := &corev1.ConfigMap{}
+	err = ctx.Get(client.ObjectKeyFromObject(desired), cm)
+	switch {
+	case apierrors.IsNotFound(err):
+		return desired, ctx.CreateOwned(desired)
+	case err != nil:
+		return nil, err
+	}
+	// configmaps created by older versions have no list script
+	if cm.Data[entrypoint] != desired.Data[entrypoint] || cm.Data[listScript] != desired.Data[listScript] {
+		cm.Data = desired.Data
+		if err := ctx.Update(cm); err != nil {
+			return nil, err
+		}
+	}
+	return cm, nil
+}
+
+// readListResult reads the output of the list script from the termination message of the succeeded job pod
+func (bca *Actor) readListResult(ctx *recon.Context[*v1alpha1.BucketClaim], job *batchv1.Job) (*listResult, error) {
+	podList := &corev1.PodList{}
+	if err := ctx.List(podList, client.InNamespace(job.Namespace), client.MatchingLabels{jobNameLabel: job.Name}); err != nil {
+		return nil, errors.WrapPrefix(err, "error list job pods", 0)
+	}
+	for _, pod := range podList.Items {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name != v1alpha1.ContainerMain || cs.State.Terminated == nil || cs.State.Terminated.ExitCode != 0 {
+				continue
+			}
+			return parseListResult(cs.State.Terminated.Message)
+		}
+	}
+	return nil, fmt.Errorf("no succeeded pod found for job %v", client.ObjectKeyFromObject(job))
+}
+
+// parseListResult parses the summary of 'aws s3 ls --summarize' that goes first in the message, followed by the listing
+func parseListResult(msg string) (*listResult, error) {
+	objects := totalObjectsPattern.FindStringSubmatch(msg)
+	size := totalSizePattern.FindStringSubmatch(msg)
+	if objects == nil || size == nil {
+		return nil, fmt.Errorf("no summary found in list result: %q", msg)
+	}
+	res := &listResult{}
+	var err error
+	if res.objects, err = strconv.ParseInt(objects[1], 10, 64); err != nil {
+		return nil, err
+	}
+	if res.bytes, err = strconv.ParseInt(size[1], 10, 64); err != nil {
+		return nil, err
+	}
+	var lines []string
+	for _, line := range strings.Split(msg, "\n") {
+		if strings.TrimSpace(line) == "" || totalObjectsPattern.MatchString(line) || totalSizePattern.MatchString(line) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	res.listing = strings.Join(lines, "\n")
+	res.listed = int64(len(lines))
+	return res, nil
+}
+
+func (r *listResult) usage() *v1alpha1.BucketUsage {
+	return &v1alpha1.BucketUsage{
+		Objects:        r.objects,
+		Bytes:          r.bytes,
+		LastUpdateTime: metav1.Now(),
+	}
+}
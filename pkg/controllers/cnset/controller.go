@@ -92,6 +92,9 @@ func (c *Actor) Observe(ctx *recon.Context[*v1alpha1.CNSet]) (recon.Action[*v1al
 	}); err != nil {
 		return nil, errors.WrapPrefix(err, "sync service", 0)
 	}
+	if err := common.SyncSQLExposure(ctx, svc, cn.Spec.Exposure, CNSQLPort); err != nil {
+		return nil, errors.WrapPrefix(err, "sync exposure", 0)
+	}
 
 	// diff desired cloneset and determine whether should an update be invoked
 	origin := cs.DeepCopy()
@@ -338,7 +341,7 @@ func syncCloneSet(ctx *recon.Context[*v1alpha1.CNSet], cs *kruisev1alpha1.CloneS
 		return errors.WrapPrefix(err, "sync pod meta", 0)
 	}
 	if ctx.Dep != nil {
-		syncPodSpec(ctx.Obj, cs, ctx.Dep.Deps.LogSet.Spec.SharedStorage)
+		syncPodSpec(ctx.Obj, cs, ctx.Dep.Deps.LogSet.Spec.SharedStorage, ctx.Dep.Deps.LogSet.Spec.ETLStorage)
 	}
 	if pooling {
 		if cs.Annotations == nil {
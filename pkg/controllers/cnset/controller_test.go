@@ -291,7 +291,7 @@ func TestCNSetVolumeMount(t *testing.T) {
 					},
 				},
 			}
-			syncPodSpec(tt.cnset, tt.cs, tt.sp)
+			syncPodSpec(tt.cnset, tt.cs, tt.sp, nil)
 
 			if tt.cnset.Spec.CacheVolume == nil {
 				// if cacheVolume not set, volumeClaimTemplates should be 0
@@ -16,7 +16,6 @@ package cnstore
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -357,17 +356,12 @@ func (c *withCNSet) defaultCNNormalReconcile(ctx *recon.Context[*corev1.Pod]) er
 	pod := ctx.Obj
 	uid := v1alpha1.GetCNPodUUID(pod)
 
-	// sync CN labels for store and mark store as UP state
-	var cnLabels []v1alpha1.CNLabel
-	labelStr, ok := pod.Annotations[common.CNLabelAnnotation]
-	if ok {
-		err := json.Unmarshal([]byte(labelStr), &cnLabels)
-		if err != nil {
-			return errors.WrapPrefix(err, "unmarshal CNLabels", 0)
-		}
+	// sync CN labels (including the labels of ProxyRoutes) for store and mark store as UP state
+	cnLabels, err := common.PodCNLabels(pod)
+	if err != nil {
+		return err
 	}
 
-	var err error
 	if c.cn.Spec.ScalingConfig.GetStoreDrainEnabled() {
 		err = c.withMOClientSet(ctx, func(timeout context.Context, h *mocli.ClientSet) error {
 			return h.Client.PatchCNStore(timeout, logpb.CNStateLabel{
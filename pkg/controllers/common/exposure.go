@@ -0,0 +1,240 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ExposedAnno records the kinds of the networking objects that expose the service, so that
+	// the objects can be cleaned up when the exposure is removed
+	ExposedAnno = "matrixone.cloud/exposed"
+
+	KindIngress = "Ingress"
+)
+
+var (
+	HTTPRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+	TCPRouteGVK  = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1alpha2", Kind: "TCPRoute"}
+)
+
+// SyncHTTPExposure syncs the Ingress and HTTPRoute that expose the HTTP port of the service,
+// the svc must be the object that is persisted in kubernetes
+func SyncHTTPExposure(cli recon.KubeClient, svc *corev1.Service, exp *v1alpha1.HTTPExposure, port int32) error {
+	if exp == nil {
+		exp = &v1alpha1.HTTPExposure{}
+	}
+	return syncExposure(cli, svc, func(exposed []string) ([]string, error) {
+		exposed, err := syncIngress(cli, svc, exp.Ingress, port, exposed)
+		if err != nil {
+			return exposed, err
+		}
+		return syncRoute(cli, svc, HTTPRouteGVK, exp.HTTPRoute, port, exposed)
+	})
+}
+
+// SyncSQLExposure syncs the Gateway API TCPRoute that exposes the MySQL port of the service,
+// the svc must be the object that is persisted in kubernetes
+func SyncSQLExposure(cli recon.KubeClient, svc *corev1.Service, exp *v1alpha1.SQLExposure, port int32) error {
+	if exp == nil {
+		exp = &v1alpha1.SQLExposure{}
+	}
+	return syncExposure(cli, svc, func(exposed []string) ([]string, error) {
+		return syncRoute(cli, svc, TCPRouteGVK, exp.TCPRoute, port, exposed)
+	})
+}
+
+func syncExposure(cli recon.KubeClient, svc *corev1.Service, syncFn func([]string) ([]string, error)) error {
+	var exposed []string
+	if s := svc.Annotations[ExposedAnno]; s != "" {
+		exposed = strings.Split(s, ",")
+	}
+	desired, syncErr := syncFn(slices.Clone(exposed))
+	slices.Sort(desired)
+	if !slices.Equal(exposed, desired) {
+		// record the exposed kinds even if the sync is partially failed
+		if err := cli.Patch(svc, func() error {
+			if len(desired) == 0 {
+				delete(svc.Annotations, ExposedAnno)
+				return nil
+			}
+			if svc.Annotations == nil {
+				svc.Annotations = map[string]string{}
+			}
+			svc.Annotations[ExposedAnno] = strings.Join(desired, ",")
+			return nil
+		}); err != nil {
+			return errors.WrapPrefix(err, "record exposed objects", 0)
+		}
+	}
+	return syncErr
+}
+
+func syncIngress(cli recon.KubeClient, svc *corev1.Service, exp *v1alpha1.IngressExposure, port int32, exposed []string) ([]string, error) {
+	ing := &networkingv1.Ingress{}
+	ing.Namespace = svc.Namespace
+	ing.Name = svc.Name
+	if exp == nil {
+		return removeExposed(cli, ing, KindIngress, exposed)
+	}
+	if err := recon.CreateOwnedOrUpdate(cli, ing, func() error {
+		syncExposedMeta(svc, ing, exp.Annotations)
+		pathType := networkingv1.PathTypePrefix
+		ing.Spec = networkingv1.IngressSpec{
+			IngressClassName: exp.ClassName,
+			Rules: []networkingv1.IngressRule{{
+				Host: exp.Host,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     exp.GetPath(),
+							PathType: &pathType,
+							Backend: networkingv1.IngressBackend{
+								Service: &networkingv1.IngressServiceBackend{
+									Name: svc.Name,
+									Port: networkingv1.ServiceBackendPort{Number: port},
+								},
+							},
+						}},
+					},
+				},
+			}},
+		}
+		if exp.TLSSecretName != nil {
+			tls := networkingv1.IngressTLS{SecretName: *exp.TLSSecretName}
+			if exp.Host != "" {
+				tls.Hosts = []string{exp.Host}
+			}
+			ing.Spec.TLS = []networkingv1.IngressTLS{tls}
+		}
+		return nil
+	}); err != nil {
+		return exposed, errors.WrapPrefix(err, "sync ingress", 0)
+	}
+	return addExposed(exposed, KindIngress), nil
+}
+
+func syncRoute(cli recon.KubeClient, svc *corev1.Service, gvk schema.GroupVersionKind, exp *v1alpha1.GatewayRouteExposure, port int32, exposed []string) ([]string, error) {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(gvk)
+	route.SetNamespace(svc.Namespace)
+	route.SetName(svc.Name)
+	if exp == nil {
+		return removeExposed(cli, route, gvk.Kind, exposed)
+	}
+	if err := recon.CreateOwnedOrUpdate(cli, route, func() error {
+		syncExposedMeta(svc, route, exp.Annotations)
+		return unstructured.SetNestedField(route.Object, buildRouteSpec(gvk, svc, exp, port), "spec")
+	}); err != nil {
+		return exposed, errors.WrapPrefix(err, "sync "+gvk.Kind, 0)
+	}
+	return addExposed(exposed, gvk.Kind), nil
+}
+
+// buildRouteSpec builds the spec of the Gateway API route in unstructured form, since the
+// Gateway API is an optional dependency of the cluster
+func buildRouteSpec(gvk schema.GroupVersionKind, svc *corev1.Service, exp *v1alpha1.GatewayRouteExposure, port int32) map[string]interface{} {
+	var parentRefs []interface{}
+	for _, ref := range exp.ParentRefs {
+		p := map[string]interface{}{"name": ref.Name}
+		if ref.Namespace != nil {
+			p["namespace"] = *ref.Namespace
+		}
+		if ref.SectionName != nil {
+			p["sectionName"] = *ref.SectionName
+		}
+		if ref.Port != nil {
+			p["port"] = int64(*ref.Port)
+		}
+		parentRefs = append(parentRefs, p)
+	}
+	spec := map[string]interface{}{
+		"parentRefs": parentRefs,
+		"rules": []interface{}{
+			map[string]interface{}{
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"name": svc.Name,
+						"port": int64(port),
+					},
+				},
+			},
+		},
+	}
+	// TCPRoute does not support hostnames
+	if gvk.Kind != TCPRouteGVK.Kind && len(exp.Hostnames) > 0 {
+		var hostnames []interface{}
+		for _, h := range exp.Hostnames {
+			hostnames = append(hostnames, h)
+		}
+		spec["hostnames"] = hostnames
+	}
+	return spec
+}
+
+func syncExposedMeta(svc *corev1.Service, obj client.Object, annotations map[string]string) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for k, v := range svc.Labels {
+		labels[k] = v
+	}
+	obj.SetLabels(labels)
+	if len(annotations) == 0 {
+		return
+	}
+	annos := obj.GetAnnotations()
+	if annos == nil {
+		annos = map[string]string{}
+	}
+	for k, v := range annotations {
+		annos[k] = v
+	}
+	obj.SetAnnotations(annos)
+}
+
+func removeExposed(cli recon.KubeClient, obj client.Object, kind string, exposed []string) ([]string, error) {
+	if !slices.Contains(exposed, kind) {
+		return exposed, nil
+	}
+	err := cli.Delete(obj)
+	if err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return exposed, errors.WrapPrefix(err, "delete "+kind, 0)
+	}
+	return slices.DeleteFunc(exposed, func(k string) bool {
+		return k == kind
+	}), nil
+}
+
+func addExposed(exposed []string, kind string) []string {
+	if slices.Contains(exposed, kind) {
+		return exposed
+	}
+	return append(exposed, kind)
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/matrixorigin/controller-runtime/pkg/fake"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSyncHTTPExposure(t *testing.T) {
+	g := NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(v1alpha1.AddToScheme(s))
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "webui"}}
+	cli := &fake.Client{Client: fake.KubeClientBuilder().WithScheme(s).WithObjects(svc).Build()}
+	owner := &v1alpha1.WebUI{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "webui"}}
+	ctx := fake.NewContext(owner, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+
+	g.Expect(ctx.Get(client.ObjectKeyFromObject(svc), svc)).To(Succeed())
+	exp := &v1alpha1.HTTPExposure{Ingress: &v1alpha1.IngressExposure{
+		Host:          "webui.example.com",
+		TLSSecretName: pointer.String("webui-tls"),
+	}}
+	g.Expect(SyncHTTPExposure(ctx, svc, exp, 8001)).To(Succeed())
+	ing := &networkingv1.Ingress{}
+	g.Expect(ctx.Get(client.ObjectKeyFromObject(svc), ing)).To(Succeed())
+	g.Expect(ing.Spec.Rules[0].Host).To(Equal("webui.example.com"))
+	g.Expect(ing.Spec.TLS[0].SecretName).To(Equal("webui-tls"))
+	g.Expect(svc.Annotations[ExposedAnno]).To(Equal(KindIngress))
+
+	// removing the exposure cleans up the ingress
+	g.Expect(SyncHTTPExposure(ctx, svc, nil, 8001)).To(Succeed())
+	err := ctx.Get(client.ObjectKeyFromObject(svc), ing)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	g.Expect(svc.Annotations).NotTo(HaveKey(ExposedAnno))
+}
+
+func Test_buildRouteSpec(t *testing.T) {
+	g := NewGomegaWithT(t)
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "webui"}}
+	exp := &v1alpha1.GatewayRouteExposure{
+		ParentRefs: []v1alpha1.GatewayParentRef{{Name: "gw", SectionName: pointer.String("https")}},
+		Hostnames:  []string{"mo.example.com"},
+	}
+	spec := buildRouteSpec(HTTPRouteGVK, svc, exp, 8001)
+	g.Expect(spec["hostnames"]).To(Equal([]interface{}{"mo.example.com"}))
+	g.Expect(spec["parentRefs"]).To(Equal([]interface{}{
+		map[string]interface{}{"name": "gw", "sectionName": "https"},
+	}))
+
+	spec = buildRouteSpec(TCPRouteGVK, svc, exp, 6001)
+	g.Expect(spec).NotTo(HaveKey("hostnames"))
+}
@@ -58,60 +58,65 @@ const (
 func SetStorageProviderConfig(sp v1alpha1.SharedStorageProvider, podSpec *corev1.PodSpec) {
 	// S3 storage provider config
 	if s3p := sp.S3; s3p != nil {
-		// mount optional certificate secret volume
-		if s3p.CertificateRef != nil {
-			podSpec.Volumes = util.UpsertByKey(podSpec.Volumes, corev1.Volume{
-				Name: S3CertificateVolume,
-				VolumeSource: corev1.VolumeSource{
-					Secret: &corev1.SecretVolumeSource{
-						SecretName: s3p.CertificateRef.Name,
-					},
+		setS3Config(s3p, podSpec)
+		for i := range podSpec.Containers {
+			podSpec.Containers[i].Env = util.UpsertByKey(podSpec.Containers[i].Env, corev1.EnvVar{Name: AWSRegion, Value: envRegion(s3p)}, util.EnvVarKey)
+		}
+	}
+}
+
+// SetETLStorageProviderConfig inject configuration of the dedicated ETL storage to Pods, the region of
+// the ETL storage is set in the fileservice config instead of the env, which belongs to the shared storage
+func SetETLStorageProviderConfig(etl *v1alpha1.SharedStorageProvider, podSpec *corev1.PodSpec) {
+	if etl == nil || etl.S3 == nil {
+		return
+	}
+	setS3Config(etl.S3, podSpec)
+}
+
+// setS3Config mounts the certificates and injects the credentials of the S3 storage
+func setS3Config(s3p *v1alpha1.S3Provider, podSpec *corev1.PodSpec) {
+	// mount optional certificate secret volume
+	if s3p.CertificateRef != nil {
+		podSpec.Volumes = util.UpsertByKey(podSpec.Volumes, corev1.Volume{
+			Name: S3CertificateVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: s3p.CertificateRef.Name,
 				},
-			}, func(v corev1.Volume) string {
+			},
+		}, func(v corev1.Volume) string {
+			return v.Name
+		})
+	}
+	for i := range podSpec.Containers {
+		InjectS3Credential(s3p, podSpec, &podSpec.Containers[i])
+		if s3p.CertificateRef != nil {
+			podSpec.Containers[i].VolumeMounts = util.UpsertByKey(podSpec.Containers[i].VolumeMounts, corev1.VolumeMount{
+				Name:      S3CertificateVolume,
+				MountPath: S3CertificatePath,
+				ReadOnly:  true,
+			}, func(v corev1.VolumeMount) string {
 				return v.Name
 			})
 		}
-		for i := range podSpec.Containers {
-			if s3p.SecretRef != nil {
-				for _, key := range []string{AWSAccessKeyID, AWSSecretAccessKey} {
-					podSpec.Containers[i].Env = util.UpsertByKey(podSpec.Containers[i].Env, corev1.EnvVar{Name: key, ValueFrom: &corev1.EnvVarSource{
-						SecretKeyRef: &corev1.SecretKeySelector{
-							LocalObjectReference: *s3p.SecretRef,
-							Key:                  key,
-						},
-					}}, util.EnvVarKey)
-				}
-			}
-			if s3p.CertificateRef != nil {
-				podSpec.Containers[i].VolumeMounts = util.UpsertByKey(podSpec.Containers[i].VolumeMounts, corev1.VolumeMount{
-					Name:      S3CertificateVolume,
-					MountPath: S3CertificatePath,
-					ReadOnly:  true,
-				}, func(v corev1.VolumeMount) string {
-					return v.Name
-				})
-			}
-			region := s3p.Region
-			if region == "" {
-				region = defaultAWSRegion
-			}
-			podSpec.Containers[i].Env = util.UpsertByKey(podSpec.Containers[i].Env, corev1.EnvVar{Name: AWSRegion, Value: region}, util.EnvVarKey)
-		}
 	}
 }
 
-// FileServiceConfig generate the fileservice config for an MO component
-func FileServiceConfig(localPath string, sp v1alpha1.SharedStorageProvider, cache *v1alpha1.SharedStorageCache) map[string]interface{} {
+// FileServiceConfig generate the fileservice config for an MO component, the ETL data is stored in the etl
+// directory of the shared storage unless a dedicated etl storage is specified
+func FileServiceConfig(localPath string, sp v1alpha1.SharedStorageProvider, etl *v1alpha1.SharedStorageProvider, cache *v1alpha1.SharedStorageCache) map[string]interface{} {
 	localFS := map[string]interface{}{
 		"name":     localFileServiceName,
 		"backend":  fsBackendTypeDisk,
 		"data-dir": localPath,
 	}
-	// MO Operator currently unifies the storage DB data and ETL data to a single shared storage
-	// for user. We may provide options to configure the shared storages of DB and ETL separately if
-	// we found it necessary in the future.
-	s3FS := sharedFileServiceConfig(sp, cache, s3FileServiceName, "data")
-	etlFS := sharedFileServiceConfig(sp, nil, etlFileServiceName, "etl")
+	s3FS := sharedFileServiceConfig(sp, sp.S3, cache, s3FileServiceName, "data")
+	etlStorage := sp
+	if etl != nil {
+		etlStorage = *etl
+	}
+	etlFS := sharedFileServiceConfig(etlStorage, sp.S3, nil, etlFileServiceName, "etl")
 	return map[string]interface{}{
 		// some data are not accessed by fileservice and will be read/written at `data-dir` directly
 		"data-dir": localPath,
@@ -123,9 +128,34 @@ func FileServiceConfig(localPath string, sp v1alpha1.SharedStorageProvider, cach
 	}
 }
 
+// fileServiceRegion returns the region that must be set in the fileservice config, the region is read
+// from the env otherwise, so that the config of the existing clusters is kept
+func fileServiceRegion(s3 *v1alpha1.S3Provider, envS3 *v1alpha1.S3Provider) string {
+	switch {
+	case s3.Region == "":
+		return ""
+	case s3.GetProviderType() == v1alpha1.S3ProviderTypeOSS:
+		// the sdk of oss does not read the region from the env
+		return s3.Region
+	case envS3 == nil || envRegion(envS3) != s3.Region:
+		// the region in the env belongs to the shared storage while the ETL storage may be in another region
+		return s3.Region
+	default:
+		return ""
+	}
+}
+
+// envRegion returns the region in the env of the components that access the S3 storage
+func envRegion(s3 *v1alpha1.S3Provider) string {
+	if s3.Region == "" {
+		return defaultAWSRegion
+	}
+	return s3.Region
+}
+
 // LogServiceFSConfig generate the fileservice config for log-service
 func LogServiceFSConfig(localPath string, sp v1alpha1.SharedStorageProvider) map[string]interface{} {
-	backupFS := sharedFileServiceConfig(sp, nil, BackupFileServiceName, "")
+	backupFS := sharedFileServiceConfig(sp, sp.S3, nil, BackupFileServiceName, "")
 	return map[string]interface{}{
 		"data-dir": localPath,
 		"fileservice": []map[string]interface{}{
@@ -134,25 +164,31 @@ func LogServiceFSConfig(localPath string, sp v1alpha1.SharedStorageProvider) map
 	}
 }
 
-func sharedFileServiceConfig(sp v1alpha1.SharedStorageProvider, cache *v1alpha1.SharedStorageCache, name, subDir string) map[string]interface{} {
+// sharedFileServiceConfig generates the config of a shared fileservice, envS3 is the S3 storage whose
+// credentials and region are provided in the env of the component
+func sharedFileServiceConfig(sp v1alpha1.SharedStorageProvider, envS3 *v1alpha1.S3Provider, cache *v1alpha1.SharedStorageCache, name, subDir string) map[string]interface{} {
 	m := map[string]interface{}{
 		"name": name,
 	}
 	// S3 file service config
 	if s3 := sp.S3; s3 != nil {
-		switch s3.GetProviderType() {
-		case v1alpha1.S3ProviderTypeMinIO:
+		// the MINIO backend addresses the bucket in path, other providers are accessed with the S3
+		// backend, and the fileservice switches to the sdk of oss by the endpoint
+		if s3.UsePathStyle() {
 			m["backend"] = fsBackendTypeMinio
-		case v1alpha1.S3ProviderTypeAWS:
+		} else {
 			m["backend"] = fsBackendTypeS3
 		}
 		s3Config := map[string]interface{}{}
-		if s3.Endpoint != "" {
-			s3Config["endpoint"] = s3.Endpoint
+		if endpoint := s3.GetEndpoint(); endpoint != "" {
+			s3Config["endpoint"] = endpoint
 		} else {
 			// TODO: let AWS SDK discover its own endpoint by default
 			s3Config["endpoint"] = "s3.us-west-2.amazonaws.com"
 		}
+		if region := fileServiceRegion(s3, envS3); region != "" {
+			s3Config["region"] = region
+		}
 		paths := strings.SplitN(strings.Trim(s3.Path, "/"), "/", 2)
 		s3Config["bucket"] = paths[0]
 		keyPrefix := subDir
@@ -24,9 +24,11 @@ import (
 )
 
 func TestFileServiceConfig(t *testing.T) {
+	gcs, oss := v1alpha1.S3ProviderTypeGCS, v1alpha1.S3ProviderTypeOSS
 	type args struct {
 		localPath string
 		sp        v1alpha1.SharedStorageProvider
+		etl       *v1alpha1.SharedStorageProvider
 		c         *v1alpha1.SharedStorageCache
 	}
 	quantity1GiB := resource.MustParse("1Gi")
@@ -127,11 +129,273 @@ func TestFileServiceConfig(t *testing.T) {
 				},
 			}},
 		},
+	}, {
+		name: "dedicated etl storage",
+		args: args{
+			localPath: "/test",
+			sp: v1alpha1.SharedStorageProvider{
+				S3: &v1alpha1.S3Provider{
+					Path: "bucket/prefix",
+				},
+			},
+			etl: &v1alpha1.SharedStorageProvider{
+				S3: &v1alpha1.S3Provider{
+					Path:   "etl-bucket",
+					Region: "us-east-1",
+				},
+			},
+		},
+		want: map[string]interface{}{
+			"data-dir": "/test",
+			"fileservice": []map[string]interface{}{{
+				"name":     "LOCAL",
+				"data-dir": "/test",
+				"backend":  "DISK",
+			}, {
+				"name":    "S3",
+				"backend": "S3",
+				"cache": map[string]string{
+					"memory-capacity": "1B",
+				},
+				"s3": map[string]interface{}{
+					"endpoint":   "s3.us-west-2.amazonaws.com",
+					"key-prefix": "prefix/data",
+					"bucket":     "bucket",
+				},
+			}, {
+				"name":    "ETL",
+				"backend": "S3",
+				"cache": map[string]string{
+					"memory-capacity": "1B",
+				},
+				"s3": map[string]interface{}{
+					"endpoint":   "s3.us-west-2.amazonaws.com",
+					"key-prefix": "etl",
+					"bucket":     "etl-bucket",
+					"region":     "us-east-1",
+				},
+			}},
+		},
+	}, {
+		name: "dedicated etl storage in the shared region",
+		args: args{
+			localPath: "/test",
+			sp: v1alpha1.SharedStorageProvider{
+				S3: &v1alpha1.S3Provider{
+					Path:   "bucket/prefix",
+					Region: "us-east-1",
+				},
+			},
+			etl: &v1alpha1.SharedStorageProvider{
+				S3: &v1alpha1.S3Provider{
+					Path:   "etl-bucket",
+					Region: "us-east-1",
+				},
+			},
+		},
+		want: map[string]interface{}{
+			"data-dir": "/test",
+			"fileservice": []map[string]interface{}{{
+				"name":     "LOCAL",
+				"data-dir": "/test",
+				"backend":  "DISK",
+			}, {
+				"name":    "S3",
+				"backend": "S3",
+				"cache": map[string]string{
+					"memory-capacity": "1B",
+				},
+				"s3": map[string]interface{}{
+					"endpoint":   "s3.us-west-2.amazonaws.com",
+					"key-prefix": "prefix/data",
+					"bucket":     "bucket",
+				},
+			}, {
+				"name":    "ETL",
+				"backend": "S3",
+				"cache": map[string]string{
+					"memory-capacity": "1B",
+				},
+				"s3": map[string]interface{}{
+					"endpoint":   "s3.us-west-2.amazonaws.com",
+					"key-prefix": "etl",
+					"bucket":     "etl-bucket",
+				},
+			}},
+		},
+	}, {
+		name: "etl on filesystem",
+		args: args{
+			localPath: "/test",
+			sp: v1alpha1.SharedStorageProvider{
+				S3: &v1alpha1.S3Provider{
+					Path: "bucket",
+				},
+			},
+			etl: &v1alpha1.SharedStorageProvider{
+				FileSystem: &v1alpha1.FileSystemProvider{
+					Path: "/var/lib/etl",
+				},
+			},
+		},
+		want: map[string]interface{}{
+			"data-dir": "/test",
+			"fileservice": []map[string]interface{}{{
+				"name":     "LOCAL",
+				"data-dir": "/test",
+				"backend":  "DISK",
+			}, {
+				"name":    "S3",
+				"backend": "S3",
+				"cache": map[string]string{
+					"memory-capacity": "1B",
+				},
+				"s3": map[string]interface{}{
+					"endpoint":   "s3.us-west-2.amazonaws.com",
+					"key-prefix": "data",
+					"bucket":     "bucket",
+				},
+			}, {
+				"name":     "ETL",
+				"backend":  "DISK-ETL",
+				"data-dir": "/var/lib/etl",
+				"cache": map[string]string{
+					"memory-capacity": "1B",
+				},
+			}},
+		},
+	}, {
+		name: "gcs",
+		args: args{
+			localPath: "/test",
+			sp: v1alpha1.SharedStorageProvider{
+				S3: &v1alpha1.S3Provider{
+					Path: "bucket",
+					Type: &gcs,
+				},
+			},
+		},
+		want: map[string]interface{}{
+			"data-dir": "/test",
+			"fileservice": []map[string]interface{}{{
+				"name":     "LOCAL",
+				"data-dir": "/test",
+				"backend":  "DISK",
+			}, {
+				"name":    "S3",
+				"backend": "S3",
+				"cache": map[string]string{
+					"memory-capacity": "1B",
+				},
+				"s3": map[string]interface{}{
+					"endpoint":   "https://storage.googleapis.com",
+					"key-prefix": "data",
+					"bucket":     "bucket",
+				},
+			}, {
+				"name":    "ETL",
+				"backend": "S3",
+				"cache": map[string]string{
+					"memory-capacity": "1B",
+				},
+				"s3": map[string]interface{}{
+					"endpoint":   "https://storage.googleapis.com",
+					"key-prefix": "etl",
+					"bucket":     "bucket",
+				},
+			}},
+		},
+	}, {
+		name: "oss",
+		args: args{
+			localPath: "/test",
+			sp: v1alpha1.SharedStorageProvider{
+				S3: &v1alpha1.S3Provider{
+					Path:   "bucket/prefix",
+					Type:   &oss,
+					Region: "cn-hangzhou",
+				},
+			},
+		},
+		want: map[string]interface{}{
+			"data-dir": "/test",
+			"fileservice": []map[string]interface{}{{
+				"name":     "LOCAL",
+				"data-dir": "/test",
+				"backend":  "DISK",
+			}, {
+				"name":    "S3",
+				"backend": "S3",
+				"cache": map[string]string{
+					"memory-capacity": "1B",
+				},
+				"s3": map[string]interface{}{
+					"endpoint":   "https://oss-cn-hangzhou.aliyuncs.com",
+					"key-prefix": "prefix/data",
+					"bucket":     "bucket",
+					"region":     "cn-hangzhou",
+				},
+			}, {
+				"name":    "ETL",
+				"backend": "S3",
+				"cache": map[string]string{
+					"memory-capacity": "1B",
+				},
+				"s3": map[string]interface{}{
+					"endpoint":   "https://oss-cn-hangzhou.aliyuncs.com",
+					"key-prefix": "prefix/etl",
+					"bucket":     "bucket",
+					"region":     "cn-hangzhou",
+				},
+			}},
+		},
+	}, {
+		name: "s3 compatible with path style",
+		args: args{
+			localPath: "/test",
+			sp: v1alpha1.SharedStorageProvider{
+				S3: &v1alpha1.S3Provider{
+					Path:           "bucket",
+					Endpoint:       "http://ceph-rgw:7480",
+					ForcePathStyle: true,
+				},
+			},
+		},
+		want: map[string]interface{}{
+			"data-dir": "/test",
+			"fileservice": []map[string]interface{}{{
+				"name":     "LOCAL",
+				"data-dir": "/test",
+				"backend":  "DISK",
+			}, {
+				"name":    "S3",
+				"backend": "MINIO",
+				"cache": map[string]string{
+					"memory-capacity": "1B",
+				},
+				"s3": map[string]interface{}{
+					"endpoint":   "http://ceph-rgw:7480",
+					"key-prefix": "data",
+					"bucket":     "bucket",
+				},
+			}, {
+				"name":    "ETL",
+				"backend": "MINIO",
+				"cache": map[string]string{
+					"memory-capacity": "1B",
+				},
+				"s3": map[string]interface{}{
+					"endpoint":   "http://ceph-rgw:7480",
+					"key-prefix": "etl",
+					"bucket":     "bucket",
+				},
+			}},
+		},
 	},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if diff := cmp.Diff(tt.want, FileServiceConfig(tt.args.localPath, tt.args.sp, tt.args.c)); diff != "" {
+			if diff := cmp.Diff(tt.want, FileServiceConfig(tt.args.localPath, tt.args.sp, tt.args.etl, tt.args.c)); diff != "" {
 				t.Errorf("FileServiceConfig(), diff:\n %s", diff)
 			}
 		})
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/yaml"
 )
 
@@ -39,10 +40,34 @@ type OperatorConfig struct {
 
 type BrConfig struct {
 	Image string `json:"image,omitempty" yaml:"image,omitempty"`
+	// StorageToolImage is the image that accesses the backup catalog in the storage, the aws cli must be provided
+	StorageToolImage string `json:"storageToolImage,omitempty" yaml:"storageToolImage,omitempty"`
+	// DumpImage is the image of the logical backup and restore jobs, the storage migration jobs and the jobs
+	// that run restore statements, /cmdrest, mo-dump and the mysql client must be provided, as well as the aws
+	// cli to access S3. Default to the br image
+	DumpImage string `json:"dumpImage,omitempty" yaml:"dumpImage,omitempty"`
+}
+
+const defaultStorageToolImage = "amazon/aws-cli:latest"
+
+func (c BrConfig) GetStorageToolImage() string {
+	if c.StorageToolImage == "" {
+		return defaultStorageToolImage
+	}
+	return c.StorageToolImage
+}
+
+func (c BrConfig) GetDumpImage() string {
+	if c.DumpImage == "" {
+		return c.Image
+	}
+	return c.DumpImage
 }
 
 type BucketCleanJob struct {
 	Image string `json:"image,omitempty" yaml:"image,omitempty"`
+	// UsageInterval is the interval of reporting the usage of in-use buckets, default to 1h, 0 disables the report
+	UsageInterval *metav1.Duration `json:"usageInterval,omitempty" yaml:"usageInterval,omitempty"`
 }
 
 // LoadOperatorConfig read all operator configurations from configmap mount path, and load it into OperatorConfig struct
@@ -35,7 +35,9 @@ type SyncMOPodTask struct {
 	ConfigMap       *corev1.ConfigMap
 	KubeCli         recon.KubeClient
 	StorageProvider *v1alpha1.SharedStorageProvider
-	ConfigSuffix    string
+	// ETLStorageProvider is the dedicated ETL storage, optional
+	ETLStorageProvider *v1alpha1.SharedStorageProvider
+	ConfigSuffix       string
 	// optional
 	MutateContainer func(c *corev1.Container)
 	MutatePod       func(p *corev1.PodTemplateSpec)
@@ -107,6 +109,7 @@ func syncPodTemplate(t *SyncMOPodTask) {
 	if t.StorageProvider != nil {
 		SetStorageProviderConfig(*t.StorageProvider, specRef)
 	}
+	SetETLStorageProviderConfig(t.ETLStorageProvider, specRef)
 	SyncPodMeta(&t.TargetTemplate.ObjectMeta, t.PodSet)
 	if t.MutatePod != nil {
 		t.MutatePod(t.TargetTemplate)
@@ -0,0 +1,99 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ProxyDrainingFinalizer holds the proxy pod restart or deletion until the connections are drained
+	ProxyDrainingFinalizer = "matrixorigin.io/proxy-draining"
+
+	// ProxyReadiness is the readiness gate that controls whether the proxy pod is registered to the service endpoints
+	ProxyReadiness corev1.PodConditionType = "matrixorigin.io/proxy"
+
+	// ProxyDrainingAnno records the time when the operator started draining the proxy pod
+	ProxyDrainingAnno = "matrixorigin.io/proxy-draining"
+
+	// ProxyRouteAnnoPrefix is the prefix of the CN pod annotations that hold the route labels of each ProxyRoute,
+	// the full key is the prefix followed by the name of the ProxyRoute
+	ProxyRouteAnnoPrefix = "route.matrixone.cloud/"
+)
+
+func NewProxyReadinessCondition(status corev1.ConditionStatus, msg string) corev1.PodCondition {
+	return corev1.PodCondition{
+		Type:               ProxyReadiness,
+		Message:            msg,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+	}
+}
+
+// PodCNLabels returns the CN labels that should be pushed to HAKeeper for the pod, which is the CN labels
+// of the CNSet merged with the route labels of all the ProxyRoutes
+func PodCNLabels(pod *corev1.Pod) ([]v1alpha1.CNLabel, error) {
+	var keys []string
+	for k := range pod.Annotations {
+		if k == CNLabelAnnotation || strings.HasPrefix(k, ProxyRouteAnnoPrefix) {
+			keys = append(keys, k)
+		}
+	}
+	// keep the CNSet labels first and the routes in a stable order
+	slices.SortFunc(keys, func(a, b string) int {
+		if a == CNLabelAnnotation {
+			return -1
+		}
+		if b == CNLabelAnnotation {
+			return 1
+		}
+		return strings.Compare(a, b)
+	})
+	var labels []v1alpha1.CNLabel
+	for _, k := range keys {
+		var ls []v1alpha1.CNLabel
+		if err := json.Unmarshal([]byte(pod.Annotations[k]), &ls); err != nil {
+			return nil, errors.WrapPrefix(err, "unmarshal CNLabels of "+k, 0)
+		}
+		labels = MergeCNLabels(labels, ls...)
+	}
+	return labels, nil
+}
+
+// MergeCNLabels merges the CN labels, values of the same key are unioned
+func MergeCNLabels(base []v1alpha1.CNLabel, others ...v1alpha1.CNLabel) []v1alpha1.CNLabel {
+	for _, o := range others {
+		i := slices.IndexFunc(base, func(l v1alpha1.CNLabel) bool {
+			return l.Key == o.Key
+		})
+		if i < 0 {
+			base = append(base, v1alpha1.CNLabel{Key: o.Key, Values: slices.Clone(o.Values)})
+			continue
+		}
+		for _, v := range o.Values {
+			if !slices.Contains(base[i].Values, v) {
+				base[i].Values = append(base[i].Values, v)
+			}
+		}
+	}
+	return base
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"path"
+
+	"github.com/matrixorigin/controller-runtime/pkg/util"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// GCSAccessKeyID and GCSSecretAccessKey are the keys of the HMAC key in the credential Secret of gcs
+	GCSAccessKeyID     = "GCS_ACCESS_KEY_ID"
+	GCSSecretAccessKey = "GCS_SECRET_ACCESS_KEY"
+	// OSSAccessKeyID and OSSAccessKeySecret are the keys of the access key in the credential Secret of oss
+	OSSAccessKeyID     = "OSS_ACCESS_KEY_ID"
+	OSSAccessKeySecret = "OSS_ACCESS_KEY_SECRET"
+
+	AWSRoleARN              = "AWS_ROLE_ARN"
+	AWSWebIdentityTokenFile = "AWS_WEB_IDENTITY_TOKEN_FILE"
+
+	S3WebIdentityVolume    = "s3-web-identity"
+	S3WebIdentityTokenPath = "/var/run/secrets/matrixorigin.io/s3-web-identity"
+
+	webIdentityTokenFile = "token"
+)
+
+// S3CredentialSource provides the credentials of an S3 storage to the containers that access the storage
+type S3CredentialSource interface {
+	// Inject injects the credentials to the container of the pod
+	Inject(podSpec *corev1.PodSpec, c *corev1.Container)
+}
+
+// GetS3CredentialSource returns the credential source configured for the S3 storage, nil is returned if
+// the credentials should be discovered from the environment
+func GetS3CredentialSource(s3 *v1alpha1.S3Provider) S3CredentialSource {
+	switch {
+	case s3.WebIdentity != nil:
+		return &WebIdentitySource{WebIdentity: *s3.WebIdentity}
+	case s3.SecretRef != nil:
+		// the clients of all the providers read the keys from the env of aws
+		return NewS3SecretSource(s3, AWSAccessKeyID, AWSSecretAccessKey)
+	default:
+		return nil
+	}
+}
+
+// InjectS3Credential injects the credentials of the S3 storage to the container if configured
+func InjectS3Credential(s3 *v1alpha1.S3Provider, podSpec *corev1.PodSpec, c *corev1.Container) {
+	if src := GetS3CredentialSource(s3); src != nil {
+		src.Inject(podSpec, c)
+	}
+}
+
+// S3SecretKeys returns the keys of the access key pair in the credential Secret of the S3 storage
+func S3SecretKeys(s3 *v1alpha1.S3Provider) (accessKey string, secretKey string) {
+	switch s3.GetProviderType() {
+	case v1alpha1.S3ProviderTypeGCS:
+		return GCSAccessKeyID, GCSSecretAccessKey
+	case v1alpha1.S3ProviderTypeOSS:
+		return OSSAccessKeyID, OSSAccessKeySecret
+	default:
+		return AWSAccessKeyID, AWSSecretAccessKey
+	}
+}
+
+// NewS3SecretSource returns the SecretSource that injects the keys in the credential Secret of
+// the S3 storage as the given env
+func NewS3SecretSource(s3 *v1alpha1.S3Provider, accessKeyEnv, secretKeyEnv string) *SecretSource {
+	accessKey, secretKey := S3SecretKeys(s3)
+	return &SecretSource{
+		SecretRef:    *s3.SecretRef,
+		AccessKey:    accessKey,
+		SecretKey:    secretKey,
+		AccessKeyEnv: accessKeyEnv,
+		SecretKeyEnv: secretKeyEnv,
+	}
+}
+
+// SecretSource injects the static keys in the Secret as env
+type SecretSource struct {
+	SecretRef corev1.LocalObjectReference
+	// AccessKey and SecretKey are the keys of the access key pair in the Secret
+	AccessKey string
+	SecretKey string
+	// AccessKeyEnv and SecretKeyEnv are the names of the env that the keys are injected as
+	AccessKeyEnv string
+	SecretKeyEnv string
+}
+
+func (s *SecretSource) Inject(_ *corev1.PodSpec, c *corev1.Container) {
+	for _, kv := range [][2]string{{s.AccessKeyEnv, s.AccessKey}, {s.SecretKeyEnv, s.SecretKey}} {
+		c.Env = util.UpsertByKey(c.Env, corev1.EnvVar{Name: kv[0], ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: s.SecretRef,
+				Key:                  kv[1],
+			},
+		}}, util.EnvVarKey)
+	}
+}
+
+// WebIdentitySource mounts a projected service account token that is refreshed by the kubelet, and points
+// the AWS SDKs and cli to the token and the role
+type WebIdentitySource struct {
+	WebIdentity v1alpha1.S3WebIdentity
+}
+
+func (s *WebIdentitySource) Inject(podSpec *corev1.PodSpec, c *corev1.Container) {
+	w := s.WebIdentity
+	if w.ServiceAccountName != "" {
+		podSpec.ServiceAccountName = w.ServiceAccountName
+	}
+	podSpec.Volumes = util.UpsertByKey(podSpec.Volumes, corev1.Volume{
+		Name: S3WebIdentityVolume,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Audience:          w.GetAudience(),
+						ExpirationSeconds: w.ExpirationSeconds,
+						Path:              webIdentityTokenFile,
+					},
+				}},
+			},
+		},
+	}, func(v corev1.Volume) string {
+		return v.Name
+	})
+	c.VolumeMounts = util.UpsertByKey(c.VolumeMounts, corev1.VolumeMount{
+		Name:      S3WebIdentityVolume,
+		MountPath: S3WebIdentityTokenPath,
+		ReadOnly:  true,
+	}, func(v corev1.VolumeMount) string {
+		return v.Name
+	})
+	c.Env = util.UpsertByKey(c.Env, corev1.EnvVar{Name: AWSRoleARN, Value: w.RoleARN}, util.EnvVarKey)
+	c.Env = util.UpsertByKey(c.Env, corev1.EnvVar{Name: AWSWebIdentityTokenFile, Value: path.Join(S3WebIdentityTokenPath, webIdentityTokenFile)}, util.EnvVarKey)
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestInjectS3Credential(t *testing.T) {
+	gcs := v1alpha1.S3ProviderTypeGCS
+	tests := []struct {
+		name        string
+		s3          v1alpha1.S3Provider
+		wantEnv     []string
+		wantVolumes []string
+		wantSA      string
+		// wantKey is the key in the Secret that the access key id is read from
+		wantKey string
+	}{{
+		name:    "secret",
+		s3:      v1alpha1.S3Provider{SecretRef: &corev1.LocalObjectReference{Name: "aws"}},
+		wantEnv: []string{AWSAccessKeyID, AWSSecretAccessKey},
+		wantKey: AWSAccessKeyID,
+	}, {
+		name: "gcs secret",
+		s3: v1alpha1.S3Provider{
+			Type:      &gcs,
+			SecretRef: &corev1.LocalObjectReference{Name: "gcs"},
+		},
+		wantEnv: []string{AWSAccessKeyID, AWSSecretAccessKey},
+		wantKey: GCSAccessKeyID,
+	}, {
+		name: "web identity",
+		s3: v1alpha1.S3Provider{WebIdentity: &v1alpha1.S3WebIdentity{
+			RoleARN:            "arn:aws:iam::123456789012:role/mo",
+			ServiceAccountName: "mo",
+		}},
+		wantEnv:     []string{AWSRoleARN, AWSWebIdentityTokenFile},
+		wantVolumes: []string{S3WebIdentityVolume},
+		wantSA:      "mo",
+	}, {
+		name: "environment",
+		s3:   v1alpha1.S3Provider{},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "main"}}}
+			c := &podSpec.Containers[0]
+			InjectS3Credential(&tt.s3, podSpec, c)
+			// inject twice to ensure the injection is idempotent
+			InjectS3Credential(&tt.s3, podSpec, c)
+			var env, volumes []string
+			for _, e := range c.Env {
+				env = append(env, e.Name)
+			}
+			for _, v := range podSpec.Volumes {
+				volumes = append(volumes, v.Name)
+			}
+			if diff := cmp.Diff(tt.wantEnv, env); diff != "" {
+				t.Errorf("InjectS3Credential() env diff:\n %s", diff)
+			}
+			if diff := cmp.Diff(tt.wantVolumes, volumes); diff != "" {
+				t.Errorf("InjectS3Credential() volumes diff:\n %s", diff)
+			}
+			if podSpec.ServiceAccountName != tt.wantSA {
+				t.Errorf("InjectS3Credential() serviceAccountName = %s, want %s", podSpec.ServiceAccountName, tt.wantSA)
+			}
+			if tt.wantKey != "" && c.Env[0].ValueFrom.SecretKeyRef.Key != tt.wantKey {
+				t.Errorf("InjectS3Credential() reads access key from %s, want %s", c.Env[0].ValueFrom.SecretKeyRef.Key, tt.wantKey)
+			}
+			if len(volumes) != len(c.VolumeMounts) {
+				t.Errorf("InjectS3Credential() mounts %d volumes, want %d", len(c.VolumeMounts), len(volumes))
+			}
+		})
+	}
+}
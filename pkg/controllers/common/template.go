@@ -50,6 +50,8 @@ const (
 	ActionRequiredLabelValue = "True"
 	// LogSetOwnerKey labels the owner of orphaned LogSet Pod that is left by failover
 	LogSetOwnerKey = "matrixorigin.io/logset-owner"
+	// DNSetOwnerKey labels the owner of orphaned DNSet Pod that is left by failover
+	DNSetOwnerKey = "matrixorigin.io/dnset-owner"
 
 	// PodNameEnvKey is the container environment variable to reflect the name of the Pod that runs the container
 	PodNameEnvKey = "POD_NAME"
@@ -18,6 +18,29 @@ const (
 	PreNameLabelKey = "matrixorigin.io/pre-name"
 	PreUUIDLabelKey = "matrixorigin.io/pre-uuid"
 
+	// BackupScheduleLabelKey and BackupScheduleNamespaceLabelKey identify the BackupSchedule that
+	// produces the BackupJob and the Backup, Backup is cluster scoped so the namespace is recorded as well
+	BackupScheduleLabelKey          = "matrixorigin.io/backup-schedule"
+	BackupScheduleNamespaceLabelKey = "matrixorigin.io/backup-schedule-namespace"
+
+	// BackupVerificationLabelKey and BackupVerificationNamespaceLabelKey identify the BackupVerification that
+	// creates the temporary cluster, the cluster might be created in another namespace
+	BackupVerificationLabelKey          = "matrixorigin.io/backup-verification"
+	BackupVerificationNamespaceLabelKey = "matrixorigin.io/backup-verification-namespace"
+
+	// BackupSourceKindLabelKey, BackupSourceLabelKey and BackupSourceNamespaceLabelKey identify the source
+	// of the Backup so that the backups can be listed per cluster
+	BackupSourceKindLabelKey      = "matrixorigin.io/backup-source-kind"
+	BackupSourceLabelKey          = "matrixorigin.io/backup-source"
+	BackupSourceNamespaceLabelKey = "matrixorigin.io/backup-source-namespace"
+	// BackupVersionLabelKey is the MO version of the backup source
+	BackupVersionLabelKey = "matrixorigin.io/backup-version"
+
+	// BackupRepositoryLabelKey and BackupRepositoryNamespaceLabelKey identify the BackupRepository that
+	// imports the Backup
+	BackupRepositoryLabelKey          = "matrixorigin.io/backup-repository"
+	BackupRepositoryNamespaceLabelKey = "matrixorigin.io/backup-repository-namespace"
+
 	// ReasonNoEnoughReadyStores means the resource fall into current condition due to there is no enough ready stores
 	ReasonNoEnoughReadyStores = "NoEnoughReadyStores"
 
@@ -20,6 +20,7 @@ import (
 
 	"github.com/matrixorigin/matrixone-operator/api/features"
 	"github.com/matrixorigin/matrixone-operator/pkg/controllers/logset"
+	"github.com/matrixorigin/matrixone-operator/pkg/mocli"
 	"github.com/matrixorigin/matrixone-operator/pkg/utils"
 
 	"github.com/go-errors/errors"
@@ -36,15 +37,23 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
 const (
 	storeDownTimeout = 1 * time.Minute
 	reSyncAfter      = 10 * time.Second
+
+	// failoverDeletionFinalizer hold the pod that chosen to be deleted until human confirmation
+	failoverDeletionFinalizer = "matrixorigin.io/confirm-deletion"
 )
 
-type Actor struct{}
+type Actor struct {
+	FailoverEnabled bool
+	// ClientMgr is used to confirm the TN store failure with HAKeeper before failover
+	ClientMgr *mocli.MORPCClientManager
+}
 
 var _ recon.Actor[*v1alpha1.DNSet] = &Actor{}
 
@@ -52,6 +61,9 @@ type WithResources struct {
 	*Actor
 	sts *kruise.StatefulSet
 	svc *corev1.Service
+
+	// failed is the TN stores that are failed in both kubernetes and HAKeeper
+	failed []v1alpha1.Store
 }
 
 func (d *Actor) with(sts *kruise.StatefulSet, svc *corev1.Service) *WithResources {
@@ -116,9 +128,23 @@ func (d *Actor) Observe(ctx *recon.Context[*v1alpha1.DNSet]) (recon.Action[*v1al
 		}
 	}
 
+	var failed []v1alpha1.Store
+	if d.FailoverEnabled && len(dn.Status.StoresFailedFor(dn.Spec.GetStoreFailureTimeout().Duration)) > 0 {
+		down, err := d.downTNStores(ctx)
+		if err != nil {
+			// failover requires confirmation from HAKeeper, skip this round
+			ctx.Log.Error(err, "failed to query TN stores from HAKeeper")
+		}
+		failed = storesToRepair(dn, down)
+	}
+
 	switch {
 	case dn.Spec.Replicas != *sts.Spec.Replicas:
 		return d.with(sts, svc).Scale, nil
+	case len(failed) > 0:
+		r := d.with(sts, svc)
+		r.failed = failed
+		return r.Repair, nil
 	}
 
 	// reservedOrdinals is only used in the service-addresses branch of buildDNSetConfigMap.
@@ -183,6 +209,29 @@ func (d *Actor) Finalize(ctx *recon.Context[*v1alpha1.DNSet]) (bool, error) {
 			return false, nil
 		}
 	}
+	// cleanup orphaned Pod that left by failover
+	podList := &corev1.PodList{}
+	err := ctx.List(podList, client.InNamespace(dn.Namespace), client.MatchingLabels(map[string]string{
+		common.DNSetOwnerKey: dn.Name,
+	}))
+	if err != nil {
+		return false, err
+	}
+	if len(podList.Items) > 0 {
+		var errs error
+		for i := range podList.Items {
+			pod := &podList.Items[i]
+			if controllerutil.RemoveFinalizer(pod, failoverDeletionFinalizer) {
+				errs = multierr.Append(errs, ctx.Update(pod))
+			}
+			errs = multierr.Append(errs, ctx.Delete(pod))
+		}
+		if errs != nil {
+			return false, errs
+		}
+		// check whether pods are cleaned in next reconcile
+		return false, nil
+	}
 	if features.DefaultFeatureGate.Enabled(features.S3Reclaim) && dn.Deps.LogSet != nil {
 		err := v1alpha1.RemoveBucketFinalizer(ctx.Context, ctx.Client, dn.Deps.LogSet.ObjectMeta, utils.MakeHashFinalizer(v1alpha1.BucketDNFinalizerPrefix, dn))
 		if err != nil {
@@ -200,7 +249,7 @@ func (d *Actor) Create(ctx *recon.Context[*v1alpha1.DNSet]) error {
 	dnSet := buildDNSet(dn)
 	syncReplicas(dn, dnSet)
 	syncPodMeta(dn, dnSet)
-	syncPodSpec(dn, dnSet, ctx.Dep.Deps.LogSet.Spec.SharedStorage)
+	syncPodSpec(dn, dnSet, ctx.Dep.Deps.LogSet.Spec.SharedStorage, ctx.Dep.Deps.LogSet.Spec.ETLStorage)
 	syncPersistentVolumeClaim(dn, dnSet)
 
 	var reservedOrdinals []int
@@ -244,6 +293,53 @@ func (r *WithResources) Scale(ctx *recon.Context[*v1alpha1.DNSet]) error {
 	})
 }
 
+// Repair replaces one failed TN store at a time by reserving its ordinal, the new store will
+// be created with a new ordinal and the config of it will be rendered in later reconciliation.
+func (r *WithResources) Repair(ctx *recon.Context[*v1alpha1.DNSet]) error {
+	if !r.FailoverEnabled || len(r.failed) == 0 {
+		return nil
+	}
+	// like LogSet, only a minority of the stores can be replaced automatically, a single TN
+	// can still be replaced once
+	limit := max(int(ctx.Obj.Spec.Replicas)/2, 1)
+	if len(r.failed) > limit {
+		ctx.Log.Info("majority failure might happen, wait for human intervention")
+		return nil
+	}
+	if len(r.sts.Spec.ReserveOrdinals) >= limit {
+		ctx.Log.Info("failover limit has reached, only minority failover can be safely automated", "limit", limit)
+		return nil
+	}
+	candidate := r.failed[0]
+	ctx.Log.Info("repair dnset", "pod", candidate.PodName)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ctx.Obj.Namespace,
+			Name:      candidate.PodName,
+		},
+	}
+	if ctx.Obj.Spec.GetFailedPodStrategy() == v1alpha1.FailedPodStrategyOrphan {
+		err := ctx.Patch(pod, func() error {
+			controllerutil.AddFinalizer(pod, failoverDeletionFinalizer)
+			// mark the pod as need external action and cleanup all old labels
+			pod.Labels = map[string]string{
+				common.ActionRequiredLabelKey: common.ActionRequiredLabelValue,
+				common.DNSetOwnerKey:          ctx.Obj.Name,
+			}
+			return nil
+		})
+		if err != nil {
+			return errors.WrapPrefix(err, "cannot orphan the victim pod", 0)
+		}
+	}
+	ordinal, err := util.PodOrdinal(candidate.PodName)
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	r.sts.Spec.ReserveOrdinals = util.Upsert(r.sts.Spec.ReserveOrdinals, ordinal)
+	return ctx.Update(r.sts)
+}
+
 func (r *WithResources) Update(ctx *recon.Context[*v1alpha1.DNSet]) error {
 	return ctx.Update(r.sts)
 }
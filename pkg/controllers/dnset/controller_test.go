@@ -258,7 +258,7 @@ func TestDNSetVolumeMount(t *testing.T) {
 					},
 				},
 			}
-			syncPodSpec(tt.dnset, tt.sts, tt.sp)
+			syncPodSpec(tt.dnset, tt.sts, tt.sp, nil)
 			if tt.dnset.Spec.CacheVolume == nil {
 				// if cacheVolume not set, volumeClaimTemplates should be 0
 				// dataVolumeMount should not be created.
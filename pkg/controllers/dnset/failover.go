@@ -0,0 +1,94 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnset
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/controller-runtime/pkg/util"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/mocli"
+	logpb "github.com/matrixorigin/matrixone/pkg/pb/logservice"
+)
+
+// downTNStores queries HAKeeper for the TN stores that have timed out. nil is returned if the
+// HAKeeper client is not configured or the LogSet is not ready to serve requests.
+func (d *Actor) downTNStores(ctx *recon.Context[*v1alpha1.DNSet]) ([]logpb.TNStore, error) {
+	if d.ClientMgr == nil || ctx.Dep == nil {
+		return nil, nil
+	}
+	ls := ctx.Dep.Deps.LogSet
+	if ls == nil || ls.Status.Discovery == nil || !recon.IsReady(ls) {
+		return nil, nil
+	}
+	cs, err := d.ClientMgr.GetClient(ls)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get HAKeeper client", 0)
+	}
+	timeout, cancel := context.WithTimeout(ctx, mocli.DefaultRPCTimeout)
+	defer cancel()
+	details, err := cs.Client.GetClusterDetails(timeout)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get cluster details", 0)
+	}
+	var down []logpb.TNStore
+	for _, tn := range details.TNStores {
+		if tn.State == logpb.TimeoutState {
+			down = append(down, tn)
+		}
+	}
+	return down, nil
+}
+
+// storesToRepair returns the TN stores that have been failed in both kubernetes and HAKeeper
+// for longer than the StoreFailureTimeout
+func storesToRepair(dn *v1alpha1.DNSet, down []logpb.TNStore) []v1alpha1.Store {
+	var stores []v1alpha1.Store
+	for _, store := range dn.Status.StoresFailedFor(dn.Spec.GetStoreFailureTimeout().Duration) {
+		if failedInHAKeeper(dn, store.PodName, down) {
+			stores = append(stores, store)
+		}
+	}
+	return stores
+}
+
+// failedInHAKeeper matches the pod against the down TN stores reported by HAKeeper. The
+// service address is always derived from the pod DNS name while the UUID is only derived from
+// the pod ordinal when DNS based identity is disabled, so both are checked.
+func failedInHAKeeper(dn *v1alpha1.DNSet, podName string, down []logpb.TNStore) bool {
+	addr := fmt.Sprintf("%s.%s.%s.svc:", podName, headlessSvcName(dn), dn.Namespace)
+	var uuid string
+	if !dn.GetDNSBasedIdentity() {
+		ordinal, err := util.PodOrdinal(podName)
+		if err == nil {
+			uuid = encodeOrdinal(ordinal)
+		}
+	}
+	for _, tn := range down {
+		if strings.HasPrefix(tn.ServiceAddress, addr) || (uuid != "" && tn.UUID == uuid) {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeOrdinal encode the pod ordinal to the TN UUID, keep in sync with the start script
+func encodeOrdinal(ordinal int) string {
+	return fmt.Sprintf("00000000-0000-0000-0000-1%011x", ordinal)
+}
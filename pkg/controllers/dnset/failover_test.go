@@ -0,0 +1,125 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/matrixorigin/controller-runtime/pkg/fake"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	logpb "github.com/matrixorigin/matrixone/pkg/pb/logservice"
+	. "github.com/onsi/gomega"
+	kruisev1 "github.com/openkruise/kruise-api/apps/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_storesToRepair(t *testing.T) {
+	failedAt := metav1.NewTime(time.Now().Add(-time.Hour))
+	dnWith := func(dnsBased bool) *v1alpha1.DNSet {
+		return &v1alpha1.DNSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+			Spec: v1alpha1.DNSetSpec{
+				PodSet: v1alpha1.PodSet{DNSBasedIdentity: pointer.Bool(dnsBased)},
+			},
+			Status: v1alpha1.DNSetStatus{FailoverStatus: v1alpha1.FailoverStatus{
+				FailedStores: []v1alpha1.Store{
+					{PodName: "test-dn-0", Phase: v1alpha1.StorePhaseDown, LastTransitionTime: failedAt},
+					{PodName: "test-dn-1", Phase: v1alpha1.StorePhaseDown, LastTransitionTime: metav1.Now()},
+				},
+			}},
+		}
+	}
+	tests := []struct {
+		name   string
+		dn     *v1alpha1.DNSet
+		down   []logpb.TNStore
+		expect []string
+	}{{
+		name:   "not confirmed by HAKeeper",
+		dn:     dnWith(false),
+		expect: nil,
+	}, {
+		name:   "matched by uuid",
+		dn:     dnWith(false),
+		down:   []logpb.TNStore{{UUID: encodeOrdinal(0)}, {UUID: encodeOrdinal(1)}},
+		expect: []string{"test-dn-0"},
+	}, {
+		name:   "matched by service address",
+		dn:     dnWith(true),
+		down:   []logpb.TNStore{{UUID: "random", ServiceAddress: "test-dn-0.test-dn-headless.default.svc:41010"}},
+		expect: []string{"test-dn-0"},
+	}, {
+		name:   "uuid is not ordinal based",
+		dn:     dnWith(true),
+		down:   []logpb.TNStore{{UUID: encodeOrdinal(0)}},
+		expect: nil,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			var got []string
+			for _, s := range storesToRepair(tt.dn, tt.down) {
+				got = append(got, s.PodName)
+			}
+			g.Expect(got).To(Equal(tt.expect))
+		})
+	}
+}
+
+func TestWithResources_RepairLimit(t *testing.T) {
+	s := newScheme()
+	dn := &v1alpha1.DNSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec:       v1alpha1.DNSetSpec{PodSet: v1alpha1.PodSet{Replicas: 1}},
+	}
+	failed := []v1alpha1.Store{{PodName: "test-tn-0"}}
+	tests := []struct {
+		name     string
+		reserved []int
+		failed   []v1alpha1.Store
+		expect   []int
+	}{{
+		name:   "replace the failed store",
+		failed: failed,
+		expect: []int{0},
+	}, {
+		name:     "limit reached",
+		reserved: []int{0},
+		failed:   []v1alpha1.Store{{PodName: "test-tn-1"}},
+		expect:   []int{0},
+	}, {
+		name:   "majority failure",
+		failed: append(failed, v1alpha1.Store{PodName: "test-tn-1"}),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			sts := &kruisev1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-tn"},
+				Spec:       kruisev1.StatefulSetSpec{ReserveOrdinals: tt.reserved},
+			}
+			cli := &fake.Client{Client: fake.KubeClientBuilder().WithScheme(s).WithObjects(sts).Build()}
+			ctx := fake.NewContext(dn.DeepCopy(), cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+			r := &WithResources{Actor: &Actor{FailoverEnabled: true}, sts: sts, failed: tt.failed}
+			g.Expect(r.Repair(ctx)).To(Succeed())
+			g.Expect(cli.Get(ctx, client.ObjectKeyFromObject(sts), sts)).To(Succeed())
+			g.Expect(sts.Spec.ReserveOrdinals).To(Equal(tt.expect))
+		})
+	}
+}
@@ -135,7 +135,7 @@ func syncPodMeta(dn *v1alpha1.DNSet, cs *kruise.StatefulSet) {
 	dn.Spec.Overlay.OverlayPodMeta(&cs.Spec.Template.ObjectMeta)
 }
 
-func syncPodSpec(dn *v1alpha1.DNSet, sts *kruise.StatefulSet, sp v1alpha1.SharedStorageProvider) {
+func syncPodSpec(dn *v1alpha1.DNSet, sts *kruise.StatefulSet, sp v1alpha1.SharedStorageProvider, etl *v1alpha1.SharedStorageProvider) {
 	volumeMountsList := []corev1.VolumeMount{
 		{
 			Name:      common.ConfigVolume,
@@ -191,6 +191,7 @@ func syncPodSpec(dn *v1alpha1.DNSet, sts *kruise.StatefulSet, sp v1alpha1.Shared
 	specRef.NodeSelector = dn.Spec.NodeSelector
 
 	common.SetStorageProviderConfig(sp, specRef)
+	common.SetETLStorageProviderConfig(etl, specRef)
 	common.SyncTopology(dn.Spec.TopologyEvenSpread, specRef, sts.Spec.Selector)
 
 	dn.Spec.Overlay.OverlayPodSpec(specRef)
@@ -221,7 +222,7 @@ func buildDNSetConfigMap(dn *v1alpha1.DNSet, ls *v1alpha1.LogSet, reservedOrdina
 	} else {
 		conf.Set([]string{"hakeeper-client", "service-addresses"}, logset.HaKeeperSvcAddrs(ls, reservedOrdinals))
 	}
-	conf.MergeDeep(common.FileServiceConfig(fmt.Sprintf("%s/%s", common.DataPath, common.DataDir), ls.Spec.SharedStorage, &dn.Spec.SharedStorageCache))
+	conf.MergeDeep(common.FileServiceConfig(fmt.Sprintf("%s/%s", common.DataPath, common.DataDir), ls.Spec.SharedStorage, ls.Spec.ETLStorage, &dn.Spec.SharedStorageCache))
 	conf.Set([]string{"service-type"}, serviceType)
 	conf.Set([]string{configAlias, "listen-address"}, getListenAddress())
 	conf.Set([]string{configAlias, "lockservice", "listen-address"}, fmt.Sprintf("0.0.0.0:%d", common.LockServicePort))
@@ -293,7 +294,7 @@ func syncPods(ctx *recon.Context[*v1alpha1.DNSet], sts *kruise.StatefulSet, rese
 		sts.Spec.Template.Annotations[common.ConfigSuffixAnno] = configSuffix
 	}
 	if ctx.Dep != nil {
-		syncPodSpec(ctx.Obj, sts, ctx.Dep.Deps.LogSet.Spec.SharedStorage)
+		syncPodSpec(ctx.Obj, sts, ctx.Dep.Deps.LogSet.Spec.SharedStorage, ctx.Dep.Deps.LogSet.Spec.ETLStorage)
 	}
 
 	return common.SyncConfigMap(ctx, &sts.Spec.Template.Spec, cm, ctx.Obj.Spec.GetOperatorVersion())
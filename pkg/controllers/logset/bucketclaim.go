@@ -28,43 +28,67 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+// bucketStorage is an S3 storage of the LogSet that is claimed by a BucketClaim
+type bucketStorage struct {
+	s3 *v1alpha1.S3Provider
+	// claimName is the name of the BucketClaim created for the storage
+	claimName string
+}
+
+// bucketStorages returns the S3 storages of the LogSet, the dedicated ETL storage is claimed separately
+func bucketStorages(ls *v1alpha1.LogSet) []bucketStorage {
+	var storages []bucketStorage
+	if s3 := ls.Spec.SharedStorage.S3; s3 != nil {
+		storages = append(storages, bucketStorage{s3: s3, claimName: fmt.Sprintf("bucket-%s", ls.ObjectMeta.UID)})
+	}
+	if s3 := ls.Spec.GetETLS3(); s3 != nil {
+		storages = append(storages, bucketStorage{s3: s3, claimName: fmt.Sprintf("bucket-etl-%s", ls.ObjectMeta.UID)})
+	}
+	return storages
+}
+
 func (r *Actor) syncBucketEverRunningAnn(ctx *recon.Context[*v1alpha1.LogSet]) error {
 	if !features.DefaultFeatureGate.Enabled(features.S3Reclaim) {
 		return nil
 	}
-	ls := ctx.Obj
-	// skip if no s3 share storage configuration
-	if ls.Spec.SharedStorage.S3 == nil {
-		return nil
-	}
-
-	bucket, err := v1alpha1.ClaimedBucket(ctx.Client, ls.Spec.SharedStorage.S3)
-	if err != nil || bucket == nil {
-		// skip if there is an error, or bucket does not exist
-		return err
+	for _, bs := range bucketStorages(ctx.Obj) {
+		bucket, err := v1alpha1.ClaimedBucket(ctx.Client, bs.s3)
+		if err != nil {
+			return err
+		}
+		if bucket == nil {
+			// skip if bucket does not exist
+			continue
+		}
+		if err := v1alpha1.SetBucketEverRunningAnn(ctx.Context, ctx.Client, bucket); err != nil {
+			return err
+		}
 	}
-	return v1alpha1.SetBucketEverRunningAnn(ctx.Context, ctx.Client, bucket)
+	return nil
 }
 
 func (r *Actor) syncBucketClaim(ctx *recon.Context[*v1alpha1.LogSet], sts *kruisev1.StatefulSet) error {
 	if !features.DefaultFeatureGate.Enabled(features.S3Reclaim) {
 		return nil
 	}
-
-	ls := ctx.Obj
-	// skip if no s3 share storage configuration
-	if ls.Spec.SharedStorage.S3 == nil {
-		return nil
+	for _, bs := range bucketStorages(ctx.Obj) {
+		if err := r.syncBucketClaimOf(ctx, sts, bs); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	bucket, err := v1alpha1.ClaimedBucket(ctx.Client, ls.Spec.SharedStorage.S3)
+func (r *Actor) syncBucketClaimOf(ctx *recon.Context[*v1alpha1.LogSet], sts *kruisev1.StatefulSet, bs bucketStorage) error {
+	ls := ctx.Obj
+	bucket, err := v1alpha1.ClaimedBucket(ctx.Client, bs.s3)
 	if err != nil {
 		return err
 	}
 
 	// create new bucket if no bucket found
 	if bucket == nil {
-		return r.createNewBucket(ctx, sts)
+		return r.createNewBucket(ctx, sts, bs)
 	}
 	if bucket.Status.BindTo != "" &&
 		bucket.Status.BindTo != v1alpha1.BucketBindToMark(ls.ObjectMeta) {
@@ -73,7 +97,7 @@ func (r *Actor) syncBucketClaim(ctx *recon.Context[*v1alpha1.LogSet], sts *kruis
 
 	targetBucket := bucket.DeepCopy()
 	controllerutil.AddFinalizer(targetBucket, v1alpha1.BucketDataFinalizer)
-	targetBucket.Spec.S3 = ls.Spec.SharedStorage.S3
+	targetBucket.Spec.S3 = bs.s3
 	targetBucket.Status.BindTo = v1alpha1.BucketBindToMark(ls.ObjectMeta)
 	targetBucket.Status.State = v1alpha1.StatusInUse
 	sort.Strings(targetBucket.Finalizers)
@@ -91,14 +115,18 @@ func (r *Actor) finalizeBucket(ctx *recon.Context[*v1alpha1.LogSet]) (success bo
 	if !features.DefaultFeatureGate.Enabled(features.S3Reclaim) {
 		return true, nil
 	}
-
-	ls := ctx.Obj
-	// skip if no s3 share storage configuration
-	if ls.Spec.SharedStorage.S3 == nil {
-		return true, nil
+	for _, bs := range bucketStorages(ctx.Obj) {
+		success, err := r.finalizeBucketOf(ctx, bs)
+		if err != nil || !success {
+			return success, err
+		}
 	}
+	return true, nil
+}
 
-	claimedBucket, err := v1alpha1.ClaimedBucket(ctx.Client, ls.Spec.SharedStorage.S3)
+func (r *Actor) finalizeBucketOf(ctx *recon.Context[*v1alpha1.LogSet], bs bucketStorage) (success bool, err error) {
+	ls := ctx.Obj
+	claimedBucket, err := v1alpha1.ClaimedBucket(ctx.Client, bs.s3)
 	if err != nil {
 		return false, err
 	}
@@ -116,11 +144,7 @@ func (r *Actor) finalizeBucket(ctx *recon.Context[*v1alpha1.LogSet]) (success bo
 		return false, nil
 	}
 
-	s3RetentionPolicy := ls.Spec.GetS3RetentionPolicy()
-	if s3RetentionPolicy == nil {
-		// generally we will never enter this block
-		return true, nil
-	}
+	s3RetentionPolicy := ls.Spec.S3RetentionPolicyOf(bs.s3)
 	switch *s3RetentionPolicy {
 	case v1alpha1.PVCRetentionPolicyRetain:
 		claimedBucket.Status.State = v1alpha1.StatusReleased
@@ -138,18 +162,17 @@ func (r *Actor) finalizeBucket(ctx *recon.Context[*v1alpha1.LogSet]) (success bo
 	}
 }
 
-func (r *Actor) createNewBucket(ctx *recon.Context[*v1alpha1.LogSet], sts *kruisev1.StatefulSet) error {
+func (r *Actor) createNewBucket(ctx *recon.Context[*v1alpha1.LogSet], sts *kruisev1.StatefulSet, bs bucketStorage) error {
 	ls := ctx.Obj
-	newBucketName := fmt.Sprintf("bucket-%s", ls.ObjectMeta.UID)
 	bucket := &v1alpha1.BucketClaim{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:       newBucketName,
+			Name:       bs.claimName,
 			Namespace:  ls.Namespace,
 			Finalizers: []string{v1alpha1.BucketDataFinalizer},
-			Labels:     map[string]string{v1alpha1.BucketUniqLabel: v1alpha1.UniqueBucketLabel(ls.Spec.SharedStorage.S3)},
+			Labels:     map[string]string{v1alpha1.BucketUniqLabel: v1alpha1.UniqueBucketLabel(bs.s3)},
 		},
 		Spec: v1alpha1.BucketClaimSpec{
-			S3:             ls.Spec.SharedStorage.S3,
+			S3:             bs.s3,
 			LogSetTemplate: sts.Spec.Template,
 		},
 		Status: v1alpha1.BucketClaimStatus{
@@ -0,0 +1,73 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logset
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/matrixorigin/controller-runtime/pkg/fake"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/api/features"
+	. "github.com/onsi/gomega"
+	kruisev1 "github.com/openkruise/kruise-api/apps/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestActor_etlBucketClaim(t *testing.T) {
+	g := NewGomegaWithT(t)
+	g.Expect(features.DefaultMutableFeatureGate.Set("s3Reclaim=true")).To(Succeed())
+	defer func() {
+		g.Expect(features.DefaultMutableFeatureGate.Set("s3Reclaim=false")).To(Succeed())
+	}()
+
+	retain := v1alpha1.PVCRetentionPolicyRetain
+	del := v1alpha1.PVCRetentionPolicyDelete
+	ls := &v1alpha1.LogSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "uid"},
+		Spec: v1alpha1.LogSetSpec{
+			SharedStorage: v1alpha1.SharedStorageProvider{
+				S3: &v1alpha1.S3Provider{Path: "data/mo", S3RetentionPolicy: &retain},
+			},
+			ETLStorage: &v1alpha1.SharedStorageProvider{
+				S3: &v1alpha1.S3Provider{Path: "etl/mo", S3RetentionPolicy: &del},
+			},
+		},
+	}
+	cli := fake.KubeClientBuilder().WithScheme(newScheme()).WithObjects(ls).Build()
+	ctx := fake.NewContext(ls, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+	r := &Actor{}
+
+	g.Expect(r.syncBucketClaim(ctx, &kruisev1.StatefulSet{})).To(Succeed())
+	shared := &v1alpha1.BucketClaim{}
+	g.Expect(cli.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "bucket-uid"}, shared)).To(Succeed())
+	g.Expect(shared.Spec.S3.Path).To(Equal("data/mo"))
+	etl := &v1alpha1.BucketClaim{}
+	g.Expect(cli.Get(context.TODO(), client.ObjectKey{Namespace: "default", Name: "bucket-etl-uid"}, etl)).To(Succeed())
+	g.Expect(etl.Spec.S3.Path).To(Equal("etl/mo"))
+	g.Expect(etl.Status.BindTo).To(Equal("default/test"))
+
+	ok, err := r.finalizeBucket(ctx)
+	g.Expect(err).To(Succeed())
+	g.Expect(ok).To(BeTrue())
+	g.Expect(cli.Get(context.TODO(), client.ObjectKeyFromObject(shared), shared)).To(Succeed())
+	g.Expect(shared.Status.State).To(Equal(v1alpha1.StatusReleased))
+	g.Expect(shared.DeletionTimestamp).To(BeNil())
+	g.Expect(cli.Get(context.TODO(), client.ObjectKeyFromObject(etl), etl)).To(Succeed())
+	g.Expect(etl.Status.State).To(Equal(v1alpha1.StatusDeleting))
+	g.Expect(etl.DeletionTimestamp).NotTo(BeNil())
+}
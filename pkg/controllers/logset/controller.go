@@ -29,6 +29,7 @@ import (
 	"github.com/matrixorigin/controller-runtime/pkg/util"
 	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
 	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	"github.com/matrixorigin/matrixone-operator/pkg/mocli"
 	kruisev1 "github.com/openkruise/kruise-api/apps/v1beta1"
 	"github.com/samber/lo"
 	"go.uber.org/multierr"
@@ -49,6 +50,9 @@ var _ recon.Actor[*v1alpha1.LogSet] = &Actor{}
 
 type Actor struct {
 	FailoverEnabled bool
+
+	// ClientMgr is used to query the cluster state from HAKeeper, optional
+	ClientMgr *mocli.MORPCClientManager
 }
 
 type WithResources struct {
@@ -87,7 +91,16 @@ func (r *Actor) Observe(ctx *recon.Context[*v1alpha1.LogSet]) (recon.Action[*v1a
 		return nil, errors.WrapPrefix(err, "list logservice pods", 0)
 	}
 
-	common.CollectStoreStatus(&ls.Status.FailoverStatus, podList.Items)
+	var storeFns []common.StoreFn
+	hs, err := r.observeHAKeeper(ctx)
+	if err != nil {
+		// HAKeeper state is complementary, keep the previous one and fallback to kubernetes state
+		ctx.Log.Info("error observe HAKeeper state", "error", err.Error())
+	} else if hs != nil {
+		ls.Status.HAKeeper = hs
+		storeFns = append(storeFns, haKeeperStoreFn(hs))
+	}
+	common.CollectStoreStatus(&ls.Status.FailoverStatus, podList.Items, storeFns...)
 	if len(ls.Status.AvailableStores) >= int(ls.Spec.Replicas) {
 		ls.Status.SetCondition(metav1.Condition{
 			Type:   recon.ConditionTypeReady,
@@ -0,0 +1,150 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logset
+
+import (
+	"context"
+	"sort"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/controller-runtime/pkg/util"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	"github.com/matrixorigin/matrixone-operator/pkg/mocli"
+	logpb "github.com/matrixorigin/matrixone/pkg/pb/logservice"
+)
+
+// hakeeperShardID is the ID of the log shard that backs HAKeeper
+const hakeeperShardID = 0
+
+// observeHAKeeper queries the cluster state from HAKeeper. HAKeeper is only reachable after the
+// logset get ready, so nil is returned if the logset is not ready or the client is not configured.
+func (r *Actor) observeHAKeeper(ctx *recon.Context[*v1alpha1.LogSet]) (*v1alpha1.HAKeeperStatus, error) {
+	ls := ctx.Obj
+	if r.ClientMgr == nil || ls.Status.Discovery == nil || !recon.IsReady(ls) {
+		return nil, nil
+	}
+	cs, err := r.ClientMgr.GetClient(ls)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get HAKeeper client", 0)
+	}
+	timeout, cancel := context.WithTimeout(ctx, mocli.DefaultRPCTimeout)
+	defer cancel()
+	details, err := cs.Client.GetClusterDetails(timeout)
+	if err != nil {
+		return nil, errors.WrapPrefix(err, "get cluster details", 0)
+	}
+	return buildHAKeeperStatus(details), nil
+}
+
+// buildHAKeeperStatus converts the cluster details reported by HAKeeper to LogSet status
+func buildHAKeeperStatus(details logpb.ClusterDetails) *v1alpha1.HAKeeperStatus {
+	hs := &v1alpha1.HAKeeperStatus{
+		TNStoreCount: int32(len(details.TNStores)),
+		CNStoreCount: int32(len(details.CNStores)),
+	}
+	// each log store reports the shard info it observed, take the most recent one
+	shards := map[uint64]logpb.LogShardInfo{}
+	for _, store := range details.LogStores {
+		if store.State == logpb.TimeoutState {
+			hs.DownStores = append(hs.DownStores, v1alpha1.HAKeeperStore{
+				UUID:           store.UUID,
+				Type:           v1alpha1.HAKeeperStoreTypeLog,
+				ServiceAddress: store.ServiceAddress,
+			})
+		}
+		for _, replica := range store.Replicas {
+			info := replica.LogShardInfo
+			if prev, ok := shards[info.ShardID]; ok && !newerShardInfo(info, prev) {
+				continue
+			}
+			shards[info.ShardID] = info
+		}
+	}
+	for _, tn := range details.TNStores {
+		if tn.State == logpb.TimeoutState {
+			hs.DownStores = append(hs.DownStores, v1alpha1.HAKeeperStore{
+				UUID:           tn.UUID,
+				Type:           v1alpha1.HAKeeperStoreTypeTN,
+				ServiceAddress: tn.ServiceAddress,
+			})
+		}
+	}
+	for _, cn := range details.CNStores {
+		if cn.State == logpb.TimeoutState {
+			hs.DownStores = append(hs.DownStores, v1alpha1.HAKeeperStore{
+				UUID:           cn.UUID,
+				Type:           v1alpha1.HAKeeperStoreTypeCN,
+				ServiceAddress: cn.ServiceAddress,
+			})
+		}
+	}
+	for _, info := range shards {
+		shard := v1alpha1.LogShardStatus{
+			ShardID:  info.ShardID,
+			LeaderID: info.LeaderID,
+			Epoch:    info.Epoch,
+			Term:     info.Term,
+		}
+		for replicaID, storeID := range info.Replicas {
+			shard.Replicas = append(shard.Replicas, v1alpha1.LogReplicaStatus{
+				ReplicaID: replicaID,
+				StoreID:   storeID,
+			})
+		}
+		sort.Slice(shard.Replicas, func(i, j int) bool {
+			return shard.Replicas[i].ReplicaID < shard.Replicas[j].ReplicaID
+		})
+		if info.ShardID == hakeeperShardID {
+			hs.Term = info.Term
+		}
+		hs.LogShards = append(hs.LogShards, shard)
+	}
+	sort.Slice(hs.LogShards, func(i, j int) bool {
+		return hs.LogShards[i].ShardID < hs.LogShards[j].ShardID
+	})
+	sort.Slice(hs.DownStores, func(i, j int) bool {
+		return hs.DownStores[i].UUID < hs.DownStores[j].UUID
+	})
+	return hs
+}
+
+func newerShardInfo(info, than logpb.LogShardInfo) bool {
+	if info.Epoch != than.Epoch {
+		return info.Epoch > than.Epoch
+	}
+	return info.Term > than.Term
+}
+
+// haKeeperStoreFn marks the log store as down if HAKeeper considers it down, even if
+// the Pod is still available in kubernetes (e.g. the process hangs or is partitioned)
+func haKeeperStoreFn(hs *v1alpha1.HAKeeperStatus) common.StoreFn {
+	down := map[string]bool{}
+	for _, s := range hs.DownStores {
+		if s.Type == v1alpha1.HAKeeperStoreTypeLog {
+			down[s.UUID] = true
+		}
+	}
+	return func(store *v1alpha1.Store) {
+		ordinal, err := util.PodOrdinal(store.PodName)
+		if err != nil {
+			return
+		}
+		if down[encodeOrdinal(ordinal)] {
+			store.Phase = v1alpha1.StorePhaseDown
+		}
+	}
+}
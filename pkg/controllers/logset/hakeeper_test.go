@@ -0,0 +1,102 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logset
+
+import (
+	"testing"
+
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	logpb "github.com/matrixorigin/matrixone/pkg/pb/logservice"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_buildHAKeeperStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+	shard := func(id, epoch, term, leader uint64) logpb.LogReplicaInfo {
+		return logpb.LogReplicaInfo{LogShardInfo: logpb.LogShardInfo{
+			ShardID:  id,
+			Epoch:    epoch,
+			Term:     term,
+			LeaderID: leader,
+			Replicas: map[uint64]string{
+				3: encodeOrdinal(2),
+				1: encodeOrdinal(0),
+				2: encodeOrdinal(1),
+			},
+		}}
+	}
+	hs := buildHAKeeperStatus(logpb.ClusterDetails{
+		LogStores: []logpb.LogStore{{
+			UUID:     encodeOrdinal(0),
+			Replicas: []logpb.LogReplicaInfo{shard(0, 1, 5, 1), shard(1, 2, 3, 2)},
+		}, {
+			UUID: encodeOrdinal(1),
+			// stale view of the shards
+			Replicas: []logpb.LogReplicaInfo{shard(0, 1, 4, 2), shard(1, 1, 7, 1)},
+		}, {
+			UUID:  encodeOrdinal(2),
+			State: logpb.TimeoutState,
+		}},
+		TNStores: []logpb.TNStore{{UUID: "tn"}},
+		CNStores: []logpb.CNStore{{UUID: "cn-0"}, {UUID: "cn-1", State: logpb.TimeoutState}},
+	})
+	g.Expect(hs.Term).To(Equal(uint64(5)))
+	g.Expect(hs.TNStoreCount).To(Equal(int32(1)))
+	g.Expect(hs.CNStoreCount).To(Equal(int32(2)))
+	g.Expect(hs.LogShards).To(HaveLen(2))
+	g.Expect(hs.LogShards[0].LeaderID).To(Equal(uint64(1)))
+	g.Expect(hs.LogShards[1].Epoch).To(Equal(uint64(2)))
+	g.Expect(hs.LogShards[1].LeaderID).To(Equal(uint64(2)))
+	g.Expect(hs.LogShards[0].Replicas).To(Equal([]v1alpha1.LogReplicaStatus{
+		{ReplicaID: 1, StoreID: encodeOrdinal(0)},
+		{ReplicaID: 2, StoreID: encodeOrdinal(1)},
+		{ReplicaID: 3, StoreID: encodeOrdinal(2)},
+	}))
+	g.Expect(hs.DownStores).To(ConsistOf(
+		v1alpha1.HAKeeperStore{UUID: encodeOrdinal(2), Type: v1alpha1.HAKeeperStoreTypeLog},
+		v1alpha1.HAKeeperStore{UUID: "cn-1", Type: v1alpha1.HAKeeperStoreTypeCN},
+	))
+}
+
+func Test_haKeeperStoreFn(t *testing.T) {
+	g := NewGomegaWithT(t)
+	pod := func(name string) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{
+					Type:               corev1.PodReady,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.Unix(0, 0),
+				}},
+			},
+		}
+	}
+	status := &v1alpha1.FailoverStatus{}
+	common.CollectStoreStatus(status, []corev1.Pod{pod("test-log-0"), pod("test-log-1")}, haKeeperStoreFn(&v1alpha1.HAKeeperStatus{
+		DownStores: []v1alpha1.HAKeeperStore{
+			{UUID: encodeOrdinal(1), Type: v1alpha1.HAKeeperStoreTypeLog},
+			// TN store that has the same ordinal should not affect the log store
+			{UUID: encodeOrdinal(0), Type: v1alpha1.HAKeeperStoreTypeTN},
+		},
+	}))
+	g.Expect(status.AvailableStores).To(HaveLen(1))
+	g.Expect(status.AvailableStores[0].PodName).To(Equal("test-log-0"))
+	g.Expect(status.FailedStores).To(HaveLen(1))
+	g.Expect(status.FailedStores[0].PodName).To(Equal("test-log-1"))
+}
@@ -112,6 +112,7 @@ func syncPodSpec(ls *v1alpha1.LogSet, specRef *corev1.PodSpec) {
 	}}
 	specRef.NodeSelector = ls.Spec.NodeSelector
 	common.SetStorageProviderConfig(ls.Spec.SharedStorage, specRef)
+	common.SetETLStorageProviderConfig(ls.Spec.ETLStorage, specRef)
 	common.SyncTopology(ls.Spec.TopologyEvenSpread, specRef, &metav1.LabelSelector{MatchLabels: common.SubResourceLabels(ls)})
 	ls.Spec.Overlay.OverlayPodSpec(specRef)
 	common.SetupMemoryFsVolume(specRef, ls.Spec.MemoryFsSize)
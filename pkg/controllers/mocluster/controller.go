@@ -66,23 +66,23 @@ func (r *MatrixOneClusterActor) Observe(ctx *recon.Context[*v1alpha1.MatrixOneCl
 		return nil, nil
 	}
 	mo := ctx.Obj
-	if mo.Spec.RestoreFrom != nil && mo.Annotations[RestoreCompleteAnno] == "" {
+	if mo.IsRestoring() && mo.Annotations[RestoreCompleteAnno] == "" {
 		// do restore
-		backup := &v1alpha1.Backup{}
-		err := ctx.Get(types.NamespacedName{Name: *mo.Spec.RestoreFrom}, backup)
-		if err != nil {
-			return nil, errors.WrapPrefix(err, "error get backup", 0)
-		}
 		restore := &v1alpha1.RestoreJob{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: mo.Namespace,
 				Name:      fmt.Sprintf("restore-%s", mo.Name),
 			},
 			Spec: v1alpha1.RestoreJobSpec{
-				BackupName: backup.Name,
-				Target:     mo.Spec.LogService.SharedStorage,
+				Target: mo.Spec.LogService.SharedStorage,
 			},
 		}
+		backup := &v1alpha1.Backup{}
+		err := ctx.Get(types.NamespacedName{Name: *mo.Spec.RestoreFrom}, backup)
+		if err != nil {
+			return nil, errors.WrapPrefix(err, "error get backup", 0)
+		}
+		restore.Spec.BackupName = backup.Name
 		if err := recon.CreateOwnedOrUpdate(ctx, restore, func() error {
 			return nil
 		}); err != nil {
@@ -116,6 +116,9 @@ func (r *MatrixOneClusterActor) Up(ctx *recon.Context[*v1alpha1.MatrixOneCluster
 		return nil, errors.WrapPrefix(err, "init cluster credential", 0)
 	}
 
+	// the writes are quiesced by scaling CN and TN in, e.g. to migrate the shared storage
+	quiesce := mo.Annotations[v1alpha1.ClusterQuiesceAnno]
+
 	// sync specs
 	ls := &v1alpha1.LogSet{
 		ObjectMeta: v1alpha1.LogSetKey(mo),
@@ -129,7 +132,7 @@ func (r *MatrixOneClusterActor) Up(ctx *recon.Context[*v1alpha1.MatrixOneCluster
 		setPodSetDefault(&ls.Spec.PodSet, mo)
 		setOverlay(&ls.Spec.Overlay, mo)
 		ls.Spec.Image = mo.LogSetImage()
-		if mo.Spec.RestoreFrom != nil {
+		if mo.IsRestoring() {
 			ls.Spec.InitialConfig.RestoreFrom = pointer.String(defaultHKDataPath)
 		}
 		return nil
@@ -142,6 +145,10 @@ func (r *MatrixOneClusterActor) Up(ctx *recon.Context[*v1alpha1.MatrixOneCluster
 		setPodSetDefault(&dn.Spec.PodSet, mo)
 		setOverlay(&dn.Spec.Overlay, mo)
 		dn.Spec.Image = mo.DnSetImage()
+		if quiesce == v1alpha1.QuiesceAll {
+			// pause the TN after the CNs are drained
+			dn.Spec.Replicas = 0
+		}
 		return nil
 	})
 	if err != nil {
@@ -203,6 +210,10 @@ func (r *MatrixOneClusterActor) Up(ctx *recon.Context[*v1alpha1.MatrixOneCluster
 				return e.Name
 			})
 			tpl.Spec.Image = common.CNSetImage(mo, &g.CNSetSpec)
+			if quiesce != "" {
+				// the CN stores are drained on scaling in
+				tpl.Spec.Replicas = 0
+			}
 			return nil
 		})
 		if err != nil {
@@ -313,6 +324,9 @@ func (r *MatrixOneClusterActor) Up(ctx *recon.Context[*v1alpha1.MatrixOneCluster
 			mo.Status.Host = firstCN.Status.Host
 			mo.Status.Port = firstCN.Status.Port
 		}
+		if err := r.syncPITR(ctx); err != nil {
+			return nil, errors.WrapPrefix(err, "sync PITR", 0)
+		}
 		return nil, nil
 	}
 	return nil, recon.ErrReSync("matrixone cluster is not ready", resyncAfter)
@@ -325,6 +339,7 @@ func (r *MatrixOneClusterActor) initializeMetricUser(ctx *recon.Context[*v1alpha
 		return errors.WrapPrefix(err, "init metric credential", 0)
 	}
 	sqlcli := mosql.NewClient(fmt.Sprintf("%s:%d", host, 6001), ctx.Client, types.NamespacedName{Namespace: mo.Namespace, Name: mo.Status.CredentialRef.Name})
+	defer sqlcli.Close()
 	if _, err := sqlcli.Query(context.TODO(), fmt.Sprintf("CREATE USER IF NOT EXISTS `%s` identified by '%s'", metricSec.Data[usernameKey], metricSec.Data[passwordKey])); err != nil {
 		return errors.WrapPrefix(err, "create operator user", 0)
 	}
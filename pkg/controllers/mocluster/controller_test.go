@@ -264,6 +264,38 @@ func TestMatrixOneClusterActor_Observe(t *testing.T) {
 			g.Expect(c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "test"}, ls)).To(Succeed())
 			g.Expect(*ls.Spec.PVCRetentionPolicy).To(Equal(v1alpha1.PVCRetentionPolicyRetain))
 		},
+	}, {
+		name: "quiesceCN",
+		mo: func() *v1alpha1.MatrixOneCluster {
+			m := tpl.DeepCopy()
+			m.Annotations = map[string]string{v1alpha1.ClusterQuiesceAnno: v1alpha1.QuiesceCN}
+			return m
+		}(),
+		objects: nil,
+		expect: func(g *WithT, _ *v1alpha1.MatrixOneCluster, err error, c client.Client) {
+			cn := &v1alpha1.CNSet{}
+			g.Expect(c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "test-tp"}, cn)).To(Succeed())
+			g.Expect(cn.Spec.Replicas).To(Equal(int32(0)))
+			dn := &v1alpha1.DNSet{}
+			g.Expect(c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "test"}, dn)).To(Succeed())
+			g.Expect(dn.Spec.Replicas).To(Equal(int32(2)))
+		},
+	}, {
+		name: "quiesceAll",
+		mo: func() *v1alpha1.MatrixOneCluster {
+			m := tpl.DeepCopy()
+			m.Annotations = map[string]string{v1alpha1.ClusterQuiesceAnno: v1alpha1.QuiesceAll}
+			return m
+		}(),
+		objects: nil,
+		expect: func(g *WithT, _ *v1alpha1.MatrixOneCluster, err error, c client.Client) {
+			cn := &v1alpha1.CNSet{}
+			g.Expect(c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "test-tp"}, cn)).To(Succeed())
+			g.Expect(cn.Spec.Replicas).To(Equal(int32(0)))
+			dn := &v1alpha1.DNSet{}
+			g.Expect(c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "test"}, dn)).To(Succeed())
+			g.Expect(dn.Spec.Replicas).To(Equal(int32(0)))
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,71 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mocluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/mosql"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// PITRName is the name of the cluster level PITR managed by the operator
+const PITRName = "mo_operator_pitr"
+
+// syncPITR reconciles the cluster level PITR with the spec and refreshes the recoverable window
+func (r *MatrixOneClusterActor) syncPITR(ctx *recon.Context[*v1alpha1.MatrixOneCluster]) error {
+	mo := ctx.Obj
+	spec := mo.Spec.PITR
+	if spec == nil && mo.Status.PITR == nil {
+		return nil
+	}
+	if mo.Status.CredentialRef == nil {
+		return errors.New("credential of the cluster is not initialized")
+	}
+	sqlcli := mosql.NewClient(fmt.Sprintf("%s:%d", mo.Status.Host, mo.Status.Port), ctx.Client, types.NamespacedName{Namespace: mo.Namespace, Name: mo.Status.CredentialRef.Name})
+	defer sqlcli.Close()
+	status := mo.Status.PITR
+	switch {
+	case spec == nil:
+		if _, err := sqlcli.Query(context.TODO(), fmt.Sprintf("DROP PITR IF EXISTS `%s`", status.Name)); err != nil {
+			return errors.WrapPrefix(err, "drop PITR", 0)
+		}
+		mo.Status.PITR = nil
+		return nil
+	case status == nil:
+		if _, err := sqlcli.Query(context.TODO(), fmt.Sprintf("CREATE PITR IF NOT EXISTS `%s` FOR CLUSTER RANGE %d '%s'", PITRName, spec.Range, spec.GetUnit())); err != nil {
+			return errors.WrapPrefix(err, "create PITR", 0)
+		}
+		status = &v1alpha1.PITRStatus{Name: PITRName, CreatedTime: metav1.Now()}
+		mo.Status.PITR = status
+	case status.Range != spec.Range || status.Unit != spec.GetUnit():
+		if _, err := sqlcli.Query(context.TODO(), fmt.Sprintf("ALTER PITR `%s` RANGE %d '%s'", status.Name, spec.Range, spec.GetUnit())); err != nil {
+			return errors.WrapPrefix(err, "alter PITR", 0)
+		}
+	}
+	status.Range = spec.Range
+	status.Unit = spec.GetUnit()
+	// the window is refreshed in minutes to avoid updating the status in every reconciliation
+	now := time.Now().Truncate(time.Minute)
+	status.RecoverableFrom = &metav1.Time{Time: status.EarliestRecoverableTime(now)}
+	status.RecoverableTo = &metav1.Time{Time: now}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mocluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/matrixorigin/controller-runtime/pkg/fake"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/mosql"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMatrixOneClusterActor_syncPITR(t *testing.T) {
+	g := NewGomegaWithT(t)
+	mosql.NewClient = mosql.NewFakeClient
+	mo := &v1alpha1.MatrixOneCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: v1alpha1.MatrixOneClusterSpec{
+			PITR: &v1alpha1.PITRSpec{Range: 7, Unit: v1alpha1.PITRUnitDay},
+		},
+		Status: v1alpha1.MatrixOneClusterStatus{
+			CredentialRef: &corev1.LocalObjectReference{Name: "test-credential"},
+		},
+	}
+	cli := fake.KubeClientBuilder().WithScheme(newScheme()).WithObjects(mo).Build()
+	ctx := fake.NewContext(mo, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+	r := &MatrixOneClusterActor{}
+
+	// the recoverable window starts from the creation of the PITR
+	g.Expect(r.syncPITR(ctx)).To(Succeed())
+	status := mo.Status.PITR
+	g.Expect(status).NotTo(BeNil())
+	g.Expect(status.Name).To(Equal(PITRName))
+	g.Expect(status.Range).To(Equal(int32(7)))
+	g.Expect(status.RecoverableFrom.Time).To(Equal(status.CreatedTime.Time))
+
+	// the window is limited by the range
+	mo.Spec.PITR = &v1alpha1.PITRSpec{Range: 2, Unit: v1alpha1.PITRUnitHour}
+	status.CreatedTime = metav1.NewTime(time.Now().Add(-48 * time.Hour))
+	g.Expect(r.syncPITR(ctx)).To(Succeed())
+	g.Expect(status.Unit).To(Equal(v1alpha1.PITRUnitHour))
+	g.Expect(status.RecoverableTo.Sub(status.RecoverableFrom.Time)).To(Equal(2 * time.Hour))
+
+	// disabled
+	mo.Spec.PITR = nil
+	g.Expect(r.syncPITR(ctx)).To(Succeed())
+	g.Expect(mo.Status.PITR).To(BeNil())
+}
@@ -0,0 +1,212 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxyroute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	"github.com/matrixorigin/matrixone-operator/pkg/mocli"
+	logpb "github.com/matrixorigin/matrixone/pkg/pb/logservice"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// resyncInterval is the interval to re-evaluate the routes since the readiness of CN stores changes over time
+	resyncInterval = 30 * time.Second
+
+	reasonInvalidRules = "InvalidRules"
+)
+
+// Actor reconciles ProxyRoute
+type Actor struct {
+	ClientMgr *mocli.MORPCClientManager
+}
+
+var _ recon.Actor[*v1alpha1.ProxyRoute] = &Actor{}
+
+func (r *Actor) Observe(ctx *recon.Context[*v1alpha1.ProxyRoute]) (recon.Action[*v1alpha1.ProxyRoute], error) {
+	route := ctx.Obj
+	if route.Deps.LogSet == nil {
+		route.Status.SetCondition(metav1.Condition{
+			Type:    recon.ConditionTypeReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  reasonInvalidRules,
+			Message: "logset dependency is required",
+		})
+		return nil, nil
+	}
+	groups, err := listCNGroups(ctx, route)
+	if err != nil {
+		return nil, err
+	}
+	if msg := validateRules(route.Spec.Rules, groups); msg != "" {
+		// keep the labels that already pushed until the rules are fixed
+		route.Status.SetCondition(metav1.Condition{
+			Type:    recon.ConditionTypeReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  reasonInvalidRules,
+			Message: msg,
+		})
+		return nil, nil
+	}
+	res := computeRoutes(route.Spec.Rules, groups)
+	for _, g := range groups {
+		for i := range g.pods {
+			if err := r.syncPodRoute(ctx, &g.pods[i], res.labels[g.pods[i].Name]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	route.Status.Rules = res.rules
+	route.Status.SetCondition(metav1.Condition{
+		Type:    recon.ConditionTypeReady,
+		Status:  metav1.ConditionTrue,
+		Message: "routes synced",
+	})
+	return nil, recon.ErrReSync("re-evaluate routes", resyncInterval)
+}
+
+func (r *Actor) Finalize(ctx *recon.Context[*v1alpha1.ProxyRoute]) (bool, error) {
+	pods, err := common.ListPods(ctx, client.InNamespace(ctx.Obj.Namespace), client.MatchingLabels{
+		common.ComponentLabelKey: "CNSet",
+	})
+	if err != nil {
+		return false, errors.WrapPrefix(err, "list CN pods", 0)
+	}
+	for i := range pods {
+		if err := r.syncPodRoute(ctx, &pods[i], nil); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// listCNGroups lists the CN groups that connect to the LogSet of the ProxyRoute, pooling CNSets are excluded
+// since the labels of pooled CNs are managed by CNClaims
+func listCNGroups(ctx *recon.Context[*v1alpha1.ProxyRoute], route *v1alpha1.ProxyRoute) ([]cnGroup, error) {
+	csList := &v1alpha1.CNSetList{}
+	if err := ctx.List(csList, client.InNamespace(route.Namespace)); err != nil {
+		return nil, errors.WrapPrefix(err, "list CNSets", 0)
+	}
+	var groups []cnGroup
+	for i := range csList.Items {
+		cs := &csList.Items[i]
+		if cs.Deps.LogSet == nil || cs.Deps.LogSet.Name != route.Deps.LogSet.Name {
+			continue
+		}
+		if cs.Spec.PodManagementPolicy != nil && *cs.Spec.PodManagementPolicy == v1alpha1.PodManagementPolicyPooling {
+			continue
+		}
+		pods, err := common.ListPods(ctx, client.InNamespace(cs.Namespace), client.MatchingLabels{
+			common.InstanceLabelKey:  cs.Name,
+			common.ComponentLabelKey: "CNSet",
+		})
+		if err != nil {
+			return nil, errors.WrapPrefix(err, "list CN pods", 0)
+		}
+		slices.SortFunc(pods, func(a, b corev1.Pod) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+		groups = append(groups, cnGroup{cnSet: cs, pods: pods})
+	}
+	slices.SortFunc(groups, func(a, b cnGroup) int {
+		return strings.Compare(a.cnSet.Name, b.cnSet.Name)
+	})
+	return groups, nil
+}
+
+// syncPodRoute records the route labels of the ProxyRoute in the pod annotation and pushes the merged
+// CN labels of the pod to HAKeeper, the labels are removed if the pod is not selected by the ProxyRoute
+func (r *Actor) syncPodRoute(ctx *recon.Context[*v1alpha1.ProxyRoute], pod *corev1.Pod, labels []v1alpha1.CNLabel) error {
+	key := common.ProxyRouteAnnoPrefix + ctx.Obj.Name
+	var desired string
+	if len(labels) > 0 {
+		b, err := json.Marshal(labels)
+		if err != nil {
+			return errors.WrapPrefix(err, "marshal route labels", 0)
+		}
+		desired = string(b)
+	}
+	current, ok := pod.Annotations[key]
+	if current == desired && ok == (desired != "") {
+		return nil
+	}
+	if err := ctx.Patch(pod, func() error {
+		if desired == "" {
+			delete(pod.Annotations, key)
+			return nil
+		}
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[key] = desired
+		return nil
+	}); err != nil {
+		return errors.WrapPrefix(err, fmt.Sprintf("patch route labels of pod %s", pod.Name), 0)
+	}
+	// the cnstore controller keeps the labels in sync afterwards, push once here so that the route takes effect
+	// without waiting for the next resync
+	if pod.DeletionTimestamp != nil || v1alpha1.IsPoolingPolicy(pod) {
+		return nil
+	}
+	return r.pushLabels(ctx, pod)
+}
+
+func (r *Actor) pushLabels(ctx *recon.Context[*v1alpha1.ProxyRoute], pod *corev1.Pod) error {
+	if r.ClientMgr == nil || ctx.Obj.Deps.LogSet == nil {
+		return nil
+	}
+	ls := &v1alpha1.LogSet{}
+	if err := ctx.Get(client.ObjectKeyFromObject(ctx.Obj.Deps.LogSet), ls); err != nil {
+		return errors.WrapPrefix(err, "error get logset", 0)
+	}
+	if !recon.IsReady(ls) {
+		return recon.ErrReSync("logset is not ready, cannot update CN labels", resyncInterval)
+	}
+	cnLabels, err := common.PodCNLabels(pod)
+	if err != nil {
+		return err
+	}
+	hc, err := r.ClientMgr.GetClient(ls)
+	if err != nil {
+		return errors.WrapPrefix(err, "get HAKeeper client", 0)
+	}
+	timeout, cancel := context.WithTimeout(ctx, mocli.DefaultRPCTimeout)
+	defer cancel()
+	uid := v1alpha1.GetCNPodUUID(pod)
+	if err := hc.Client.UpdateCNLabel(timeout, logpb.CNStoreLabel{
+		UUID:   uid,
+		Labels: common.ToStoreLabels(cnLabels),
+	}); err != nil {
+		return errors.WrapPrefix(err, fmt.Sprintf("update CN labels of store %s", uid), 0)
+	}
+	return nil
+}
+
+func (r *Actor) Reconcile(mgr manager.Manager) error {
+	return recon.Setup[*v1alpha1.ProxyRoute](&v1alpha1.ProxyRoute{}, "proxyroute", mgr, r)
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxyroute
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/matrixorigin/controller-runtime/pkg/util"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// cnGroup is a CNSet and its pods, sorted by name
+type cnGroup struct {
+	cnSet *v1alpha1.CNSet
+	pods  []corev1.Pod
+}
+
+// routeResult is the computed result of a ProxyRoute
+type routeResult struct {
+	// labels is the route labels of each pod, keyed by the pod name
+	labels map[string][]v1alpha1.CNLabel
+	rules  []v1alpha1.ProxyRouteRuleStatus
+}
+
+// validateRules validates the rules against the CN groups, an error message is returned if the rules are invalid
+func validateRules(rules []v1alpha1.ProxyRouteRule, groups []cnGroup) string {
+	var msgs []string
+	names := map[string]bool{}
+	for _, rule := range rules {
+		if names[rule.Name] {
+			msgs = append(msgs, fmt.Sprintf("rule %s: duplicated rule name", rule.Name))
+		}
+		names[rule.Name] = true
+		if len(rule.Match.Labels()) == 0 {
+			msgs = append(msgs, fmt.Sprintf("rule %s: match is empty", rule.Name))
+		}
+		if len(rule.Backends) == 0 {
+			msgs = append(msgs, fmt.Sprintf("rule %s: backends is empty", rule.Name))
+		}
+		for i, b := range append(slices.Clone(rule.Backends), rule.Fallbacks...) {
+			if len(b.Selector) == 0 {
+				msgs = append(msgs, fmt.Sprintf("rule %s: selector of backend %d is empty", rule.Name, i))
+				continue
+			}
+			if len(selectGroups(b, groups)) == 0 {
+				msgs = append(msgs, fmt.Sprintf("rule %s: backend %d matches no CN group", rule.Name, i))
+			}
+		}
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// computeRoutes selects the CN stores of each rule and computes the route labels of each pod
+func computeRoutes(rules []v1alpha1.ProxyRouteRule, groups []cnGroup) routeResult {
+	res := routeResult{labels: map[string][]v1alpha1.CNLabel{}}
+	for _, rule := range rules {
+		st := v1alpha1.ProxyRouteRuleStatus{Name: rule.Name}
+		stores := selectStores(rule.Backends, groups)
+		if len(stores) == 0 && len(rule.Fallbacks) > 0 {
+			stores = selectStores(rule.Fallbacks, groups)
+			st.Fallback = true
+		}
+		for _, s := range stores {
+			res.labels[s.PodName] = common.MergeCNLabels(res.labels[s.PodName], rule.Match.Labels()...)
+		}
+		st.Stores = stores
+		res.rules = append(res.rules, st)
+	}
+	return res
+}
+
+// selectStores selects the ready CN stores of the backends, for each CN group matched by a backend,
+// the first weight percent (rounded up) of the ready pods are selected so that the selection is stable
+func selectStores(backends []v1alpha1.ProxyRouteBackend, groups []cnGroup) []v1alpha1.ProxyRouteStore {
+	var stores []v1alpha1.ProxyRouteStore
+	selected := map[string]bool{}
+	for _, b := range backends {
+		for _, g := range selectGroups(b, groups) {
+			var ready []corev1.Pod
+			for _, pod := range g.pods {
+				if pod.DeletionTimestamp == nil && util.IsPodReady(&pod) {
+					ready = append(ready, pod)
+				}
+			}
+			n := (len(ready)*int(b.GetWeight()) + 99) / 100
+			for _, pod := range ready[:n] {
+				if selected[pod.Name] {
+					continue
+				}
+				selected[pod.Name] = true
+				stores = append(stores, v1alpha1.ProxyRouteStore{
+					UUID:    v1alpha1.GetCNPodUUID(&pod),
+					PodName: pod.Name,
+					CNSet:   g.cnSet.Name,
+				})
+			}
+		}
+	}
+	return stores
+}
+
+// selectGroups returns the CN groups that have all the labels in the selector of the backend
+func selectGroups(b v1alpha1.ProxyRouteBackend, groups []cnGroup) []cnGroup {
+	var matched []cnGroup
+	for _, g := range groups {
+		if hasLabels(g.cnSet.Spec.Labels, b.Selector) {
+			matched = append(matched, g)
+		}
+	}
+	return matched
+}
+
+func hasLabels(labels []v1alpha1.CNLabel, selector []v1alpha1.CNLabel) bool {
+	for _, s := range selector {
+		i := slices.IndexFunc(labels, func(l v1alpha1.CNLabel) bool {
+			return l.Key == s.Key
+		})
+		if i < 0 {
+			return false
+		}
+		for _, v := range s.Values {
+			if !slices.Contains(labels[i].Values, v) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxyroute
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func Test_computeRoutes(t *testing.T) {
+	group := func(name string, label string, ready ...bool) cnGroup {
+		g := cnGroup{cnSet: &v1alpha1.CNSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1alpha1.CNSetSpec{
+				Labels: []v1alpha1.CNLabel{{Key: "role", Values: []string{label}}},
+			},
+		}}
+		for i, r := range ready {
+			status := corev1.ConditionFalse
+			if r {
+				status = corev1.ConditionTrue
+			}
+			g.pods = append(g.pods, corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-%d", name, i)},
+				Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{
+					Type:   corev1.PodReady,
+					Status: status,
+				}}},
+			})
+		}
+		return g
+	}
+	backend := func(label string, weight *int32) v1alpha1.ProxyRouteBackend {
+		return v1alpha1.ProxyRouteBackend{
+			Selector: []v1alpha1.CNLabel{{Key: "role", Values: []string{label}}},
+			Weight:   weight,
+		}
+	}
+	podsOf := func(st v1alpha1.ProxyRouteRuleStatus) []string {
+		var pods []string
+		for _, s := range st.Stores {
+			pods = append(pods, s.PodName)
+		}
+		return pods
+	}
+	tests := []struct {
+		name           string
+		rule           v1alpha1.ProxyRouteRule
+		groups         []cnGroup
+		expectPods     []string
+		expectFallback bool
+	}{{
+		name: "all ready stores",
+		rule: v1alpha1.ProxyRouteRule{
+			Name:     "tp",
+			Match:    v1alpha1.ProxyRouteMatch{Accounts: []string{"acc1"}},
+			Backends: []v1alpha1.ProxyRouteBackend{backend("tp", nil)},
+		},
+		groups:     []cnGroup{group("tp", "tp", true, false, true), group("ap", "ap", true)},
+		expectPods: []string{"tp-0", "tp-2"},
+	}, {
+		name: "weighted",
+		rule: v1alpha1.ProxyRouteRule{
+			Name:     "tp",
+			Match:    v1alpha1.ProxyRouteMatch{Accounts: []string{"acc1"}},
+			Backends: []v1alpha1.ProxyRouteBackend{backend("tp", pointer.Int32(50))},
+		},
+		groups:     []cnGroup{group("tp", "tp", true, true, true)},
+		expectPods: []string{"tp-0", "tp-1"},
+	}, {
+		name: "fallback",
+		rule: v1alpha1.ProxyRouteRule{
+			Name:      "tp",
+			Match:     v1alpha1.ProxyRouteMatch{Users: []string{"u1"}},
+			Backends:  []v1alpha1.ProxyRouteBackend{backend("tp", nil)},
+			Fallbacks: []v1alpha1.ProxyRouteBackend{backend("ap", nil)},
+		},
+		groups:         []cnGroup{group("tp", "tp", false), group("ap", "ap", true)},
+		expectPods:     []string{"ap-0"},
+		expectFallback: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			rules := []v1alpha1.ProxyRouteRule{tt.rule}
+			g.Expect(validateRules(rules, tt.groups)).To(BeEmpty())
+			res := computeRoutes(rules, tt.groups)
+			g.Expect(res.rules).To(HaveLen(1))
+			g.Expect(podsOf(res.rules[0])).To(Equal(tt.expectPods))
+			g.Expect(res.rules[0].Fallback).To(Equal(tt.expectFallback))
+			for _, p := range tt.expectPods {
+				g.Expect(res.labels[p]).To(Equal(tt.rule.Match.Labels()))
+			}
+		})
+	}
+}
+
+func Test_validateRules(t *testing.T) {
+	g := NewGomegaWithT(t)
+	groups := []cnGroup{{cnSet: &v1alpha1.CNSet{
+		Spec: v1alpha1.CNSetSpec{Labels: []v1alpha1.CNLabel{{Key: "role", Values: []string{"tp"}}}},
+	}}}
+	rules := []v1alpha1.ProxyRouteRule{{
+		Name:  "r1",
+		Match: v1alpha1.ProxyRouteMatch{Accounts: []string{"acc1"}},
+		Backends: []v1alpha1.ProxyRouteBackend{{
+			Selector: []v1alpha1.CNLabel{{Key: "role", Values: []string{"ap"}}},
+		}},
+	}}
+	g.Expect(validateRules(rules, groups)).To(ContainSubstring("matches no CN group"))
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxyset
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/controller-runtime/pkg/util"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	"github.com/openkruise/kruise-api/apps/pub"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// autoscaleInterval is the interval to evaluate the autoscaling
+	autoscaleInterval = 30 * time.Second
+)
+
+// syncAutoscaling evaluates the desired replicas of the proxy from the client connections and records
+// the decision in status. The connections of each pod are also recorded as the pod deletion cost so that
+// the scale-in removes the pods with the fewest connections, the removed pods are drained by the lifecycle hooks.
+func syncAutoscaling(ctx *recon.Context[*v1alpha1.ProxySet]) error {
+	p := ctx.Obj
+	as := p.Spec.Autoscaling
+	if as == nil {
+		p.Status.Autoscaling = nil
+		return nil
+	}
+	podList := &corev1.PodList{}
+	if err := ctx.List(podList, client.InNamespace(p.Namespace), client.MatchingLabels(common.SubResourceLabels(p))); err != nil {
+		return errors.WrapPrefix(err, "list proxy pods", 0)
+	}
+	var total int32
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		state := pod.Labels[pub.LifecycleStateKey]
+		if pod.DeletionTimestamp != nil || state == string(pub.LifecycleStatePreparingDelete) {
+			// the connections on the removing pods are going to be drained to the remaining pods
+			continue
+		}
+		if pod.Status.Phase != corev1.PodRunning || !util.IsPodReady(pod) {
+			// the pods just scaled up have no IP yet and the restarting pods serve no connection,
+			// evaluate on the ready pods so that the scale-up is not blocked by them
+			continue
+		}
+		conns, err := scrapeConnections(ctx, pod)
+		if err != nil {
+			// evaluate on partial metrics of the ready pods may scale-in unexpectedly, skip this round
+			ctx.Log.Info("cannot count proxy connections, skip autoscaling", "pod", pod.Name, "error", err.Error())
+			return nil
+		}
+		total += conns
+		if err := patchDeletionCost(ctx, pod, conns); err != nil {
+			return err
+		}
+	}
+
+	current := p.GetReplicas()
+	desired := desiredReplicas(as, current, total)
+	now := time.Now()
+	if desired != current && inCooldown(as, p.Status.Autoscaling, desired > current, now) {
+		desired = current
+	}
+	st := &v1alpha1.ProxyAutoscalingStatus{
+		Replicas:    desired,
+		Connections: total,
+	}
+	if p.Status.Autoscaling != nil {
+		st.LastScaleTime = p.Status.Autoscaling.LastScaleTime
+	}
+	if desired != current {
+		ctx.Log.Info("scale proxy on connections", "connections", total, "from", current, "to", desired)
+		t := metav1.NewTime(now)
+		st.LastScaleTime = &t
+	}
+	p.Status.Autoscaling = st
+	return nil
+}
+
+// desiredReplicas computes the replicas that keep the connections per replica around the target,
+// no scaling is needed if the deviation is within the tolerance
+func desiredReplicas(as *v1alpha1.ProxyAutoscaling, current int32, connections int32) int32 {
+	target := int64(as.TargetConnectionsPerReplica)
+	if target <= 0 || current <= 0 {
+		return current
+	}
+	// compare in percentage to avoid floating point calculation
+	ratio := int64(connections) * 100 / (int64(current) * target)
+	tolerance := int64(as.GetTolerance())
+	if ratio >= 100-tolerance && ratio <= 100+tolerance {
+		return current
+	}
+	desired := int32((int64(connections) + target - 1) / target)
+	if desired > as.MaxReplicas {
+		desired = as.MaxReplicas
+	}
+	if desired < as.MinReplicas {
+		desired = as.MinReplicas
+	}
+	return desired
+}
+
+func inCooldown(as *v1alpha1.ProxyAutoscaling, st *v1alpha1.ProxyAutoscalingStatus, scaleUp bool, now time.Time) bool {
+	if st == nil || st.LastScaleTime == nil {
+		return false
+	}
+	cooldown := as.GetScaleDownCooldown()
+	if scaleUp {
+		cooldown = as.GetScaleUpCooldown()
+	}
+	return now.Sub(st.LastScaleTime.Time) < cooldown
+}
+
+func patchDeletionCost(ctx *recon.Context[*v1alpha1.ProxySet], pod *corev1.Pod, conns int32) error {
+	cost := strconv.Itoa(int(conns))
+	if pod.Annotations[common.DeletionCostAnno] == cost {
+		return nil
+	}
+	if err := ctx.Patch(pod, func() error {
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[common.DeletionCostAnno] = cost
+		return nil
+	}); err != nil {
+		return errors.WrapPrefix(err, "patch proxy pod deletion cost", 0)
+	}
+	return nil
+}
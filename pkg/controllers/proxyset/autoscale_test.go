@@ -0,0 +1,119 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxyset
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/matrixorigin/controller-runtime/pkg/fake"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+func Test_desiredReplicas(t *testing.T) {
+	as := &v1alpha1.ProxyAutoscaling{
+		MinReplicas:                 2,
+		MaxReplicas:                 5,
+		TargetConnectionsPerReplica: 100,
+	}
+	tests := []struct {
+		name        string
+		current     int32
+		connections int32
+		expect      int32
+	}{{
+		name:        "within tolerance",
+		current:     3,
+		connections: 320,
+		expect:      3,
+	}, {
+		name:        "scale up",
+		current:     3,
+		connections: 380,
+		expect:      4,
+	}, {
+		name:        "scale in",
+		current:     3,
+		connections: 150,
+		expect:      2,
+	}, {
+		name:        "bounded by max replicas",
+		current:     3,
+		connections: 1000,
+		expect:      5,
+	}, {
+		name:        "bounded by min replicas",
+		current:     3,
+		connections: 0,
+		expect:      2,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			g.Expect(desiredReplicas(as, tt.current, tt.connections)).To(Equal(tt.expect))
+		})
+	}
+}
+
+func Test_inCooldown(t *testing.T) {
+	g := NewGomegaWithT(t)
+	as := &v1alpha1.ProxyAutoscaling{}
+	now := time.Now()
+	lastScale := metav1.NewTime(now.Add(-time.Minute))
+	st := &v1alpha1.ProxyAutoscalingStatus{LastScaleTime: &lastScale}
+	g.Expect(inCooldown(as, nil, false, now)).To(BeFalse())
+	g.Expect(inCooldown(as, st, true, now)).To(BeFalse())
+	g.Expect(inCooldown(as, st, false, now)).To(BeTrue())
+	as.ScaleDownCooldown = &metav1.Duration{Duration: 30 * time.Second}
+	g.Expect(inCooldown(as, st, false, now)).To(BeFalse())
+}
+
+func Test_syncAutoscaling_skipNotReadyPods(t *testing.T) {
+	g := NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(v1alpha1.AddToScheme(s))
+	p := &v1alpha1.ProxySet{
+		ObjectMeta: metav1.ObjectMeta{Name: "proxy", Namespace: "default"},
+		Spec: v1alpha1.ProxySetSpec{
+			PodSet: v1alpha1.PodSet{Replicas: 2},
+			Autoscaling: &v1alpha1.ProxyAutoscaling{
+				MinReplicas:                 1,
+				MaxReplicas:                 5,
+				TargetConnectionsPerReplica: 100,
+			},
+		},
+	}
+	// the pod just scheduled has no IP to scrape
+	pending := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "proxy-0", Namespace: "default", Labels: common.SubResourceLabels(p)},
+		Status:     corev1.PodStatus{Phase: corev1.PodPending},
+	}
+	cli := &fake.Client{Client: fake.KubeClientBuilder().WithScheme(s).WithObjects(pending).Build()}
+	ctx := fake.NewContext(p, cli, fake.NewMockEventEmitter(gomock.NewController(t)))
+
+	g.Expect(syncAutoscaling(ctx)).To(Succeed())
+	g.Expect(p.Status.Autoscaling).NotTo(BeNil())
+	g.Expect(p.Status.Autoscaling.Connections).To(Equal(int32(0)))
+	g.Expect(p.Status.Autoscaling.Replicas).To(Equal(int32(1)))
+}
@@ -21,6 +21,7 @@ import (
 	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
 	"github.com/matrixorigin/controller-runtime/pkg/util"
 	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
 	kruisev1alpha1 "github.com/openkruise/kruise-api/apps/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -40,6 +41,9 @@ var _ recon.Actor[*v1alpha1.ProxySet] = &Actor{}
 
 func (r *Actor) Observe(ctx *recon.Context[*v1alpha1.ProxySet]) (recon.Action[*v1alpha1.ProxySet], error) {
 	p := ctx.Obj
+	if err := syncAutoscaling(ctx); err != nil {
+		return nil, err
+	}
 	cloneset := buildCloneSet(p)
 	err := recon.CreateOwnedOrUpdate(ctx, cloneset, func() error {
 		return syncCloneSet(ctx, p, cloneset)
@@ -55,7 +59,13 @@ func (r *Actor) Observe(ctx *recon.Context[*v1alpha1.ProxySet]) (recon.Action[*v
 	if err != nil {
 		return nil, errors.WrapPrefix(err, "sync service", 0)
 	}
-	if cloneset.Status.ReadyReplicas >= p.Spec.Replicas {
+	if err := common.SyncSQLExposure(ctx, svc, p.Spec.Exposure, port); err != nil {
+		return nil, errors.WrapPrefix(err, "sync exposure", 0)
+	}
+	if err := syncDraining(ctx); err != nil {
+		return nil, err
+	}
+	if cloneset.Status.ReadyReplicas >= p.GetReplicas() {
 		p.Status.SetCondition(metav1.Condition{
 			Type:    recon.ConditionTypeReady,
 			Status:  metav1.ConditionTrue,
@@ -63,10 +73,13 @@ func (r *Actor) Observe(ctx *recon.Context[*v1alpha1.ProxySet]) (recon.Action[*v
 		})
 		p.Status.Host = fmt.Sprintf("%s.%s", svc.Name, svc.Namespace)
 		p.Status.Port = ProxyPort
+		if p.Spec.Autoscaling != nil {
+			return nil, recon.ErrReSync("evaluate proxy autoscaling", autoscaleInterval)
+		}
 		return nil, nil
 	}
 	// proxy not ready
-	msg := fmt.Sprintf("proxy not ready, ready replicas: %d, desired replicas: %d", cloneset.Status.ReadyReplicas, p.Spec.Replicas)
+	msg := fmt.Sprintf("proxy not ready, ready replicas: %d, desired replicas: %d", cloneset.Status.ReadyReplicas, p.GetReplicas())
 	p.Status.SetCondition(metav1.Condition{
 		Type:    recon.ConditionTypeReady,
 		Status:  metav1.ConditionFalse,
@@ -0,0 +1,318 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxyset
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-errors/errors"
+	recon "github.com/matrixorigin/controller-runtime/pkg/reconciler"
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	"github.com/openkruise/kruise-api/apps/pub"
+	kruisev1alpha1 "github.com/openkruise/kruise-api/apps/v1alpha1"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	drainRetryInterval = 5 * time.Second
+	scrapeTimeout      = 5 * time.Second
+
+	// proxyConnectMetric counts the connections of proxy by type, the number of living connections
+	// is the number of accepted connections minus the number of closed connections (labeled as current)
+	proxyConnectMetric = "mo_proxy_connect_counter"
+
+	messageProxyReady    = "ProxyReady"
+	messageProxyDraining = "ProxyDraining"
+
+	// drainExitGracePeriod is the time for the proxy to exit after the preStop hook returns
+	drainExitGracePeriod = 30 * time.Second
+)
+
+// preStopScript holds the SIGTERM, which stops the proxy at once, until the living connections of the proxy
+// reach zero or the drain timeout expires. The MO proxy has no shutdown-drain signal of its own, so the hook
+// counts the connections from the metrics of the proxy like syncDraining does, which also covers the pods
+// that are deleted or evicted outside the lifecycle hooks of the CloneSet.
+const preStopScript = `deadline=$((SECONDS + %d))
+while [ "$SECONDS" -lt "$deadline" ]; do
+  exec 3<>/dev/tcp/127.0.0.1/%d || break
+  printf 'GET /metrics HTTP/1.0\r\n\r\n' >&3
+  conns=$(awk '/^%[3]s\{.*type="accepted"/ { a = $NF } /^%[3]s\{.*type="current"/ { c = $NF } END { printf "%%d", a - c }' <&3)
+  exec 3<&-
+  [ "${conns:-0}" -gt 0 ] || break
+  sleep 1
+done
+`
+
+// syncLifecycle configures the lifecycle hooks of the proxy pods so that the restart and deletion
+// of a pod wait for the connection draining
+func syncLifecycle(proxy *v1alpha1.ProxySet, cs *kruisev1alpha1.CloneSet) {
+	if !proxy.Spec.DrainEnabled() {
+		cs.Spec.Lifecycle = nil
+		return
+	}
+	cs.Spec.Lifecycle = &pub.Lifecycle{
+		PreDelete: &pub.LifecycleHook{
+			FinalizersHandler: []string{common.ProxyDrainingFinalizer},
+			MarkPodNotReady:   true,
+		},
+		// like CN, the readiness of in-place updated pod is controlled by our own readiness-gate
+		InPlaceUpdate: &pub.LifecycleHook{
+			FinalizersHandler: []string{common.ProxyDrainingFinalizer},
+		},
+	}
+}
+
+// syncDrainPodTemplate adds the readiness-gate that unregisters the draining pod from the service
+// endpoints, so that the draining proxy accepts no new connection, and extends the termination grace
+// period to cover the preStop hook
+func syncDrainPodTemplate(proxy *v1alpha1.ProxySet, tpl *corev1.PodTemplateSpec) {
+	if !proxy.Spec.DrainEnabled() {
+		return
+	}
+	common.AddReadinessGate(&tpl.Spec, common.ProxyReadiness)
+	tpl.Spec.TerminationGracePeriodSeconds = pointer.Int64(int64((proxy.Spec.GetDrainTimeout() + drainExitGracePeriod).Seconds()))
+}
+
+// syncDrainContainer sets the preStop hook that delays the shutdown of the proxy until the connections
+// are drained, the lifecycle in the overlay of the main container takes precedence
+func syncDrainContainer(proxy *v1alpha1.ProxySet, c *corev1.Container) {
+	if !proxy.Spec.DrainEnabled() {
+		c.Lifecycle = nil
+		return
+	}
+	c.Lifecycle = &corev1.Lifecycle{
+		PreStop: &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{"/bin/bash", "-c", fmt.Sprintf(preStopScript, int(proxy.Spec.GetDrainTimeout().Seconds()), common.MetricsPort, proxyConnectMetric)},
+			},
+		},
+	}
+}
+
+// syncDraining drains the connections of the proxy pods that are going to be restarted or deleted,
+// a re-sync error is returned if there are pods still draining
+func syncDraining(ctx *recon.Context[*v1alpha1.ProxySet]) error {
+	p := ctx.Obj
+	podList := &corev1.PodList{}
+	if err := ctx.List(podList, client.InNamespace(p.Namespace), client.MatchingLabels(common.SubResourceLabels(p))); err != nil {
+		return errors.WrapPrefix(err, "list proxy pods", 0)
+	}
+	previous := map[string]v1alpha1.ProxyDrainingStatus{}
+	for _, d := range p.Status.Draining {
+		previous[d.PodName] = d
+	}
+	var draining []v1alpha1.ProxyDrainingStatus
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.DeletionTimestamp != nil || !p.Spec.DrainEnabled() {
+			// the pod is being deleted or draining is disabled, release the pod
+			if err := completeDraining(ctx, pod); err != nil {
+				return err
+			}
+			continue
+		}
+		state := pod.Labels[pub.LifecycleStateKey]
+		if state != string(pub.LifecycleStatePreparingUpdate) && state != string(pub.LifecycleStatePreparingDelete) {
+			if err := onProxyNormal(ctx, pod); err != nil {
+				return err
+			}
+			continue
+		}
+		if !controllerutil.ContainsFinalizer(pod, common.ProxyDrainingFinalizer) {
+			// already drained, wait kruise to continue the update or deletion
+			continue
+		}
+		status, done, err := drainPod(ctx, pod, previous[pod.Name])
+		if err != nil {
+			return err
+		}
+		if !done {
+			draining = append(draining, status)
+		}
+	}
+	p.Status.Draining = draining
+	if len(draining) > 0 {
+		return recon.ErrReSync("wait for proxy connections draining", drainRetryInterval)
+	}
+	return nil
+}
+
+// onProxyNormal ensures the draining finalizer and marks the proxy ready to serve
+func onProxyNormal(ctx *recon.Context[*v1alpha1.ProxySet], pod *corev1.Pod) error {
+	if err := ctx.Patch(pod, func() error {
+		controllerutil.AddFinalizer(pod, common.ProxyDrainingFinalizer)
+		// cleanup the draining start time in case the deletion decision is withdrawn
+		delete(pod.Annotations, common.ProxyDrainingAnno)
+		return nil
+	}); err != nil {
+		return errors.WrapPrefix(err, "ensure finalizer for proxy pod", 0)
+	}
+	return patchProxyReadiness(ctx, pod, corev1.ConditionTrue, messageProxyReady)
+}
+
+// drainPod removes the pod from service endpoints and records the draining start time in the pod
+// annotation, then waits until the connections reach zero or the drain timeout expires
+func drainPod(ctx *recon.Context[*v1alpha1.ProxySet], pod *corev1.Pod, status v1alpha1.ProxyDrainingStatus) (v1alpha1.ProxyDrainingStatus, bool, error) {
+	if err := patchProxyReadiness(ctx, pod, corev1.ConditionFalse, messageProxyDraining); err != nil {
+		return status, false, err
+	}
+	startTime := time.Now()
+	if s, ok := pod.Annotations[common.ProxyDrainingAnno]; ok {
+		parsed, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return status, false, errors.Wrap(err, 0)
+		}
+		startTime = parsed
+	} else {
+		ctx.Log.Info("start draining proxy", "pod", pod.Name)
+		if err := ctx.Patch(pod, func() error {
+			if pod.Annotations == nil {
+				pod.Annotations = map[string]string{}
+			}
+			pod.Annotations[common.ProxyDrainingAnno] = startTime.Format(time.RFC3339)
+			return nil
+		}); err != nil {
+			return status, false, errors.WrapPrefix(err, "record draining start time", 0)
+		}
+	}
+
+	conns, err := scrapeConnections(ctx, pod)
+	if err != nil {
+		ctx.Log.Info("cannot count proxy connections, wait until drain timeout", "pod", pod.Name, "error", err.Error())
+		conns = -1
+	}
+	status = updateDrainingStatus(pod.Name, status, startTime, conns)
+	if conns == 0 || time.Since(startTime) > ctx.Obj.Spec.GetDrainTimeout() {
+		ctx.Log.Info("proxy draining completed", "pod", pod.Name, "remainingConnections", conns, "drainedConnections", status.DrainedConnections)
+		return status, true, completeDraining(ctx, pod)
+	}
+	return status, false, nil
+}
+
+func updateDrainingStatus(podName string, status v1alpha1.ProxyDrainingStatus, startTime time.Time, conns int32) v1alpha1.ProxyDrainingStatus {
+	if status.PodName == "" {
+		status = v1alpha1.ProxyDrainingStatus{
+			PodName:            podName,
+			StartTime:          metav1.NewTime(startTime),
+			InitialConnections: conns,
+		}
+	}
+	if status.InitialConnections < 0 {
+		status.InitialConnections = conns
+	}
+	status.Connections = conns
+	if conns >= 0 && status.InitialConnections > conns {
+		status.DrainedConnections = status.InitialConnections - conns
+	}
+	return status
+}
+
+func completeDraining(ctx *recon.Context[*v1alpha1.ProxySet], pod *corev1.Pod) error {
+	if !controllerutil.ContainsFinalizer(pod, common.ProxyDrainingFinalizer) {
+		return nil
+	}
+	if err := ctx.Patch(pod, func() error {
+		controllerutil.RemoveFinalizer(pod, common.ProxyDrainingFinalizer)
+		return nil
+	}); err != nil {
+		return errors.WrapPrefix(err, "error removing proxy draining finalizer", 0)
+	}
+	return nil
+}
+
+func patchProxyReadiness(ctx *recon.Context[*v1alpha1.ProxySet], pod *corev1.Pod, newC corev1.ConditionStatus, reason string) error {
+	cond := common.GetReadinessCondition(pod, common.ProxyReadiness)
+	if cond != nil && cond.Status == newC {
+		return nil
+	}
+	if err := ctx.PatchStatus(pod, func() error {
+		cond := common.GetReadinessCondition(pod, common.ProxyReadiness)
+		if cond == nil {
+			pod.Status.Conditions = append(pod.Status.Conditions, common.NewProxyReadinessCondition(newC, reason))
+		} else {
+			cond.Status = newC
+			cond.LastTransitionTime = metav1.Now()
+			cond.Message = reason
+		}
+		return nil
+	}); err != nil {
+		return errors.WrapPrefix(err, "patch proxy readiness", 0)
+	}
+	return nil
+}
+
+// scrapeConnections counts the living connections from the metrics of the proxy
+func scrapeConnections(ctx context.Context, pod *corev1.Pod) (int32, error) {
+	if pod.Status.PodIP == "" {
+		return 0, errors.New("pod IP is empty")
+	}
+	timeout, cancel := context.WithTimeout(ctx, scrapeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(timeout, http.MethodGet, fmt.Sprintf("http://%s:%d/metrics", pod.Status.PodIP, common.MetricsPort), nil)
+	if err != nil {
+		return 0, errors.Wrap(err, 0)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, errors.WrapPrefix(err, "scrape proxy metrics", 0)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, errors.Errorf("scrape proxy metrics, status code %d", resp.StatusCode)
+	}
+	return parseConnections(resp.Body)
+}
+
+// parseConnections parses the living connections from the metrics in prometheus text format
+func parseConnections(r io.Reader) (int32, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return 0, errors.WrapPrefix(err, "parse proxy metrics", 0)
+	}
+	mf, ok := families[proxyConnectMetric]
+	if !ok {
+		// no connection has ever been accepted
+		return 0, nil
+	}
+	var accepted, closed float64
+	for _, m := range mf.GetMetric() {
+		for _, l := range m.GetLabel() {
+			if l.GetName() != "type" {
+				continue
+			}
+			switch l.GetValue() {
+			case "accepted":
+				accepted = m.GetCounter().GetValue()
+			case "current":
+				closed = m.GetCounter().GetValue()
+			}
+		}
+	}
+	if accepted < closed {
+		return 0, nil
+	}
+	return int32(accepted - closed), nil
+}
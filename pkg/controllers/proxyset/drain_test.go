@@ -0,0 +1,112 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxyset
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_parseConnections(t *testing.T) {
+	tests := []struct {
+		name    string
+		metrics string
+		expect  int32
+	}{{
+		name: "living connections",
+		metrics: `# HELP mo_proxy_connect_counter Count of proxy connect to backend
+# TYPE mo_proxy_connect_counter counter
+mo_proxy_connect_counter{type="accepted"} 10
+mo_proxy_connect_counter{type="current"} 7
+mo_proxy_connect_counter{type="success"} 10
+`,
+		expect: 3,
+	}, {
+		name: "no connection",
+		metrics: `# TYPE go_goroutines gauge
+go_goroutines 10
+`,
+		expect: 0,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			got, err := parseConnections(strings.NewReader(tt.metrics))
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.expect))
+		})
+	}
+}
+
+func Test_updateDrainingStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+	start := time.Now()
+	s := updateDrainingStatus("proxy-0", v1alpha1.ProxyDrainingStatus{}, start, -1)
+	g.Expect(s.PodName).To(Equal("proxy-0"))
+	g.Expect(s.InitialConnections).To(Equal(int32(-1)))
+	s = updateDrainingStatus("proxy-0", s, start, 5)
+	g.Expect(s.InitialConnections).To(Equal(int32(5)))
+	g.Expect(s.DrainedConnections).To(Equal(int32(0)))
+	s = updateDrainingStatus("proxy-0", s, start, 2)
+	g.Expect(s.Connections).To(Equal(int32(2)))
+	g.Expect(s.DrainedConnections).To(Equal(int32(3)))
+	// connections cannot be counted, keep the drained count
+	s = updateDrainingStatus("proxy-0", s, start, -1)
+	g.Expect(s.Connections).To(Equal(int32(-1)))
+	g.Expect(s.DrainedConnections).To(Equal(int32(3)))
+}
+
+func Test_syncDrainPodTemplate(t *testing.T) {
+	g := NewGomegaWithT(t)
+	proxy := &v1alpha1.ProxySet{Spec: v1alpha1.ProxySetSpec{
+		DrainTimeout: &metav1.Duration{Duration: time.Minute},
+	}}
+	tpl := &corev1.PodTemplateSpec{}
+	syncDrainPodTemplate(proxy, tpl)
+	syncDrainPodTemplate(proxy, tpl)
+	g.Expect(tpl.Spec.ReadinessGates).To(Equal([]corev1.PodReadinessGate{{ConditionType: common.ProxyReadiness}}))
+	g.Expect(*tpl.Spec.TerminationGracePeriodSeconds).To(Equal(int64(90)))
+
+	proxy.Spec.DrainTimeout = nil
+	tpl = &corev1.PodTemplateSpec{}
+	syncDrainPodTemplate(proxy, tpl)
+	g.Expect(tpl.Spec.ReadinessGates).To(BeEmpty())
+	g.Expect(tpl.Spec.TerminationGracePeriodSeconds).To(BeNil())
+}
+
+func Test_syncDrainContainer(t *testing.T) {
+	g := NewGomegaWithT(t)
+	proxy := &v1alpha1.ProxySet{Spec: v1alpha1.ProxySetSpec{
+		DrainTimeout: &metav1.Duration{Duration: time.Minute},
+	}}
+	c := &corev1.Container{}
+	syncDrainContainer(proxy, c)
+	g.Expect(c.Lifecycle.PreStop.Exec.Command).To(HaveLen(3))
+	script := c.Lifecycle.PreStop.Exec.Command[2]
+	g.Expect(script).To(ContainSubstring("deadline=$((SECONDS + 60))"))
+	g.Expect(script).To(ContainSubstring("/dev/tcp/127.0.0.1/7001"))
+	g.Expect(script).To(ContainSubstring(`/^mo_proxy_connect_counter\{.*type="accepted"/`))
+
+	proxy.Spec.DrainTimeout = nil
+	syncDrainContainer(proxy, c)
+	g.Expect(c.Lifecycle).To(BeNil())
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxyset
+
+import (
+	"fmt"
+
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	"github.com/matrixorigin/matrixone-operator/pkg/controllers/common"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	pluginContainer = "plugin"
+	// pluginConfigFile is the key of the plugin config in the proxy configmap
+	pluginConfigFile = "plugin.toml"
+	pluginConfigPath = "/etc/proxy-plugin"
+	pluginConfigEnv  = "PLUGIN_CONFIG_FILE"
+	// pluginConfigAnno records the digest of the plugin config to roll the pods when the config changes
+	pluginConfigAnno = "matrixone.cloud/plugin-config"
+)
+
+// pluginAddr is the address that the proxy connects to the plugin, the plugin runs in the same pod
+// so the connection goes through the local loopback address
+func pluginAddr(plugin *v1alpha1.ProxyPlugin) string {
+	return fmt.Sprintf("127.0.0.1:%d", plugin.Port)
+}
+
+// syncPluginConfig wires the plugin address to the proxy config and returns the plugin config content
+func syncPluginConfig(proxy *v1alpha1.ProxySet, conf *v1alpha1.TomlConfig) (string, error) {
+	plugin := proxy.Spec.Plugin
+	if plugin == nil {
+		return "", nil
+	}
+	conf.Set([]string{"proxy", "plugin", "backend"}, pluginAddr(plugin))
+	if plugin.Timeout != nil {
+		conf.Set([]string{"proxy", "plugin", "timeout"}, plugin.Timeout.Duration.String())
+	}
+	if plugin.Config == nil {
+		return "", nil
+	}
+	return plugin.Config.ToString()
+}
+
+// syncPluginContainer injects the plugin container to the proxy pod template
+func syncPluginContainer(proxy *v1alpha1.ProxySet, tpl *corev1.PodTemplateSpec, pluginConfig string) {
+	plugin := proxy.Spec.Plugin
+	if plugin == nil {
+		delete(tpl.Annotations, pluginConfigAnno)
+		return
+	}
+	c := corev1.Container{
+		Name:      pluginContainer,
+		Image:     plugin.Image,
+		Resources: plugin.Resources,
+		Ports: []corev1.ContainerPort{{
+			Name:          pluginContainer,
+			ContainerPort: plugin.Port,
+		}},
+		Env: append([]corev1.EnvVar{{
+			Name:  pluginConfigEnv,
+			Value: fmt.Sprintf("%s/%s", pluginConfigPath, pluginConfigFile),
+		}}, plugin.Env...),
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      common.ConfigVolume,
+			ReadOnly:  true,
+			MountPath: pluginConfigPath,
+		}},
+		// the pod is not ready to serve until the plugin is ready
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{
+					Port: intstr.FromInt(int(plugin.Port)),
+				},
+			},
+			FailureThreshold: probeFailureThreshold,
+			PeriodSeconds:    probePeriodSeconds,
+		},
+	}
+	// the containers of the template are rebuilt on each sync
+	tpl.Spec.Containers = append(tpl.Spec.Containers, c)
+	if tpl.Annotations == nil {
+		tpl.Annotations = map[string]string{}
+	}
+	tpl.Annotations[pluginConfigAnno] = common.DataDigest([]byte(pluginConfig))
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxyset
+
+import (
+	"testing"
+
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_syncPlugin(t *testing.T) {
+	g := NewGomegaWithT(t)
+	proxy := &v1alpha1.ProxySet{Spec: v1alpha1.ProxySetSpec{
+		Plugin: &v1alpha1.ProxyPlugin{
+			Image:  "plugin:v1",
+			Port:   6009,
+			Config: v1alpha1.NewTomlConfig(map[string]interface{}{"key": "value"}),
+		},
+	}}
+	conf := v1alpha1.NewTomlConfig(map[string]interface{}{})
+	pluginConf, err := syncPluginConfig(proxy, conf)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(pluginConf).To(ContainSubstring("key"))
+	g.Expect(conf.Get("proxy", "plugin", "backend").MustString()).To(Equal("127.0.0.1:6009"))
+
+	tpl := &corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+		Containers: []corev1.Container{{Name: v1alpha1.ContainerMain}},
+	}}
+	syncPluginContainer(proxy, tpl, pluginConf)
+	g.Expect(tpl.Spec.Containers).To(HaveLen(2))
+	g.Expect(tpl.Spec.Containers[1].Image).To(Equal("plugin:v1"))
+	g.Expect(tpl.Annotations).To(HaveKey(pluginConfigAnno))
+
+	// plugin config change rolls the pods
+	digest := tpl.Annotations[pluginConfigAnno]
+	syncPluginContainer(proxy, tpl, "key = 'another'")
+	g.Expect(tpl.Annotations[pluginConfigAnno]).NotTo(Equal(digest))
+
+	proxy.Spec.Plugin = nil
+	syncPluginContainer(proxy, tpl, "")
+	g.Expect(tpl.Annotations).NotTo(HaveKey(pluginConfigAnno))
+}
@@ -91,16 +91,26 @@ func syncCloneSet(ctx *recon.Context[*v1alpha1.ProxySet], proxy *v1alpha1.ProxyS
 	if err != nil {
 		return errors.WrapPrefix(err, "build configmap", 0)
 	}
-	cs.Spec.Replicas = &proxy.Spec.Replicas
+	replicas := proxy.GetReplicas()
+	cs.Spec.Replicas = &replicas
 	cs.Spec.MinReadySeconds = proxy.Spec.MinReadySeconds
+	syncLifecycle(proxy, cs)
 	return common.SyncMOPod(&common.SyncMOPodTask{
-		PodSet:          &proxy.Spec.PodSet,
-		TargetTemplate:  &cs.Spec.Template,
-		ConfigMap:       cm,
-		KubeCli:         ctx,
-		StorageProvider: &ctx.Dep.Deps.LogSet.Spec.SharedStorage,
-		ConfigSuffix:    configSuffix,
-		MutateContainer: syncMainContainer,
+		PodSet:             &proxy.Spec.PodSet,
+		TargetTemplate:     &cs.Spec.Template,
+		ConfigMap:          cm,
+		KubeCli:            ctx,
+		StorageProvider:    &ctx.Dep.Deps.LogSet.Spec.SharedStorage,
+		ETLStorageProvider: ctx.Dep.Deps.LogSet.Spec.ETLStorage,
+		ConfigSuffix:       configSuffix,
+		MutateContainer: func(c *corev1.Container) {
+			syncMainContainer(c)
+			syncDrainContainer(proxy, c)
+		},
+		MutatePod: func(tpl *corev1.PodTemplateSpec) {
+			syncDrainPodTemplate(proxy, tpl)
+			syncPluginContainer(proxy, tpl, cm.Data[pluginConfigFile])
+		},
 	})
 }
 
@@ -117,6 +127,7 @@ func syncMainContainer(c *corev1.Container) {
 	}
 	// TODO(aylei): liveness probe should be defined carefully since restarting proxy would interrupt
 	// living connections, at least we cannot rely on tcp port readiness to indicate the liveness.
+	// Planned restarts are covered by connection draining if spec.drainTimeout is set.
 }
 
 func buildSvc(proxy *v1alpha1.ProxySet) *corev1.Service {
@@ -173,12 +184,17 @@ func buildProxyConfigMap(proxy *v1alpha1.ProxySet, ls *v1alpha1.LogSet) (*corev1
 		conf = v1alpha1.NewTomlConfig(map[string]interface{}{})
 	}
 	conf.Set([]string{"hakeeper-client", "discovery-address"}, ls.Status.Discovery.String())
-	conf.MergeDeep(common.FileServiceConfig(fmt.Sprintf("%s/%s", common.DataPath, common.DataDir), ls.Spec.SharedStorage, nil))
+	conf.MergeDeep(common.FileServiceConfig(fmt.Sprintf("%s/%s", common.DataPath, common.DataDir), ls.Spec.SharedStorage, ls.Spec.ETLStorage, nil))
 	conf.Set([]string{"service-type"}, "PROXY")
 	conf.Set([]string{"proxy", "listen-address"}, fmt.Sprintf("0.0.0.0:%d", port))
-	if proxy.Spec.GetExportToPrometheus() {
+	// connection draining counts the connections from the metrics
+	if proxy.Spec.GetExportToPrometheus() || proxy.Spec.DrainEnabled() {
 		conf.Set([]string{"observability", "enableMetricToProm"}, true)
 	}
+	pluginConf, err := syncPluginConfig(proxy, conf)
+	if err != nil {
+		return nil, "", errors.WrapPrefix(err, "build plugin config", 0)
+	}
 	s, err := conf.ToString()
 	if err != nil {
 		return nil, "", err
@@ -192,6 +208,8 @@ func buildProxyConfigMap(proxy *v1alpha1.ProxySet, ls *v1alpha1.LogSet) (*corev1
 	if proxy.Spec.WaitPluginAddr != nil {
 		parts := strings.Split(*proxy.Spec.WaitPluginAddr, ":")
 		m.PluginSocket = utils.PtrTo(strings.Join(parts, "/"))
+	} else if proxy.Spec.Plugin != nil {
+		m.PluginSocket = utils.PtrTo(strings.ReplaceAll(pluginAddr(proxy.Spec.Plugin), ":", "/"))
 	}
 	err = startScriptTpl.Execute(buff, m)
 	if err != nil {
@@ -205,6 +223,9 @@ func buildProxyConfigMap(proxy *v1alpha1.ProxySet, ls *v1alpha1.LogSet) (*corev1
 			common.Entrypoint: buff.String(),
 		},
 	}
+	if pluginConf != "" {
+		cm.Data[pluginConfigFile] = pluginConf
+	}
 	// keep backward-compatible
 	if v1alpha1.GateInplaceConfigmapUpdate.Enabled(proxy.Spec.GetOperatorVersion()) {
 		configSuffix = common.DataDigest([]byte(s))
@@ -88,6 +88,9 @@ func (w *Actor) Observe(ctx *recon.Context[*v1alpha1.WebUI]) (recon.Action[*v1al
 	if !equality.Semantic.DeepEqual(originSvc, svc) {
 		return w.with(dp, svc).SvcUpdate, nil
 	}
+	if err := common.SyncHTTPExposure(ctx, svc, wi.Spec.Exposure, frontendPort); err != nil {
+		return nil, errors.WrapPrefix(err, "sync exposure", 0)
+	}
 
 	podList := &corev1.PodList{}
 	err = ctx.List(podList, client.InNamespace(wi.Namespace), client.MatchingLabels(common.SubResourceLabels(wi)))
@@ -16,6 +16,7 @@ package mocli
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -32,7 +33,10 @@ type StoreCache struct {
 	mu              struct {
 		sync.RWMutex
 		cnServices map[string]metadata.CNService
-		tnService  *metadata.TNService
+		// tnServices holds all the TN stores, healthy stores come first
+		tnServices []metadata.TNService
+		// healthyTNs is the number of healthy TN stores in tnServices
+		healthyTNs int
 	}
 	done chan struct{}
 }
@@ -60,13 +64,24 @@ func (c *StoreCache) GetCN(uuid string) (metadata.CNService, bool) {
 	return cn, ok
 }
 
+// GetTN returns the first TN store, healthy TN store is preferred
 func (c *StoreCache) GetTN() (*metadata.TNService, bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	if c.mu.tnService == nil {
+	if len(c.mu.tnServices) == 0 {
 		return nil, false
 	}
-	return c.mu.tnService, true
+	tn := c.mu.tnServices[0]
+	return &tn, true
+}
+
+// ListTN returns all the healthy TN stores
+func (c *StoreCache) ListTN() []metadata.TNService {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tns := make([]metadata.TNService, c.mu.healthyTNs)
+	copy(tns, c.mu.tnServices)
+	return tns
 }
 
 func (c *StoreCache) refresh() {
@@ -102,9 +117,20 @@ func (c *StoreCache) doRefresh() {
 		v := newCNService(cn)
 		c.mu.cnServices[cn.UUID] = v
 	}
-	// TODO(aylei): handler multiple TNStore
-	if len(details.TNStores) > 0 {
-		c.mu.tnService = newTNService(details.TNStores[0])
+	tnStores := details.TNStores
+	sort.SliceStable(tnStores, func(i, j int) bool {
+		if tnStores[i].State != tnStores[j].State {
+			return tnStores[i].State == logpb.NormalState
+		}
+		return tnStores[i].UUID < tnStores[j].UUID
+	})
+	c.mu.tnServices = c.mu.tnServices[:0]
+	c.mu.healthyTNs = 0
+	for _, tn := range tnStores {
+		if tn.State == logpb.NormalState {
+			c.mu.healthyTNs++
+		}
+		c.mu.tnServices = append(c.mu.tnServices, newTNService(tn))
 	}
 }
 
@@ -120,8 +146,8 @@ func newCNService(cn logpb.CNStore) metadata.CNService {
 	}
 }
 
-func newTNService(tn logpb.TNStore) *metadata.TNService {
-	return &metadata.TNService{
+func newTNService(tn logpb.TNStore) metadata.TNService {
+	return metadata.TNService{
 		ServiceID:          tn.UUID,
 		LockServiceAddress: tn.LockServiceAddress,
 	}
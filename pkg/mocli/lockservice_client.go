@@ -16,6 +16,7 @@ package mocli
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-errors/errors"
 	"github.com/matrixorigin/matrixone/pkg/common/morpc"
@@ -23,15 +24,19 @@ import (
 	"go.uber.org/zap"
 )
 
+// LockServiceClient sends lock service requests to the TN stores. A CN may hold locks
+// allocated by any TN when there are multiple TN stores, so requests about a CN are sent
+// to every TN store and the responses are merged.
 type LockServiceClient struct {
 	client morpc.RPCClient
-	tnAddr string
+	// tnAddrs returns the lock service addresses of all TN stores
+	tnAddrs func() []string
 }
 
-func NewLockServiceClient(tnAddr string, logger *zap.Logger) (*LockServiceClient, error) {
+func NewLockServiceClient(tnAddrs func() []string, logger *zap.Logger) (*LockServiceClient, error) {
 	cfg := morpc.Config{}
 	cfg.Adjust()
-	logger.Info("new lockservice client", zap.String("TN addr", tnAddr))
+	logger.Info("new lockservice client")
 	cfg.BackendOptions = append(cfg.BackendOptions,
 		morpc.WithBackendReadTimeout(DefaultRPCTimeout))
 	client, err := cfg.NewClient("", "lock-client", func() morpc.Message {
@@ -40,50 +45,77 @@ func NewLockServiceClient(tnAddr string, logger *zap.Logger) (*LockServiceClient
 	if err != nil {
 		return nil, errors.WrapPrefix(err, "error create lock service client", 0)
 	}
-	return &LockServiceClient{client: client, tnAddr: tnAddr}, nil
+	return &LockServiceClient{client: client, tnAddrs: tnAddrs}, nil
 }
 
+// SetRestartCN marks the CN as restarting on all TN stores, true is returned only if all the TN stores accept
 func (l *LockServiceClient) SetRestartCN(ctx context.Context, uuid string) (bool, error) {
-	resp, err := l.sendToTN(ctx, &lock.Request{
+	ok := true
+	err := l.sendToAllTN(ctx, &lock.Request{
 		SetRestartService: lock.SetRestartServiceRequest{ServiceID: uuid},
-		Method:            lock.Method_SetRestartService},
-	)
+		Method:            lock.Method_SetRestartService,
+	}, func(resp *lock.Response) {
+		ok = ok && resp.SetRestartService.OK
+	})
 	if err != nil {
 		return false, errors.WrapPrefix(err, "error set restart service request", 0)
 	}
-	return resp.SetRestartService.OK, nil
+	return ok, nil
 }
 
+// CanRestartCN returns true only if all the TN stores allow the CN to be restarted
 func (l *LockServiceClient) CanRestartCN(ctx context.Context, uuid string) (bool, error) {
-	resp, err := l.sendToTN(ctx, &lock.Request{
+	ok := true
+	err := l.sendToAllTN(ctx, &lock.Request{
 		CanRestartService: lock.CanRestartServiceRequest{ServiceID: uuid},
-		Method:            lock.Method_CanRestartService},
-	)
+		Method:            lock.Method_CanRestartService,
+	}, func(resp *lock.Response) {
+		ok = ok && resp.CanRestartService.OK
+	})
 	if err != nil {
 		return false, errors.WrapPrefix(err, "error check can restart service", 0)
 	}
-	return resp.CanRestartService.OK, nil
+	return ok, nil
 }
 
+// RemainTxnCount returns the sum of the remaining txns of the CN on all TN stores
 func (l *LockServiceClient) RemainTxnCount(ctx context.Context, uuid string) (int, error) {
-	resp, err := l.sendToTN(ctx, &lock.Request{
+	count := 0
+	err := l.sendToAllTN(ctx, &lock.Request{
 		RemainTxnInService: lock.RemainTxnInServiceRequest{ServiceID: uuid},
-		Method:             lock.Method_RemainTxnInService},
-	)
+		Method:             lock.Method_RemainTxnInService,
+	}, func(resp *lock.Response) {
+		count += int(resp.RemainTxnInService.RemainTxn)
+	})
 	if err != nil {
 		return 0, err
 	}
-	return int(resp.RemainTxnInService.RemainTxn), nil
+	return count, nil
+}
+
+func (l *LockServiceClient) sendToAllTN(ctx context.Context, request *lock.Request, merge func(resp *lock.Response)) error {
+	addrs := l.tnAddrs()
+	if len(addrs) == 0 {
+		return errors.New("no healthy TN store available")
+	}
+	for _, addr := range addrs {
+		resp, err := l.sendToTN(ctx, addr, request)
+		if err != nil {
+			return errors.WrapPrefix(err, fmt.Sprintf("TN %s", addr), 0)
+		}
+		merge(resp)
+	}
+	return nil
 }
 
-func (l *LockServiceClient) sendToTN(ctx context.Context, request *lock.Request) (*lock.Response, error) {
+func (l *LockServiceClient) sendToTN(ctx context.Context, tnAddr string, request *lock.Request) (*lock.Response, error) {
 	_, ok := ctx.Deadline()
 	if !ok {
 		c, cancel := context.WithTimeout(ctx, DefaultRPCTimeout)
 		defer cancel()
 		ctx = c
 	}
-	f, err := l.client.Send(ctx, l.tnAddr, request)
+	f, err := l.client.Send(ctx, tnAddr, request)
 	if err != nil {
 		return nil, errors.WrapPrefix(err, "error send lock rpc to TN", 0)
 	}
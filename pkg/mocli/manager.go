@@ -151,12 +151,23 @@ func (m *MORPCClientManager) newClientSet(ls *v1alpha1.LogSet) (*ClientSet, erro
 	}
 
 	mc := NewCNCache(cli, RefreshInterval, zapr.NewLogger(m.logger.Named(ls.Name+"-store-cache")))
-	tn, ok := mc.GetTN()
-	if !ok {
-		return nil, errors.Errorf("TN service not found, logset: %s/%s", ls.Namespace, ls.Name)
+	closeOnErr := func() {
+		mc.Close()
+		if err := cli.Close(); err != nil {
+			m.logger.Error("error closing HAKeeper client", zap.Error(err), zap.Any("logset", client.ObjectKeyFromObject(ls)))
+		}
 	}
-	lcc, err := NewLockServiceClient(tn.LockServiceAddress, m.logger.Named("lockservice-cli"))
+	// TN stores are resolved from the cache on each request since TN stores may be
+	// scaled or failed over after the client is built
+	lcc, err := NewLockServiceClient(func() []string {
+		var addrs []string
+		for _, tn := range mc.ListTN() {
+			addrs = append(addrs, tn.LockServiceAddress)
+		}
+		return addrs
+	}, m.logger.Named("lockservice-cli"))
 	if err != nil {
+		closeOnErr()
 		return nil, errors.WrapPrefix(err, "build lockservice Client", 0)
 	}
 	handler := &ClientSet{
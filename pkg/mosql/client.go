@@ -36,6 +36,11 @@ const (
 type Client interface {
 	GetServerConnection(ctx context.Context, uid string) (int, error)
 	Query(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	// QueryValue returns the first column of the first row of the query result as string,
+	// NULL is returned as "NULL"
+	QueryValue(ctx context.Context, query string, args ...any) (string, error)
+	// Close closes the connections of the client, the client is created per use and must be closed after use
+	Close() error
 }
 
 type moClient struct {
@@ -91,6 +96,54 @@ func (c *moClient) Query(ctx context.Context, query string, args ...any) (*sql.R
 	return conn.QueryContext(ctx, query, args...)
 }
 
+func (c *moClient) QueryValue(ctx context.Context, query string, args ...any) (string, error) {
+	// the rows must be read before the timeout is canceled, so the timeout is owned here instead of by Query
+	if _, ok := ctx.Deadline(); !ok {
+		timeout, cancel := context.WithTimeout(ctx, queryTimeout)
+		defer cancel()
+		ctx = timeout
+	}
+	rows, err := c.Query(ctx, query, args...)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return "", err
+		}
+		return "", sql.ErrNoRows
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+	values := make([]any, len(columns))
+	var v sql.NullString
+	values[0] = &v
+	for i := 1; i < len(values); i++ {
+		values[i] = new(sql.RawBytes)
+	}
+	if err := rows.Scan(values...); err != nil {
+		return "", err
+	}
+	if !v.Valid {
+		return "NULL", nil
+	}
+	return v.String, nil
+}
+
+func (c *moClient) Close() error {
+	c.Lock()
+	defer c.Unlock()
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
 func (c *moClient) getConnection(ctx context.Context) (*sql.DB, error) {
 	if c.conn != nil {
 		return c.conn, nil
@@ -35,3 +35,11 @@ func (c *fakeClient) GetServerConnection(_ context.Context, _ string) (int, erro
 func (c *fakeClient) Query(_ context.Context, _ string, _ ...any) (*sql.Rows, error) {
 	return nil, nil
 }
+
+func (c *fakeClient) QueryValue(_ context.Context, _ string, _ ...any) (string, error) {
+	return "", nil
+}
+
+func (c *fakeClient) Close() error {
+	return nil
+}
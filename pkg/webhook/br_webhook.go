@@ -0,0 +1,646 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+)
+
+type brWebhook struct{}
+
+func (brWebhook) setupWebhookWithManager(mgr ctrl.Manager) error {
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.BackupJob{}).
+		WithValidator(&backupJobValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.RestoreJob{}).
+		WithValidator(&restoreJobValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.BackupSchedule{}).
+		WithValidator(&backupScheduleValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.BackupVerification{}).
+		WithValidator(&backupVerificationValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.BackupRepository{}).
+		WithValidator(&backupRepositoryValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.SnapshotSchedule{}).
+		WithValidator(&snapshotScheduleValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.SnapshotRestore{}).
+		WithValidator(&snapshotRestoreValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.LogicalBackupJob{}).
+		WithValidator(&logicalBackupJobValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.LogicalRestoreJob{}).
+		WithValidator(&logicalRestoreJobValidator{}).
+		Complete(); err != nil {
+		return err
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.StorageMigration{}).
+		WithValidator(&storageMigrationValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-core-matrixorigin-io-v1alpha1-backupjob,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.matrixorigin.io,resources=backupjobs,verbs=create;update,versions=v1alpha1,name=vbackupjob.kb.io,admissionReviewVersions={v1,v1beta1}
+
+// backupJobValidator implements webhook.Validator so a webhook will be registered for the v1alpha1.BackupJob
+type backupJobValidator struct{}
+
+var _ webhook.CustomValidator = &backupJobValidator{}
+
+func (v *backupJobValidator) ValidateCreate(_ context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
+	bj, ok := obj.(*v1alpha1.BackupJob)
+	if !ok {
+		return nil, unexpectedKindError("BackupJob", obj)
+	}
+	path := field.NewPath("spec")
+	errs := validateBackupJobSpec(&bj.Spec, path)
+	if bj.GetBackupType() == v1alpha1.BackupTypeIncremental && bj.Spec.ParentBackup == "" {
+		errs = append(errs, field.Required(path.Child("parentBackup"), "parentBackup must be set for incremental backup"))
+	}
+	return nil, invalidOrNil(errs, bj)
+}
+
+func (v *backupJobValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (warnings admission.Warnings, err error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+func (v *backupJobValidator) ValidateDelete(_ context.Context, _ runtime.Object) (warnings admission.Warnings, err error) {
+	return nil, nil
+}
+
+// +kubebuilder:webhook:path=/validate-core-matrixorigin-io-v1alpha1-restorejob,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.matrixorigin.io,resources=restorejobs,verbs=create;update,versions=v1alpha1,name=vrestorejob.kb.io,admissionReviewVersions={v1,v1beta1}
+
+// restoreJobValidator implements webhook.Validator so a webhook will be registered for the v1alpha1.RestoreJob
+type restoreJobValidator struct{}
+
+var _ webhook.CustomValidator = &restoreJobValidator{}
+
+func (v *restoreJobValidator) ValidateCreate(_ context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
+	rj, ok := obj.(*v1alpha1.RestoreJob)
+	if !ok {
+		return nil, unexpectedKindError("RestoreJob", obj)
+	}
+	var errs field.ErrorList
+	path := field.NewPath("spec")
+	sources := 0
+	for _, set := range []bool{rj.Spec.BackupName != "", rj.Spec.PointInTime != nil, rj.Spec.ExternalSource != nil} {
+		if set {
+			sources++
+		}
+	}
+	switch {
+	case sources > 1:
+		errs = append(errs, field.Invalid(path, nil, "backupName, pointInTime and externalSource are mutual exclusive"))
+	case sources == 0:
+		errs = append(errs, field.Required(path.Child("backupName"), "one of backupName, pointInTime or externalSource must be set"))
+	}
+	if rj.Spec.ExternalSource != nil {
+		errs = append(errs, validateBRStorage(rj.Spec.ExternalSource, path.Child("externalSource"))...)
+		if rj.Spec.ExternalSource.S3 != nil && rj.Spec.ExternalBackupID == "" {
+			errs = append(errs, field.Required(path.Child("externalBackupID"), "the latest backup cannot be resolved from the catalog in S3, externalBackupID must be set"))
+		}
+	} else if rj.Spec.ExternalBackupID != "" {
+		errs = append(errs, field.Forbidden(path.Child("externalBackupID"), "externalBackupID can only be set with externalSource"))
+	}
+	if rj.IsInPlacePITR() {
+		errs = append(errs, validatePointInTime(rj.Spec.PointInTime, path.Child("pointInTime"))...)
+		if rj.Spec.Target.S3 != nil || rj.Spec.Target.FileSystem != nil {
+			// a backup cannot be replayed to a later time on another cluster
+			errs = append(errs, field.Forbidden(path.Child("target"), "pointInTime only recovers the cluster in place with its PITR and cannot be restored to a target, restore a backup with backupName instead"))
+		}
+	} else {
+		errs = append(errs, validateBRStorage(&rj.Spec.Target, path.Child("target"))...)
+	}
+	errs = append(errs, validateRetry(rj.Spec.RetryPolicy, rj.Spec.ActiveDeadline, path)...)
+	if rj.Spec.Decryption != nil {
+		errs = append(errs, validateEncryption(rj.Spec.Decryption, path.Child("decryption"))...)
+	}
+	return nil, invalidOrNil(errs, rj)
+}
+
+func (v *restoreJobValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (warnings admission.Warnings, err error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+func (v *restoreJobValidator) ValidateDelete(_ context.Context, _ runtime.Object) (warnings admission.Warnings, err error) {
+	return nil, nil
+}
+
+// +kubebuilder:webhook:path=/validate-core-matrixorigin-io-v1alpha1-backupschedule,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.matrixorigin.io,resources=backupschedules,verbs=create;update,versions=v1alpha1,name=vbackupschedule.kb.io,admissionReviewVersions={v1,v1beta1}
+
+// backupScheduleValidator implements webhook.Validator so a webhook will be registered for the v1alpha1.BackupSchedule
+type backupScheduleValidator struct{}
+
+var _ webhook.CustomValidator = &backupScheduleValidator{}
+
+func (v *backupScheduleValidator) ValidateCreate(_ context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
+	bs, ok := obj.(*v1alpha1.BackupSchedule)
+	if !ok {
+		return nil, unexpectedKindError("BackupSchedule", obj)
+	}
+	var errs field.ErrorList
+	path := field.NewPath("spec")
+	if _, err := cron.ParseStandard(bs.Spec.Schedule); err != nil {
+		errs = append(errs, field.Invalid(path.Child("schedule"), bs.Spec.Schedule, err.Error()))
+	}
+	if bs.Spec.TimeZone != nil {
+		if _, err := time.LoadLocation(*bs.Spec.TimeZone); err != nil {
+			errs = append(errs, field.Invalid(path.Child("timeZone"), *bs.Spec.TimeZone, err.Error()))
+		}
+	}
+	errs = append(errs, validateBackupJobSpec(&bs.Spec.Template, path.Child("template"))...)
+	return nil, invalidOrNil(errs, bs)
+}
+
+func (v *backupScheduleValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (warnings admission.Warnings, err error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+func (v *backupScheduleValidator) ValidateDelete(_ context.Context, _ runtime.Object) (warnings admission.Warnings, err error) {
+	return nil, nil
+}
+
+// +kubebuilder:webhook:path=/validate-core-matrixorigin-io-v1alpha1-backupverification,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.matrixorigin.io,resources=backupverifications,verbs=create;update,versions=v1alpha1,name=vbackupverification.kb.io,admissionReviewVersions={v1,v1beta1}
+
+// backupVerificationValidator implements webhook.Validator so a webhook will be registered for the v1alpha1.BackupVerification
+type backupVerificationValidator struct{}
+
+var _ webhook.CustomValidator = &backupVerificationValidator{}
+
+func (v *backupVerificationValidator) ValidateCreate(_ context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
+	bv, ok := obj.(*v1alpha1.BackupVerification)
+	if !ok {
+		return nil, unexpectedKindError("BackupVerification", obj)
+	}
+	var errs field.ErrorList
+	// the temporary cluster is named verify-<name>
+	if maxLen := MatrixOneClusterNameMaxLength - len("verify-"); len(bv.Name) > maxLen {
+		errs = append(errs, field.Invalid(field.NewPath("metadata").Child("name"), bv.Name, fmt.Sprintf("must be no more than %d characters", maxLen)))
+	}
+	path := field.NewPath("spec")
+	switch bv.Spec.Namespace {
+	case "":
+		errs = append(errs, field.Required(path.Child("namespace"), "namespace must be set"))
+	case bv.Namespace:
+		errs = append(errs, field.Invalid(path.Child("namespace"), bv.Spec.Namespace, "the temporary cluster must be created in an isolated namespace other than the namespace of the verification"))
+	}
+	names := map[string]bool{}
+	for i, a := range bv.Spec.Assertions {
+		aPath := path.Child("assertions").Index(i)
+		if a.Name == "" {
+			errs = append(errs, field.Required(aPath.Child("name"), "name must be set"))
+		} else if names[a.Name] {
+			errs = append(errs, field.Duplicate(aPath.Child("name"), a.Name))
+		}
+		names[a.Name] = true
+		if a.Query == "" {
+			errs = append(errs, field.Required(aPath.Child("query"), "query must be set"))
+		}
+	}
+	return nil, invalidOrNil(errs, bv)
+}
+
+func (v *backupVerificationValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (warnings admission.Warnings, err error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+func (v *backupVerificationValidator) ValidateDelete(_ context.Context, _ runtime.Object) (warnings admission.Warnings, err error) {
+	return nil, nil
+}
+
+// +kubebuilder:webhook:path=/validate-core-matrixorigin-io-v1alpha1-backuprepository,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.matrixorigin.io,resources=backuprepositories,verbs=create;update,versions=v1alpha1,name=vbackuprepository.kb.io,admissionReviewVersions={v1,v1beta1}
+
+// backupRepositoryValidator implements webhook.Validator so a webhook will be registered for the v1alpha1.BackupRepository
+type backupRepositoryValidator struct{}
+
+var _ webhook.CustomValidator = &backupRepositoryValidator{}
+
+func (v *backupRepositoryValidator) ValidateCreate(_ context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
+	repo, ok := obj.(*v1alpha1.BackupRepository)
+	if !ok {
+		return nil, unexpectedKindError("BackupRepository", obj)
+	}
+	path := field.NewPath("spec")
+	errs := validateBRStorage(&repo.Spec.Source, path.Child("source"))
+	if repo.Spec.Interval != nil && repo.Spec.Interval.Duration < time.Minute {
+		errs = append(errs, field.Invalid(path.Child("interval"), repo.Spec.Interval.Duration.String(), "interval must be at least 1m"))
+	}
+	return nil, invalidOrNil(errs, repo)
+}
+
+func (v *backupRepositoryValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (warnings admission.Warnings, err error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+func (v *backupRepositoryValidator) ValidateDelete(_ context.Context, _ runtime.Object) (warnings admission.Warnings, err error) {
+	return nil, nil
+}
+
+// +kubebuilder:webhook:path=/validate-core-matrixorigin-io-v1alpha1-snapshotschedule,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.matrixorigin.io,resources=snapshotschedules,verbs=create;update,versions=v1alpha1,name=vsnapshotschedule.kb.io,admissionReviewVersions={v1,v1beta1}
+
+// snapshotScheduleValidator implements webhook.Validator so a webhook will be registered for the v1alpha1.SnapshotSchedule
+type snapshotScheduleValidator struct{}
+
+var _ webhook.CustomValidator = &snapshotScheduleValidator{}
+
+func (v *snapshotScheduleValidator) ValidateCreate(_ context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
+	ss, ok := obj.(*v1alpha1.SnapshotSchedule)
+	if !ok {
+		return nil, unexpectedKindError("SnapshotSchedule", obj)
+	}
+	var errs field.ErrorList
+	// the snapshots are named <name>_<yyyyMMddHHmmss> so that they can be restored by name
+	if maxLen := 64 - len("_20060102150405"); len(ss.Name) > maxLen {
+		errs = append(errs, field.Invalid(field.NewPath("metadata").Child("name"), ss.Name, fmt.Sprintf("must be no more than %d characters", maxLen)))
+	}
+	path := field.NewPath("spec")
+	if ss.Spec.ClusterRef == "" {
+		errs = append(errs, field.Required(path.Child("clusterRef"), "clusterRef must be set"))
+	}
+	if _, err := cron.ParseStandard(ss.Spec.Schedule); err != nil {
+		errs = append(errs, field.Invalid(path.Child("schedule"), ss.Spec.Schedule, err.Error()))
+	}
+	if ss.Spec.TimeZone != nil {
+		if _, err := time.LoadLocation(*ss.Spec.TimeZone); err != nil {
+			errs = append(errs, field.Invalid(path.Child("timeZone"), *ss.Spec.TimeZone, err.Error()))
+		}
+	}
+	errs = append(errs, validateSnapshotObject(&ss.Spec.SnapshotObject, path)...)
+	return nil, invalidOrNil(errs, ss)
+}
+
+func (v *snapshotScheduleValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (warnings admission.Warnings, err error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+func (v *snapshotScheduleValidator) ValidateDelete(_ context.Context, _ runtime.Object) (warnings admission.Warnings, err error) {
+	return nil, nil
+}
+
+// +kubebuilder:webhook:path=/validate-core-matrixorigin-io-v1alpha1-snapshotrestore,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.matrixorigin.io,resources=snapshotrestores,verbs=create;update,versions=v1alpha1,name=vsnapshotrestore.kb.io,admissionReviewVersions={v1,v1beta1}
+
+// snapshotRestoreValidator implements webhook.Validator so a webhook will be registered for the v1alpha1.SnapshotRestore
+type snapshotRestoreValidator struct{}
+
+var _ webhook.CustomValidator = &snapshotRestoreValidator{}
+
+func (v *snapshotRestoreValidator) ValidateCreate(_ context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
+	sr, ok := obj.(*v1alpha1.SnapshotRestore)
+	if !ok {
+		return nil, unexpectedKindError("SnapshotRestore", obj)
+	}
+	var errs field.ErrorList
+	path := field.NewPath("spec")
+	if sr.Spec.ClusterRef == "" {
+		errs = append(errs, field.Required(path.Child("clusterRef"), "clusterRef must be set"))
+	}
+	if !identifierPattern.MatchString(sr.Spec.Snapshot) {
+		errs = append(errs, field.Invalid(path.Child("snapshot"), sr.Spec.Snapshot, identifierMessage))
+	}
+	errs = append(errs, validateSnapshotObject(&sr.Spec.SnapshotObject, path)...)
+	if sr.Spec.ToAccount != "" {
+		if sr.Spec.Level == v1alpha1.SnapshotLevelCluster {
+			errs = append(errs, field.Invalid(path.Child("toAccount"), sr.Spec.ToAccount, "toAccount is not supported for cluster level"))
+		} else if !identifierPattern.MatchString(sr.Spec.ToAccount) {
+			errs = append(errs, field.Invalid(path.Child("toAccount"), sr.Spec.ToAccount, identifierMessage))
+		}
+	}
+	return nil, invalidOrNil(errs, sr)
+}
+
+func (v *snapshotRestoreValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (warnings admission.Warnings, err error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+func (v *snapshotRestoreValidator) ValidateDelete(_ context.Context, _ runtime.Object) (warnings admission.Warnings, err error) {
+	return nil, nil
+}
+
+// identifierPattern is the account, database, table and snapshot names that the jobs accept, the names
+// are passed to the dump commands in the shell or pasted into the SQL that is run as root
+var identifierPattern = regexp.MustCompile(`^[A-Za-z0-9_]{1,64}$`)
+
+const identifierMessage = "names must consist of at most 64 letters, digits and underscores"
+
+// +kubebuilder:webhook:path=/validate-core-matrixorigin-io-v1alpha1-logicalbackupjob,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.matrixorigin.io,resources=logicalbackupjobs,verbs=create;update,versions=v1alpha1,name=vlogicalbackupjob.kb.io,admissionReviewVersions={v1,v1beta1}
+
+// logicalBackupJobValidator implements webhook.Validator so a webhook will be registered for the v1alpha1.LogicalBackupJob
+type logicalBackupJobValidator struct{}
+
+var _ webhook.CustomValidator = &logicalBackupJobValidator{}
+
+func (v *logicalBackupJobValidator) ValidateCreate(_ context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
+	lbj, ok := obj.(*v1alpha1.LogicalBackupJob)
+	if !ok {
+		return nil, unexpectedKindError("LogicalBackupJob", obj)
+	}
+	path := field.NewPath("spec")
+	errs := validateLogicalEndpoint(&lbj.Spec.Source, path.Child("source"))
+	errs = append(errs, validateBRStorage(&lbj.Spec.Target, path.Child("target"))...)
+	scope := lbj.Spec.Scope
+	scopePath := path.Child("scope")
+	scopes := 0
+	for _, set := range []bool{scope.Account != "", len(scope.Databases) > 0, len(scope.Tables) > 0} {
+		if set {
+			scopes++
+		}
+	}
+	if scopes != 1 {
+		errs = append(errs, field.Invalid(scopePath, nil, "exactly one of account, databases and tables must be set"))
+	}
+	if scope.Account != "" && scope.Account != "sys" && lbj.Spec.Source.SecretRef == nil {
+		errs = append(errs, field.Required(path.Child("source", "secretRef"), "secretRef of a user of the account must be set to dump an account other than sys"))
+	}
+	for i, db := range scope.Databases {
+		if !identifierPattern.MatchString(db) {
+			errs = append(errs, field.Invalid(scopePath.Child("databases").Index(i), db, identifierMessage))
+		}
+	}
+	for i, t := range scope.Tables {
+		if db, table, ok := strings.Cut(t, "."); !ok || !identifierPattern.MatchString(db) || !identifierPattern.MatchString(table) {
+			errs = append(errs, field.Invalid(scopePath.Child("tables").Index(i), t, "table must be in the form of <database>.<table>, "+identifierMessage))
+		}
+	}
+	return nil, invalidOrNil(errs, lbj)
+}
+
+func (v *logicalBackupJobValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (warnings admission.Warnings, err error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+func (v *logicalBackupJobValidator) ValidateDelete(_ context.Context, _ runtime.Object) (warnings admission.Warnings, err error) {
+	return nil, nil
+}
+
+// +kubebuilder:webhook:path=/validate-core-matrixorigin-io-v1alpha1-logicalrestorejob,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.matrixorigin.io,resources=logicalrestorejobs,verbs=create;update,versions=v1alpha1,name=vlogicalrestorejob.kb.io,admissionReviewVersions={v1,v1beta1}
+
+// logicalRestoreJobValidator implements webhook.Validator so a webhook will be registered for the v1alpha1.LogicalRestoreJob
+type logicalRestoreJobValidator struct{}
+
+var _ webhook.CustomValidator = &logicalRestoreJobValidator{}
+
+func (v *logicalRestoreJobValidator) ValidateCreate(_ context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
+	lrj, ok := obj.(*v1alpha1.LogicalRestoreJob)
+	if !ok {
+		return nil, unexpectedKindError("LogicalRestoreJob", obj)
+	}
+	path := field.NewPath("spec")
+	errs := validateLogicalEndpoint(&lrj.Spec.Target, path.Child("target"))
+	switch {
+	case lrj.Spec.BackupJobName != "" && lrj.Spec.Source != nil:
+		errs = append(errs, field.Invalid(path, nil, "backupJobName and source are mutual exclusive"))
+	case lrj.Spec.BackupJobName == "" && lrj.Spec.Source == nil:
+		errs = append(errs, field.Required(path.Child("backupJobName"), "one of backupJobName or source must be set"))
+	case lrj.Spec.Source != nil:
+		errs = append(errs, validateBRStorage(lrj.Spec.Source, path.Child("source"))...)
+	}
+	return nil, invalidOrNil(errs, lrj)
+}
+
+func (v *logicalRestoreJobValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (warnings admission.Warnings, err error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+func (v *logicalRestoreJobValidator) ValidateDelete(_ context.Context, _ runtime.Object) (warnings admission.Warnings, err error) {
+	return nil, nil
+}
+
+// +kubebuilder:webhook:path=/validate-core-matrixorigin-io-v1alpha1-storagemigration,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.matrixorigin.io,resources=storagemigrations,verbs=create;update,versions=v1alpha1,name=vstoragemigration.kb.io,admissionReviewVersions={v1,v1beta1}
+
+// storageMigrationValidator implements webhook.Validator so a webhook will be registered for the v1alpha1.StorageMigration
+type storageMigrationValidator struct{}
+
+var _ webhook.CustomValidator = &storageMigrationValidator{}
+
+func (v *storageMigrationValidator) ValidateCreate(_ context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
+	sm, ok := obj.(*v1alpha1.StorageMigration)
+	if !ok {
+		return nil, unexpectedKindError("StorageMigration", obj)
+	}
+	path := field.NewPath("spec")
+	var errs field.ErrorList
+	if sm.Spec.ClusterRef == "" {
+		errs = append(errs, field.Required(path.Child("clusterRef"), "clusterRef must be set"))
+	}
+	targetPath := path.Child("target")
+	switch target := sm.Spec.Target; {
+	case target.FileSystem != nil:
+		errs = append(errs, field.Invalid(targetPath.Child("fileSystem"), nil, "only S3 compatible storages are supported"))
+	case target.S3 == nil:
+		errs = append(errs, field.Required(targetPath.Child("s3"), "s3 must be set"))
+	default:
+		s3Path := targetPath.Child("s3")
+		if target.S3.Path == "" {
+			errs = append(errs, field.Required(s3Path.Child("path"), "path must be set for S3 storage"))
+		}
+		if target.S3.CertificateRef != nil {
+			errs = append(errs, field.Invalid(s3Path.Child("certificateRef"), nil, "storages with custom certificates are not supported"))
+		}
+		errs = append(errs, validateS3Provider(target.S3, s3Path)...)
+	}
+	if sm.Spec.Parallelism != nil && *sm.Spec.Parallelism < 1 {
+		errs = append(errs, field.Invalid(path.Child("parallelism"), *sm.Spec.Parallelism, "parallelism must be positive"))
+	}
+	return nil, invalidOrNil(errs, sm)
+}
+
+func (v *storageMigrationValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (warnings admission.Warnings, err error) {
+	warnings, err = v.ValidateCreate(ctx, newObj)
+	if err != nil {
+		return warnings, err
+	}
+	oldSM, ok := oldObj.(*v1alpha1.StorageMigration)
+	if !ok {
+		return nil, unexpectedKindError("StorageMigration", oldObj)
+	}
+	sm := newObj.(*v1alpha1.StorageMigration)
+	// the objects copied to the target would be left behind if the migration is redirected
+	if oldSM.Spec.ClusterRef != sm.Spec.ClusterRef || !equality.Semantic.DeepEqual(withoutS3Credential(oldSM.Spec.Target.S3), withoutS3Credential(sm.Spec.Target.S3)) {
+		return nil, invalidOrNil(field.ErrorList{field.Invalid(field.NewPath("spec"), nil, "clusterRef and target are immutable")}, sm)
+	}
+	return warnings, nil
+}
+
+func (v *storageMigrationValidator) ValidateDelete(_ context.Context, _ runtime.Object) (warnings admission.Warnings, err error) {
+	return nil, nil
+}
+
+func validateLogicalEndpoint(ep *v1alpha1.LogicalEndpoint, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if ep.ClusterRef == "" {
+		errs = append(errs, field.Required(path.Child("clusterRef"), "clusterRef must be set"))
+	}
+	if ep.SecretRef != nil && ep.SecretRef.Name == "" {
+		errs = append(errs, field.Required(path.Child("secretRef", "name"), "secretRef name must be set"))
+	}
+	return errs
+}
+
+// validateSnapshotObject validates that the objectName matches the level
+func validateSnapshotObject(o *v1alpha1.SnapshotObject, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	namePath := path.Child("objectName")
+	switch o.Level {
+	case v1alpha1.SnapshotLevelCluster:
+		if o.ObjectName != "" {
+			errs = append(errs, field.Invalid(namePath, o.ObjectName, "objectName must be empty for cluster level"))
+		}
+	case v1alpha1.SnapshotLevelAccount, v1alpha1.SnapshotLevelDatabase:
+		if o.ObjectName == "" {
+			errs = append(errs, field.Required(namePath, fmt.Sprintf("objectName must be set for %s level", o.Level)))
+		} else if !identifierPattern.MatchString(o.ObjectName) {
+			errs = append(errs, field.Invalid(namePath, o.ObjectName, identifierMessage))
+		}
+	case v1alpha1.SnapshotLevelTable:
+		if db, table, ok := strings.Cut(o.ObjectName, "."); !ok || !identifierPattern.MatchString(db) || !identifierPattern.MatchString(table) {
+			errs = append(errs, field.Invalid(namePath, o.ObjectName, "objectName must be <database>.<table> for table level, "+identifierMessage))
+		}
+	default:
+		errs = append(errs, field.NotSupported(path.Child("level"), o.Level, []string{
+			string(v1alpha1.SnapshotLevelCluster), string(v1alpha1.SnapshotLevelAccount),
+			string(v1alpha1.SnapshotLevelDatabase), string(v1alpha1.SnapshotLevelTable),
+		}))
+	}
+	return errs
+}
+
+func validateBackupJobSpec(spec *v1alpha1.BackupJobSpec, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	source := spec.Source
+	sourcePath := path.Child("source")
+	switch {
+	case source.ClusterRef != nil && source.CNSetRef != nil:
+		errs = append(errs, field.Invalid(sourcePath, nil, "clusterRef and cnSetRef are mutual exclusive"))
+	case source.ClusterRef == nil && source.CNSetRef == nil:
+		errs = append(errs, field.Invalid(sourcePath, nil, "one of clusterRef or cnSetRef must be set"))
+	case source.CNSetRef != nil && source.SecretRef == nil:
+		errs = append(errs, field.Required(sourcePath.Child("secretRef"), "secretRef must be set when using cnSetRef as backup source"))
+	}
+	if spec.Type != v1alpha1.BackupTypeIncremental && spec.ParentBackup != "" {
+		errs = append(errs, field.Invalid(path.Child("parentBackup"), spec.ParentBackup, "parentBackup can only be set for incremental backup"))
+	}
+	errs = append(errs, validateBRStorage(&spec.Target, path.Child("target"))...)
+	errs = append(errs, validateRetry(spec.RetryPolicy, spec.ActiveDeadline, path)...)
+	if spec.Encryption != nil {
+		errs = append(errs, validateEncryption(spec.Encryption, path.Child("encryption"))...)
+	}
+	return errs
+}
+
+func validateEncryption(e *v1alpha1.BackupEncryption, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if e.SecretRef.Name == "" {
+		errs = append(errs, field.Required(path.Child("secretRef", "name"), "secretRef must be set"))
+	}
+	if e.KeyID == "" {
+		errs = append(errs, field.Required(path.Child("keyID"), "keyID must be set"))
+	}
+	return errs
+}
+
+// validateRetry validates the retry policy and the active deadline of the backup and restore jobs
+func validateRetry(policy *v1alpha1.RetryPolicy, deadline *metav1.Duration, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if policy != nil && policy.Backoff != nil && policy.Backoff.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("retryPolicy", "backoff"), policy.Backoff.Duration.String(), "backoff must be positive"))
+	}
+	if deadline != nil && deadline.Duration <= 0 {
+		errs = append(errs, field.Invalid(path.Child("activeDeadline"), deadline.Duration.String(), "activeDeadline must be positive"))
+	}
+	return errs
+}
+
+func validatePointInTime(pit *v1alpha1.PointInTimeRestore, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if pit.ClusterRef == "" {
+		errs = append(errs, field.Required(path.Child("clusterRef"), "clusterRef must be set"))
+	}
+	if pit.Time.IsZero() {
+		errs = append(errs, field.Required(path.Child("time"), "time must be set"))
+	}
+	return errs
+}
+
+// validateBRStorage validates the storage of the backup and restore jobs
+func validateBRStorage(sp *v1alpha1.SharedStorageProvider, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	switch {
+	case sp.S3 != nil && sp.FileSystem != nil:
+		errs = append(errs, field.Invalid(path, nil, "more than 1 storage provider configured"))
+	case sp.S3 != nil:
+		if sp.S3.Path == "" {
+			errs = append(errs, field.Required(path.Child("s3", "path"), "path must be set for S3 storage"))
+		}
+		errs = append(errs, validateS3Provider(sp.S3, path.Child("s3"))...)
+	case sp.FileSystem != nil:
+		fsPath := path.Child("fileSystem")
+		if !filepath.IsAbs(sp.FileSystem.Path) {
+			errs = append(errs, field.Invalid(fsPath.Child("path"), sp.FileSystem.Path, "path must be an absolute path for file-system storage"))
+		}
+		if vol := sp.FileSystem.Volume; vol != nil {
+			if (vol.PersistentVolumeClaim == nil) == (vol.NFS == nil) {
+				errs = append(errs, field.Invalid(fsPath.Child("volume"), nil, "exactly one of persistentVolumeClaim or nfs must be set"))
+			}
+		}
+	default:
+		errs = append(errs, field.Invalid(path, nil, "no storage provider configured"))
+	}
+	return errs
+}
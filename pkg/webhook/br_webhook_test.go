@@ -0,0 +1,576 @@
+// Copyright 2025 Matrix Origin
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+
+	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
+)
+
+func Test_validateBackupJobSpec(t *testing.T) {
+	gcs, oss, cos := v1alpha1.S3ProviderTypeGCS, v1alpha1.S3ProviderTypeOSS, v1alpha1.S3ProviderType("cos")
+	tests := []struct {
+		name    string
+		spec    v1alpha1.BackupJobSpec
+		wantErr bool
+	}{{
+		name: "s3 target",
+		spec: v1alpha1.BackupJobSpec{
+			Source: v1alpha1.BackupSource{ClusterRef: pointer.String("mo")},
+			Target: v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/backup"}},
+		},
+	}, {
+		name: "pvc target",
+		spec: v1alpha1.BackupJobSpec{
+			Source: v1alpha1.BackupSource{ClusterRef: pointer.String("mo")},
+			Target: v1alpha1.SharedStorageProvider{FileSystem: &v1alpha1.FileSystemProvider{
+				Path: "/backup",
+				Volume: &v1alpha1.FileSystemVolume{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "backup"},
+				},
+			}},
+		},
+	}, {
+		name: "s3 target with web identity",
+		spec: v1alpha1.BackupJobSpec{
+			Source: v1alpha1.BackupSource{ClusterRef: pointer.String("mo")},
+			Target: v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{
+				Path:        "bucket/backup",
+				WebIdentity: &v1alpha1.S3WebIdentity{RoleARN: "arn:aws:iam::123456789012:role/backup"},
+			}},
+		},
+	}, {
+		name: "s3 target with both secret and web identity",
+		spec: v1alpha1.BackupJobSpec{
+			Source: v1alpha1.BackupSource{ClusterRef: pointer.String("mo")},
+			Target: v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{
+				Path:        "bucket/backup",
+				SecretRef:   &corev1.LocalObjectReference{Name: "aws"},
+				WebIdentity: &v1alpha1.S3WebIdentity{RoleARN: "arn:aws:iam::123456789012:role/backup"},
+			}},
+		},
+		wantErr: true,
+	}, {
+		name: "web identity without role",
+		spec: v1alpha1.BackupJobSpec{
+			Source: v1alpha1.BackupSource{ClusterRef: pointer.String("mo")},
+			Target: v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{
+				Path:        "bucket/backup",
+				WebIdentity: &v1alpha1.S3WebIdentity{},
+			}},
+		},
+		wantErr: true,
+	}, {
+		name: "gcs target",
+		spec: v1alpha1.BackupJobSpec{
+			Source: v1alpha1.BackupSource{ClusterRef: pointer.String("mo")},
+			Target: v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{
+				Path:      "bucket/backup",
+				Type:      &gcs,
+				SecretRef: &corev1.LocalObjectReference{Name: "gcs"},
+			}},
+		},
+	}, {
+		name: "oss target without region",
+		spec: v1alpha1.BackupJobSpec{
+			Source: v1alpha1.BackupSource{ClusterRef: pointer.String("mo")},
+			Target: v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{
+				Path: "bucket/backup",
+				Type: &oss,
+			}},
+		},
+		wantErr: true,
+	}, {
+		name: "unknown provider type",
+		spec: v1alpha1.BackupJobSpec{
+			Source: v1alpha1.BackupSource{ClusterRef: pointer.String("mo")},
+			Target: v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{
+				Path: "bucket/backup",
+				Type: &cos,
+			}},
+		},
+		wantErr: true,
+	}, {
+		name: "web identity of gcs",
+		spec: v1alpha1.BackupJobSpec{
+			Source: v1alpha1.BackupSource{ClusterRef: pointer.String("mo")},
+			Target: v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{
+				Path:        "bucket/backup",
+				Type:        &gcs,
+				WebIdentity: &v1alpha1.S3WebIdentity{RoleARN: "arn:aws:iam::123456789012:role/backup"},
+			}},
+		},
+		wantErr: true,
+	}, {
+		name: "path style without endpoint",
+		spec: v1alpha1.BackupJobSpec{
+			Source: v1alpha1.BackupSource{ClusterRef: pointer.String("mo")},
+			Target: v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{
+				Path:           "bucket/backup",
+				ForcePathStyle: true,
+			}},
+		},
+		wantErr: true,
+	}, {
+		name: "no target",
+		spec: v1alpha1.BackupJobSpec{
+			Source: v1alpha1.BackupSource{ClusterRef: pointer.String("mo")},
+		},
+		wantErr: true,
+	}, {
+		name: "relative fileSystem path",
+		spec: v1alpha1.BackupJobSpec{
+			Source: v1alpha1.BackupSource{ClusterRef: pointer.String("mo")},
+			Target: v1alpha1.SharedStorageProvider{FileSystem: &v1alpha1.FileSystemProvider{Path: "backup"}},
+		},
+		wantErr: true,
+	}, {
+		name: "volume with multiple sources",
+		spec: v1alpha1.BackupJobSpec{
+			Source: v1alpha1.BackupSource{ClusterRef: pointer.String("mo")},
+			Target: v1alpha1.SharedStorageProvider{FileSystem: &v1alpha1.FileSystemProvider{
+				Path: "/backup",
+				Volume: &v1alpha1.FileSystemVolume{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "backup"},
+					NFS:                   &corev1.NFSVolumeSource{Server: "nfs", Path: "/export"},
+				},
+			}},
+		},
+		wantErr: true,
+	}, {
+		name: "cnset source without secret",
+		spec: v1alpha1.BackupJobSpec{
+			Source: v1alpha1.BackupSource{CNSetRef: pointer.String("cn")},
+			Target: v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket"}},
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			errs := validateBackupJobSpec(&tt.spec, field.NewPath("spec"))
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func Test_restoreJobValidator(t *testing.T) {
+	pit := &v1alpha1.PointInTimeRestore{ClusterRef: "mo", Time: metav1.Now()}
+	tests := []struct {
+		name    string
+		spec    v1alpha1.RestoreJobSpec
+		wantErr bool
+	}{{
+		name: "restore backup",
+		spec: v1alpha1.RestoreJobSpec{
+			BackupName: "daily",
+			Target:     v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/data"}},
+		},
+	}, {
+		name: "restore backup without target",
+		spec: v1alpha1.RestoreJobSpec{
+			BackupName: "daily",
+		},
+		wantErr: true,
+	}, {
+		name: "in-place point-in-time recovery",
+		spec: v1alpha1.RestoreJobSpec{
+			PointInTime: pit,
+		},
+	}, {
+		name: "point in time to a target",
+		spec: v1alpha1.RestoreJobSpec{
+			PointInTime: pit,
+			Target:      v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/data"}},
+		},
+		wantErr: true,
+	}, {
+		name: "both backup and point in time",
+		spec: v1alpha1.RestoreJobSpec{
+			BackupName:  "daily",
+			PointInTime: pit,
+			Target:      v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/data"}},
+		},
+		wantErr: true,
+	}, {
+		name: "point in time without cluster",
+		spec: v1alpha1.RestoreJobSpec{
+			PointInTime: &v1alpha1.PointInTimeRestore{Time: metav1.Now()},
+		},
+		wantErr: true,
+	}, {
+		name: "restore from external source",
+		spec: v1alpha1.RestoreJobSpec{
+			ExternalSource:   &v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/backup"}},
+			ExternalBackupID: "abcdefg",
+			Target:           v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/data"}},
+		},
+	}, {
+		name: "restore the latest backup from external S3",
+		spec: v1alpha1.RestoreJobSpec{
+			ExternalSource: &v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/backup"}},
+			Target:         v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/data"}},
+		},
+		wantErr: true,
+	}, {
+		name: "restore the latest backup from external fileSystem",
+		spec: v1alpha1.RestoreJobSpec{
+			ExternalSource: &v1alpha1.SharedStorageProvider{FileSystem: &v1alpha1.FileSystemProvider{Path: "/backup"}},
+			Target:         v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/data"}},
+		},
+	}, {
+		name: "both backup and external source",
+		spec: v1alpha1.RestoreJobSpec{
+			BackupName:     "daily",
+			ExternalSource: &v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/backup"}},
+			Target:         v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/data"}},
+		},
+		wantErr: true,
+	}, {
+		name: "non-positive retry backoff",
+		spec: v1alpha1.RestoreJobSpec{
+			BackupName:  "daily",
+			Target:      v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/data"}},
+			RetryPolicy: &v1alpha1.RetryPolicy{Backoff: &metav1.Duration{}},
+		},
+		wantErr: true,
+	}, {
+		name: "external backup id without external source",
+		spec: v1alpha1.RestoreJobSpec{
+			BackupName:       "daily",
+			ExternalBackupID: "abcdefg",
+			Target:           v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/data"}},
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			v := &restoreJobValidator{}
+			_, err := v.ValidateCreate(context.TODO(), &v1alpha1.RestoreJob{Spec: tt.spec})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func Test_backupVerificationValidator(t *testing.T) {
+	tests := []struct {
+		name      string
+		namespace string
+		wantErr   bool
+	}{{
+		name:      "isolated namespace",
+		namespace: "verify",
+	}, {
+		name:    "namespace not set",
+		wantErr: true,
+	}, {
+		name:      "namespace of the verification",
+		namespace: "default",
+		wantErr:   true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			v := &backupVerificationValidator{}
+			_, err := v.ValidateCreate(context.TODO(), &v1alpha1.BackupVerification{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "audit"},
+				Spec: v1alpha1.BackupVerificationSpec{
+					BackupName: "daily",
+					Namespace:  tt.namespace,
+				},
+			})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func Test_snapshotRestoreValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    v1alpha1.SnapshotRestoreSpec
+		wantErr bool
+	}{{
+		name: "cluster",
+		spec: v1alpha1.SnapshotRestoreSpec{
+			ClusterRef:     "mo",
+			Snapshot:       "sp",
+			SnapshotObject: v1alpha1.SnapshotObject{Level: v1alpha1.SnapshotLevelCluster},
+		},
+	}, {
+		name: "table",
+		spec: v1alpha1.SnapshotRestoreSpec{
+			ClusterRef:     "mo",
+			Snapshot:       "sp",
+			SnapshotObject: v1alpha1.SnapshotObject{Level: v1alpha1.SnapshotLevelTable, ObjectName: "db.t"},
+			ToAccount:      "acc",
+		},
+	}, {
+		name: "cluster with object name",
+		spec: v1alpha1.SnapshotRestoreSpec{
+			ClusterRef:     "mo",
+			Snapshot:       "sp",
+			SnapshotObject: v1alpha1.SnapshotObject{Level: v1alpha1.SnapshotLevelCluster, ObjectName: "acc"},
+		},
+		wantErr: true,
+	}, {
+		name: "cluster to another account",
+		spec: v1alpha1.SnapshotRestoreSpec{
+			ClusterRef:     "mo",
+			Snapshot:       "sp",
+			SnapshotObject: v1alpha1.SnapshotObject{Level: v1alpha1.SnapshotLevelCluster},
+			ToAccount:      "acc",
+		},
+		wantErr: true,
+	}, {
+		name: "table without database",
+		spec: v1alpha1.SnapshotRestoreSpec{
+			ClusterRef:     "mo",
+			Snapshot:       "sp",
+			SnapshotObject: v1alpha1.SnapshotObject{Level: v1alpha1.SnapshotLevelTable, ObjectName: "t"},
+		},
+		wantErr: true,
+	}, {
+		name: "database without name",
+		spec: v1alpha1.SnapshotRestoreSpec{
+			ClusterRef:     "mo",
+			Snapshot:       "sp",
+			SnapshotObject: v1alpha1.SnapshotObject{Level: v1alpha1.SnapshotLevelDatabase},
+		},
+		wantErr: true,
+	}, {
+		name: "without cluster",
+		spec: v1alpha1.SnapshotRestoreSpec{
+			Snapshot:       "sp",
+			SnapshotObject: v1alpha1.SnapshotObject{Level: v1alpha1.SnapshotLevelAccount, ObjectName: "acc"},
+		},
+		wantErr: true,
+	}, {
+		name: "account with backtick",
+		spec: v1alpha1.SnapshotRestoreSpec{
+			ClusterRef:     "mo",
+			Snapshot:       "sp",
+			SnapshotObject: v1alpha1.SnapshotObject{Level: v1alpha1.SnapshotLevelAccount, ObjectName: "acc`; DROP DATABASE d; --"},
+		},
+		wantErr: true,
+	}, {
+		name: "table with invalid name",
+		spec: v1alpha1.SnapshotRestoreSpec{
+			ClusterRef:     "mo",
+			Snapshot:       "sp",
+			SnapshotObject: v1alpha1.SnapshotObject{Level: v1alpha1.SnapshotLevelTable, ObjectName: "db.t-1"},
+		},
+		wantErr: true,
+	}, {
+		name: "snapshot with backtick",
+		spec: v1alpha1.SnapshotRestoreSpec{
+			ClusterRef:     "mo",
+			Snapshot:       "sp`",
+			SnapshotObject: v1alpha1.SnapshotObject{Level: v1alpha1.SnapshotLevelCluster},
+		},
+		wantErr: true,
+	}, {
+		name: "to account with backtick",
+		spec: v1alpha1.SnapshotRestoreSpec{
+			ClusterRef:     "mo",
+			Snapshot:       "sp",
+			SnapshotObject: v1alpha1.SnapshotObject{Level: v1alpha1.SnapshotLevelAccount, ObjectName: "acc"},
+			ToAccount:      "acc`",
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			v := &snapshotRestoreValidator{}
+			_, err := v.ValidateCreate(context.TODO(), &v1alpha1.SnapshotRestore{Spec: tt.spec})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func Test_logicalBackupJobValidator(t *testing.T) {
+	target := v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/dump"}}
+	source := v1alpha1.LogicalEndpoint{ClusterRef: "mo"}
+	tests := []struct {
+		name    string
+		spec    v1alpha1.LogicalBackupJobSpec
+		wantErr bool
+	}{{
+		name: "tables",
+		spec: v1alpha1.LogicalBackupJobSpec{
+			Source: source,
+			Target: target,
+			Scope:  v1alpha1.LogicalScope{Tables: []string{"db.t1", "db.t2"}},
+		},
+	}, {
+		name: "sys account",
+		spec: v1alpha1.LogicalBackupJobSpec{
+			Source: source,
+			Target: target,
+			Scope:  v1alpha1.LogicalScope{Account: "sys"},
+		},
+	}, {
+		name: "account without credential",
+		spec: v1alpha1.LogicalBackupJobSpec{
+			Source: source,
+			Target: target,
+			Scope:  v1alpha1.LogicalScope{Account: "acc"},
+		},
+		wantErr: true,
+	}, {
+		name: "more than one scope",
+		spec: v1alpha1.LogicalBackupJobSpec{
+			Source: source,
+			Target: target,
+			Scope:  v1alpha1.LogicalScope{Databases: []string{"db"}, Tables: []string{"db.t1"}},
+		},
+		wantErr: true,
+	}, {
+		name: "table without database",
+		spec: v1alpha1.LogicalBackupJobSpec{
+			Source: source,
+			Target: target,
+			Scope:  v1alpha1.LogicalScope{Tables: []string{"t1"}},
+		},
+		wantErr: true,
+	}, {
+		name: "database with shell metacharacters",
+		spec: v1alpha1.LogicalBackupJobSpec{
+			Source: source,
+			Target: target,
+			Scope:  v1alpha1.LogicalScope{Databases: []string{"db;rm -rf /"}},
+		},
+		wantErr: true,
+	}, {
+		name: "table with shell metacharacters",
+		spec: v1alpha1.LogicalBackupJobSpec{
+			Source: source,
+			Target: target,
+			Scope:  v1alpha1.LogicalScope{Tables: []string{"db.$(id)"}},
+		},
+		wantErr: true,
+	}, {
+		name: "without target",
+		spec: v1alpha1.LogicalBackupJobSpec{
+			Source: source,
+			Scope:  v1alpha1.LogicalScope{Databases: []string{"db"}},
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			v := &logicalBackupJobValidator{}
+			_, err := v.ValidateCreate(context.TODO(), &v1alpha1.LogicalBackupJob{Spec: tt.spec})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
+
+func Test_storageMigrationValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    v1alpha1.StorageMigrationSpec
+		wantErr bool
+	}{{
+		name: "s3",
+		spec: v1alpha1.StorageMigrationSpec{
+			ClusterRef: "mo",
+			Target:     v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/data"}},
+		},
+	}, {
+		name: "without cluster",
+		spec: v1alpha1.StorageMigrationSpec{
+			Target: v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/data"}},
+		},
+		wantErr: true,
+	}, {
+		name: "file system",
+		spec: v1alpha1.StorageMigrationSpec{
+			ClusterRef: "mo",
+			Target:     v1alpha1.SharedStorageProvider{FileSystem: &v1alpha1.FileSystemProvider{Path: "/data"}},
+		},
+		wantErr: true,
+	}, {
+		name: "without path",
+		spec: v1alpha1.StorageMigrationSpec{
+			ClusterRef: "mo",
+			Target:     v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{}},
+		},
+		wantErr: true,
+	}, {
+		name: "zero parallelism",
+		spec: v1alpha1.StorageMigrationSpec{
+			ClusterRef:  "mo",
+			Target:      v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/data"}},
+			Parallelism: pointer.Int32(0),
+		},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			v := &storageMigrationValidator{}
+			_, err := v.ValidateCreate(context.TODO(), &v1alpha1.StorageMigration{Spec: tt.spec})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+
+	g := NewGomegaWithT(t)
+	v := &storageMigrationValidator{}
+	old := &v1alpha1.StorageMigration{Spec: tests[0].spec}
+	// the credentials of the target can be changed, while the location cannot
+	rotated := old.DeepCopy()
+	rotated.Spec.Target.S3.SecretRef = &corev1.LocalObjectReference{Name: "aws"}
+	_, err := v.ValidateUpdate(context.TODO(), old, rotated)
+	g.Expect(err).NotTo(HaveOccurred())
+	moved := old.DeepCopy()
+	moved.Spec.Target.S3.Path = "other/data"
+	_, err = v.ValidateUpdate(context.TODO(), old, moved)
+	g.Expect(err).To(HaveOccurred())
+}
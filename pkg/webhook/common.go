@@ -70,6 +70,54 @@ func validateVolume(v *v1alpha1.Volume, parent *field.Path) field.ErrorList {
 	return errs
 }
 
+// validateS3Provider validates the provider type, the addressing and the credential source of the S3 storage
+func validateS3Provider(s3 *v1alpha1.S3Provider, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	switch t := s3.GetProviderType(); t {
+	case v1alpha1.S3ProviderTypeAWS, v1alpha1.S3ProviderTypeMinIO, v1alpha1.S3ProviderTypeGCS:
+	case v1alpha1.S3ProviderTypeOSS:
+		if s3.Endpoint == "" && s3.Region == "" {
+			errs = append(errs, field.Required(path.Child("region"), "region or endpoint must be set for oss"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(path.Child("type"), t, []string{
+			string(v1alpha1.S3ProviderTypeAWS),
+			string(v1alpha1.S3ProviderTypeMinIO),
+			string(v1alpha1.S3ProviderTypeGCS),
+			string(v1alpha1.S3ProviderTypeOSS),
+		}))
+	}
+	if s3.WebIdentity != nil && s3.GetProviderType() != v1alpha1.S3ProviderTypeAWS {
+		errs = append(errs, field.Invalid(path.Child("webIdentity"), nil, "webIdentity is only supported by aws"))
+	}
+	if s3.ForcePathStyle && s3.Endpoint == "" {
+		errs = append(errs, field.Required(path.Child("endpoint"), "endpoint must be set to address the bucket in path"))
+	}
+	errs = append(errs, validateS3Credential(s3, path)...)
+	return errs
+}
+
+// validateS3Credential validates the credential source of the S3 storage
+func validateS3Credential(s3 *v1alpha1.S3Provider, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	w := s3.WebIdentity
+	if w == nil {
+		return nil
+	}
+	if s3.SecretRef != nil {
+		errs = append(errs, field.Invalid(path, nil, "secretRef and webIdentity are mutual exclusive"))
+	}
+	if w.RoleARN == "" {
+		errs = append(errs, field.Required(path.Child("webIdentity", "roleARN"), "roleARN must be set"))
+	}
+	if w.ServiceAccountName != "" {
+		for _, msg := range corevalidation.ValidateServiceAccountName(w.ServiceAccountName, false) {
+			errs = append(errs, field.Invalid(path.Child("webIdentity", "serviceAccountName"), w.ServiceAccountName, msg))
+		}
+	}
+	return errs
+}
+
 func validateGoMemLimitPercent(memPercent *int, path *field.Path) field.ErrorList {
 	if memPercent == nil {
 		return nil
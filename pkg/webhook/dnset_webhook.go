@@ -16,6 +16,7 @@ package webhook
 
 import (
 	"context"
+	"fmt"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -84,7 +85,7 @@ func (d *dnSetValidator) ValidateCreate(_ context.Context, obj runtime.Object) (
 	errs = append(errs, d.ValidateSpecCreate(&dnSet.Spec)...)
 	errs = append(errs, validatePodSet(&dnSet.Spec.PodSet, field.NewPath("spec"))...)
 	errs = append(errs, d.validateConfig(dnSet.Spec.Config, field.NewPath("spec").Child("config"))...)
-	return nil, invalidOrNil(errs, dnSet)
+	return d.WarningsForSpec(&dnSet.Spec, field.NewPath("spec")), invalidOrNil(errs, dnSet)
 }
 
 func (d *dnSetValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (warnings admission.Warnings, err error) {
@@ -118,6 +119,18 @@ func (d *dnSetValidator) ValidateSpecCreate(spec *v1alpha1.DNSetSpec) field.Erro
 	return errs
 }
 
+// WarningsForSpec warns about multiple TN replicas for MO versions that cannot run multiple TN stores,
+// the spec is still accepted to keep backward compatibility
+func (d *dnSetValidator) WarningsForSpec(spec *v1alpha1.DNSetSpec, path *field.Path) admission.Warnings {
+	if spec.Replicas <= 1 {
+		return nil
+	}
+	if sv, ok := spec.GetSemVer(); ok && v1alpha1.HasMOFeature(*sv, v1alpha1.MOFeatureMultiTN) {
+		return nil
+	}
+	return admission.Warnings{fmt.Sprintf("%s: multiple TN replicas are not supported by the MO version, only one TN store will serve", path.Child("replicas"))}
+}
+
 func (d *dnSetValidator) validateConfig(c *v1alpha1.TomlConfig, path *field.Path) field.ErrorList {
 	var errs field.ErrorList
 	if c == nil {
@@ -21,6 +21,7 @@ import (
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
 )
@@ -116,4 +117,21 @@ var _ = Describe("DNSet Webhook", func() {
 		}
 		Expect(k8sClient.Create(context.TODO(), dn)).NotTo(Succeed())
 	})
+
+	It("should warn multiple TN replicas if the MO version does not support it", func() {
+		v := &dnSetValidator{}
+		spec := &v1alpha1.DNSetSpec{
+			PodSet: v1alpha1.PodSet{
+				Replicas: 2,
+				MainContainer: v1alpha1.MainContainer{
+					Image: "test:v1.2.3",
+				},
+			},
+		}
+		Expect(v.WarningsForSpec(spec, field.NewPath("spec"))).To(HaveLen(1))
+		spec.Image = "test:v2.1.0"
+		Expect(v.WarningsForSpec(spec, field.NewPath("spec"))).To(HaveLen(1))
+		spec.Replicas = 1
+		Expect(v.WarningsForSpec(spec, field.NewPath("spec"))).To(BeEmpty())
+	})
 })
@@ -95,6 +95,7 @@ func (l *logSetDefaulter) DefaultSpec(spec *v1alpha1.LogSetSpec) {
 		spec.StoreFailureTimeout = &metav1.Duration{Duration: defaultStoreFailureTimeout}
 	}
 	l.setDefaultRetentionPolicy(spec)
+	l.setDefaultETLStorage(spec)
 	setDefaultServiceArgs(spec)
 	setPodSetDefaults(&spec.PodSet)
 }
@@ -131,6 +132,27 @@ func (l *logSetDefaulter) setDefaultRetentionPolicy(spec *v1alpha1.LogSetSpec) {
 	}
 }
 
+// setDefaultETLStorage makes the credentials and the retention policy of a dedicated S3 ETL storage explicit,
+// the ETL storage is accessed with the credentials of the shared storage and its BucketClaim cleans the data with them
+func (l *logSetDefaulter) setDefaultETLStorage(spec *v1alpha1.LogSetSpec) {
+	etl := spec.GetETLS3()
+	if etl == nil {
+		return
+	}
+	if shared := spec.SharedStorage.S3; shared != nil {
+		if etl.SecretRef == nil && etl.WebIdentity == nil {
+			etl.SecretRef = shared.SecretRef
+			etl.WebIdentity = shared.WebIdentity
+		}
+		if etl.CertificateRef == nil {
+			etl.CertificateRef = shared.CertificateRef
+		}
+	}
+	if etl.S3RetentionPolicy == nil {
+		etl.S3RetentionPolicy = spec.S3RetentionPolicyOf(etl)
+	}
+}
+
 // +kubebuilder:webhook:path=/validate-core-matrixorigin-io-v1alpha1-logset,mutating=false,failurePolicy=fail,sideEffects=None,groups=core.matrixorigin.io,resources=logsets,verbs=create;update,versions=v1alpha1,name=vlogset.kb.io,admissionReviewVersions={v1,v1beta1}
 
 // logSetValidator implements webhook.Validator so a webhook will be registered for the v1alpha1.LogSet
@@ -179,18 +201,66 @@ func (l *logSetValidator) ValidateSpecUpdate(oldSpec, spec *v1alpha1.LogSetSpec,
 	if !equality.Semantic.DeepEqual(oldSpec.InitialConfig, spec.InitialConfig) {
 		errs = append(errs, field.Invalid(field.NewPath("spec").Child("initialConfig"), nil, "initialConfig is immutable"))
 	}
-	if !equality.Semantic.DeepEqual(oldSpec.SharedStorage.S3, spec.SharedStorage.S3) {
-		errs = append(errs, field.Invalid(field.NewPath("spec").Child("sharedStorage").Child("s3"), nil, "sharedStorage.s3 is immutable"))
+	if !equality.Semantic.DeepEqual(withoutS3Credential(oldSpec.SharedStorage.S3), withoutS3Credential(spec.SharedStorage.S3)) {
+		migrating, err := l.isMigrating(meta, oldSpec.SharedStorage.S3, spec.SharedStorage.S3)
+		switch {
+		case err != nil:
+			errs = append(errs, field.InternalError(field.NewPath("spec").Child("sharedStorage").Child("s3"), err))
+		case !migrating:
+			errs = append(errs, field.Invalid(field.NewPath("spec").Child("sharedStorage").Child("s3"), nil, "sharedStorage.s3 is immutable"))
+		}
+	}
+	if !equality.Semantic.DeepEqual(withoutS3Credential(oldSpec.GetETLS3()), withoutS3Credential(spec.GetETLS3())) {
+		// the bucket claim of the etl storage is named after the LogSet and cannot be switched or released
+		errs = append(errs, field.Invalid(field.NewPath("spec").Child("etlStorage").Child("s3"), nil, "etlStorage.s3 is immutable"))
 	}
 	errs = append(errs, l.validateIfBucketInUse(meta, spec)...)
 	return errs
 }
 
+// isMigrating returns whether the shared storage is being switched from the old S3 storage to the new one by
+// a StorageMigration of the cluster, the objects have been copied and verified at the time
+func (l *logSetValidator) isMigrating(meta metav1.ObjectMeta, oldS3, newS3 *v1alpha1.S3Provider) (bool, error) {
+	if l.kClient == nil || oldS3 == nil || newS3 == nil {
+		return false, nil
+	}
+	smList := &v1alpha1.StorageMigrationList{}
+	if err := l.kClient.List(context.TODO(), smList, client.InNamespace(meta.Namespace)); err != nil {
+		return false, err
+	}
+	for _, sm := range smList.Items {
+		if sm.Spec.ClusterRef != meta.Name || sm.Status.Phase != v1alpha1.StorageMigrationPhaseSwitching {
+			continue
+		}
+		if sm.Status.Source == nil || sm.Status.Source.S3 == nil || sm.Spec.Target.S3 == nil {
+			continue
+		}
+		if v1alpha1.UniqueBucketLabel(sm.Status.Source.S3) == v1alpha1.UniqueBucketLabel(oldS3) &&
+			v1alpha1.UniqueBucketLabel(sm.Spec.Target.S3) == v1alpha1.UniqueBucketLabel(newS3) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// withoutS3Credential returns a copy of the S3 storage without the credential source, which can be switched
+// to rotate the credentials or to move off static keys
+func withoutS3Credential(s3 *v1alpha1.S3Provider) *v1alpha1.S3Provider {
+	if s3 == nil {
+		return nil
+	}
+	s3 = s3.DeepCopy()
+	s3.SecretRef = nil
+	s3.WebIdentity = nil
+	return s3
+}
+
 func (l *logSetValidator) validateMutateCommon(spec *v1alpha1.LogSetSpec) field.ErrorList {
 	var errs field.ErrorList
 	errs = append(errs, validateVolume(&spec.Volume, field.NewPath("spec").Child("volume"))...)
 	errs = append(errs, l.validateInitialConfig(spec)...)
 	errs = append(errs, l.validateSharedStorage(spec)...)
+	errs = append(errs, l.validateETLStorage(spec)...)
 	errs = append(errs, validateGoMemLimitPercent(spec.MemoryLimitPercent, field.NewPath("spec").Child("memoryLimitPercent"))...)
 	return errs
 }
@@ -204,6 +274,7 @@ func (l *logSetValidator) validateSharedStorage(spec *v1alpha1.LogSetSpec) field
 		if spec.SharedStorage.S3.Path == "" {
 			errs = append(errs, field.Invalid(parent, nil, "path must be set for S3 storage"))
 		}
+		errs = append(errs, validateS3Provider(spec.SharedStorage.S3, parent.Child("s3"))...)
 	}
 	if spec.SharedStorage.FileSystem != nil {
 		count += 1
@@ -220,6 +291,46 @@ func (l *logSetValidator) validateSharedStorage(spec *v1alpha1.LogSetSpec) field
 	return errs
 }
 
+func (l *logSetValidator) validateETLStorage(spec *v1alpha1.LogSetSpec) field.ErrorList {
+	etl := spec.ETLStorage
+	if etl == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	parent := field.NewPath("spec").Child("etlStorage")
+	switch {
+	case etl.S3 != nil && etl.FileSystem != nil:
+		errs = append(errs, field.Invalid(parent, nil, "more than 1 storage provider configured"))
+	case etl.S3 != nil:
+		path := parent.Child("s3")
+		if etl.S3.Path == "" {
+			errs = append(errs, field.Required(path.Child("path"), "path must be set for S3 storage"))
+		}
+		errs = append(errs, validateS3Provider(etl.S3, path)...)
+		shared := spec.SharedStorage.S3
+		if shared == nil {
+			break
+		}
+		if v1alpha1.UniqueBucketLabel(shared) == v1alpha1.UniqueBucketLabel(etl.S3) {
+			errs = append(errs, field.Invalid(path.Child("path"), etl.S3.Path, "etl storage must not be the shared storage"))
+		}
+		// the credentials are injected as the same env and the certificates are mounted to the same path
+		if !equality.Semantic.DeepEqual(etl.S3.SecretRef, shared.SecretRef) || !equality.Semantic.DeepEqual(etl.S3.WebIdentity, shared.WebIdentity) {
+			errs = append(errs, field.Invalid(path, nil, "etl storage must be accessed with the credentials of the shared storage"))
+		}
+		if etl.S3.CertificateRef != nil && shared.CertificateRef != nil && !equality.Semantic.DeepEqual(etl.S3.CertificateRef, shared.CertificateRef) {
+			errs = append(errs, field.Invalid(path.Child("certificateRef"), nil, "etl storage must use the certificates of the shared storage"))
+		}
+	case etl.FileSystem != nil:
+		if etl.FileSystem.Path == "" {
+			errs = append(errs, field.Required(parent.Child("fileSystem", "path"), "path must be set for file-system storage"))
+		}
+	default:
+		errs = append(errs, field.Invalid(parent, nil, "no storage provider configured"))
+	}
+	return errs
+}
+
 func (l *logSetValidator) validateInitialConfig(spec *v1alpha1.LogSetSpec) field.ErrorList {
 	var errs field.ErrorList
 	parent := field.NewPath("spec").Child("initialConfig")
@@ -250,49 +361,55 @@ func (l *logSetValidator) validateIfBucketDeleting(spec *v1alpha1.LogSetSpec) fi
 	if !features.DefaultFeatureGate.Enabled(features.S3Reclaim) {
 		return nil
 	}
-	if spec.SharedStorage.S3 == nil {
-		return nil
-	}
 	var errs field.ErrorList
-	path := field.NewPath("spec").Child("sharedStorage").Child("s3")
-	bucket, err := v1alpha1.ClaimedBucket(l.kClient, spec.SharedStorage.S3)
-	if err != nil {
-		errs = append(errs, field.Invalid(path, nil, err.Error()))
-		return errs
-	}
-	if bucket == nil {
-		return nil
-	}
-	if bucket.DeletionTimestamp != nil {
-		msg := fmt.Sprintf("claimed bucket %v state is deleting", client.ObjectKeyFromObject(bucket))
-		errs = append(errs, field.Invalid(path, nil, msg))
-		return errs
+	for _, cs := range claimedStorages(spec) {
+		bucket, err := v1alpha1.ClaimedBucket(l.kClient, cs.s3)
+		if err != nil {
+			errs = append(errs, field.Invalid(cs.path, nil, err.Error()))
+			continue
+		}
+		if bucket != nil && bucket.DeletionTimestamp != nil {
+			msg := fmt.Sprintf("claimed bucket %v state is deleting", client.ObjectKeyFromObject(bucket))
+			errs = append(errs, field.Invalid(cs.path, nil, msg))
+		}
 	}
-	return nil
+	return errs
 }
 
 func (l *logSetValidator) validateIfBucketInUse(meta metav1.ObjectMeta, spec *v1alpha1.LogSetSpec) field.ErrorList {
 	if !features.DefaultFeatureGate.Enabled(features.S3Reclaim) {
 		return nil
 	}
-	if spec.SharedStorage.S3 == nil {
-		return nil
-	}
 	var errs field.ErrorList
-	path := field.NewPath("spec").Child("sharedStorage").Child("s3")
-	bucket, err := v1alpha1.ClaimedBucket(l.kClient, spec.SharedStorage.S3)
-	if err != nil {
-		errs = append(errs, field.Invalid(path, nil, err.Error()))
-		return errs
+	for _, cs := range claimedStorages(spec) {
+		bucket, err := v1alpha1.ClaimedBucket(l.kClient, cs.s3)
+		if err != nil {
+			errs = append(errs, field.Invalid(cs.path, nil, err.Error()))
+			continue
+		}
+		if bucket != nil && bucket.Status.State == v1alpha1.StatusInUse &&
+			bucket.Status.BindTo != v1alpha1.BucketBindToMark(meta) {
+			msg := fmt.Sprintf("claimed bucket %v already bind to %v", client.ObjectKeyFromObject(bucket), bucket.Status.BindTo)
+			errs = append(errs, field.Invalid(cs.path, nil, msg))
+		}
 	}
-	if bucket == nil {
-		return nil
+	return errs
+}
+
+// claimedStorage is an S3 storage that is claimed by the LogSet
+type claimedStorage struct {
+	path *field.Path
+	s3   *v1alpha1.S3Provider
+}
+
+// claimedStorages returns the S3 storages that are claimed by the LogSet
+func claimedStorages(spec *v1alpha1.LogSetSpec) []claimedStorage {
+	var storages []claimedStorage
+	if spec.SharedStorage.S3 != nil {
+		storages = append(storages, claimedStorage{path: field.NewPath("spec").Child("sharedStorage").Child("s3"), s3: spec.SharedStorage.S3})
 	}
-	if bucket.Status.State == v1alpha1.StatusInUse &&
-		bucket.Status.BindTo != v1alpha1.BucketBindToMark(meta) {
-		msg := fmt.Sprintf("claimed bucket %v already bind to %v", client.ObjectKeyFromObject(bucket), bucket.Status.BindTo)
-		errs = append(errs, field.Invalid(path, nil, msg))
-		return errs
+	if s3 := spec.GetETLS3(); s3 != nil {
+		storages = append(storages, claimedStorage{path: field.NewPath("spec").Child("etlStorage").Child("s3"), s3: s3})
 	}
-	return nil
+	return storages
 }
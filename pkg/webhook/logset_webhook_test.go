@@ -16,12 +16,17 @@ package webhook
 
 import (
 	"context"
+	"testing"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/matrixorigin/matrixone-operator/api/core/v1alpha1"
 )
@@ -143,3 +148,161 @@ var _ = Describe("LogSet Webhook", func() {
 		Expect(k8sClient.Update(context.TODO(), ls)).To(Succeed())
 	})
 })
+
+func Test_logSetETLStorage(t *testing.T) {
+	aws := &corev1.LocalObjectReference{Name: "aws"}
+	tests := []struct {
+		name    string
+		shared  v1alpha1.SharedStorageProvider
+		etl     *v1alpha1.SharedStorageProvider
+		wantErr bool
+	}{{
+		name:   "no etl storage",
+		shared: v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/data", SecretRef: aws}},
+	}, {
+		name:   "dedicated bucket inherits credentials",
+		shared: v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/data", SecretRef: aws}},
+		etl:    &v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "etl/data"}},
+	}, {
+		name:   "filesystem",
+		shared: v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/data"}},
+		etl:    &v1alpha1.SharedStorageProvider{FileSystem: &v1alpha1.FileSystemProvider{Path: "/etl"}},
+	}, {
+		name:    "same as shared storage",
+		shared:  v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/data"}},
+		etl:     &v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/data"}},
+		wantErr: true,
+	}, {
+		name:   "different credentials",
+		shared: v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/data", SecretRef: aws}},
+		etl: &v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{
+			Path:      "etl/data",
+			SecretRef: &corev1.LocalObjectReference{Name: "etl"},
+		}},
+		wantErr: true,
+	}, {
+		name:    "no provider",
+		shared:  v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "bucket/data"}},
+		etl:     &v1alpha1.SharedStorageProvider{},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			spec := &v1alpha1.LogSetSpec{SharedStorage: tt.shared, ETLStorage: tt.etl}
+			(&logSetDefaulter{}).DefaultSpec(spec)
+			if etl := spec.GetETLS3(); etl != nil {
+				g.Expect(etl.S3RetentionPolicy).NotTo(BeNil())
+			}
+			errs := (&logSetValidator{}).validateETLStorage(spec)
+			if tt.wantErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func Test_logSetSharedStorageMigration(t *testing.T) {
+	g := NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	utilruntime.Must(v1alpha1.AddToScheme(s))
+	source := &v1alpha1.S3Provider{Path: "bucket/data"}
+	target := &v1alpha1.S3Provider{Path: "new-bucket/data"}
+	sm := &v1alpha1.StorageMigration{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "migrate"},
+		Spec: v1alpha1.StorageMigrationSpec{
+			ClusterRef: "mo",
+			Target:     v1alpha1.SharedStorageProvider{S3: target},
+		},
+		Status: v1alpha1.StorageMigrationStatus{
+			Phase:  v1alpha1.StorageMigrationPhaseVerifying,
+			Source: &v1alpha1.SharedStorageProvider{S3: source},
+		},
+	}
+	cli := fake.NewClientBuilder().WithScheme(s).WithObjects(sm).Build()
+	l := &logSetValidator{kClient: cli}
+	meta := metav1.ObjectMeta{Namespace: "default", Name: "mo"}
+
+	// the shared storage is immutable until the objects are verified
+	migrating, err := l.isMigrating(meta, source, target)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(migrating).To(BeFalse())
+
+	sm.Status.Phase = v1alpha1.StorageMigrationPhaseSwitching
+	g.Expect(cli.Update(context.TODO(), sm)).To(Succeed())
+	migrating, err = l.isMigrating(meta, source, target)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(migrating).To(BeTrue())
+
+	// only the switch to the target of the migration is allowed
+	migrating, err = l.isMigrating(meta, source, &v1alpha1.S3Provider{Path: "other/data"})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(migrating).To(BeFalse())
+	migrating, err = l.isMigrating(metav1.ObjectMeta{Namespace: "default", Name: "other"}, source, target)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(migrating).To(BeFalse())
+}
+
+func Test_logSetETLStorageImmutable(t *testing.T) {
+	etl := &v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "etl/data"}}
+	tests := []struct {
+		name    string
+		old     *v1alpha1.SharedStorageProvider
+		new     *v1alpha1.SharedStorageProvider
+		wantErr bool
+	}{{
+		name: "unchanged",
+		old:  etl,
+		new:  etl,
+	}, {
+		name: "credentials changed",
+		old:  etl,
+		new: &v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{
+			Path:      "etl/data",
+			SecretRef: &corev1.LocalObjectReference{Name: "aws"},
+		}},
+	}, {
+		name:    "path changed",
+		old:     etl,
+		new:     &v1alpha1.SharedStorageProvider{S3: &v1alpha1.S3Provider{Path: "etl/other"}},
+		wantErr: true,
+	}, {
+		name:    "added",
+		new:     etl,
+		wantErr: true,
+	}, {
+		name:    "removed",
+		old:     etl,
+		wantErr: true,
+	}, {
+		name: "filesystem changed",
+		old:  &v1alpha1.SharedStorageProvider{FileSystem: &v1alpha1.FileSystemProvider{Path: "/etl"}},
+		new:  &v1alpha1.SharedStorageProvider{FileSystem: &v1alpha1.FileSystemProvider{Path: "/other"}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			newSpec := func(etl *v1alpha1.SharedStorageProvider) *v1alpha1.LogSetSpec {
+				spec := &v1alpha1.LogSetSpec{
+					PodSet: v1alpha1.PodSet{Replicas: 3},
+					Volume: v1alpha1.Volume{Size: resource.MustParse("10Gi")},
+					SharedStorage: v1alpha1.SharedStorageProvider{
+						S3: &v1alpha1.S3Provider{Path: "bucket/data"},
+					},
+					ETLStorage: etl.DeepCopy(),
+				}
+				(&logSetDefaulter{}).DefaultSpec(spec)
+				return spec
+			}
+			errs := (&logSetValidator{}).ValidateSpecUpdate(newSpec(tt.old), newSpec(tt.new), metav1.ObjectMeta{Namespace: "default", Name: "mo"})
+			immutable := ContainElement(HaveField("Detail", "etlStorage.s3 is immutable"))
+			if tt.wantErr {
+				g.Expect(errs).To(immutable)
+			} else {
+				g.Expect(errs).NotTo(immutable)
+			}
+		})
+	}
+}
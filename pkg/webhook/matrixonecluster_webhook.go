@@ -51,8 +51,9 @@ func (matrixOneClusterWebhook) setupWebhookWithManager(mgr ctrl.Manager) error {
 			logService: &logSetDefaulter{},
 		}).
 		WithValidator(&matrixOneClusterValidator{
-			cn: &cnSetValidator{},
-			dn: &dnSetValidator{},
+			cn:    &cnSetValidator{},
+			dn:    &dnSetValidator{},
+			proxy: &proxySetValidator{},
 			logService: &logSetValidator{
 				kClient: mgr.GetClient(),
 			},
@@ -108,6 +109,7 @@ func (m *matrixOneClusterDefaulter) Default(ctx context.Context, obj runtime.Obj
 type matrixOneClusterValidator struct {
 	cn         *cnSetValidator
 	dn         *dnSetValidator
+	proxy      *proxySetValidator
 	logService *logSetValidator
 }
 
@@ -135,7 +137,7 @@ func (m *matrixOneClusterValidator) ValidateCreate(_ context.Context, obj runtim
 	errs = append(errs, m.validateMutateCommon(moc)...)
 	//errs = append(errs, r.Spec.LogService.ValidateCreate(LogSetKey(r))...)
 	errs = append(errs, m.logService.ValidateSpecCreate(v1alpha1.LogSetKey(moc), &moc.Spec.LogService)...)
-	return nil, invalidOrNil(errs, moc)
+	return m.tnWarnings(moc), invalidOrNil(errs, moc)
 }
 
 func (m *matrixOneClusterValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (warnings admission.Warnings, err error) {
@@ -145,7 +147,22 @@ func (m *matrixOneClusterValidator) ValidateUpdate(_ context.Context, oldObj, ne
 
 	old := oldObj.(*v1alpha1.MatrixOneCluster)
 	errs = append(errs, m.logService.ValidateSpecUpdate(&old.Spec.LogService, &moc.Spec.LogService, v1alpha1.LogSetKey(moc))...)
-	return nil, invalidOrNil(errs, moc)
+	return m.tnWarnings(moc), invalidOrNil(errs, moc)
+}
+
+// tnWarnings checks the TN spec against the version that the TN will actually run
+func (m *matrixOneClusterValidator) tnWarnings(moc *v1alpha1.MatrixOneCluster) admission.Warnings {
+	if moc.GetTN() == nil {
+		return nil
+	}
+	tn := moc.GetTN().DeepCopy()
+	if tn.SemanticVersion == nil {
+		tn.SemanticVersion = moc.Spec.SemanticVersion
+	}
+	if tn.SemanticVersion == nil && tn.Image == "" {
+		tn.SemanticVersion = &moc.Spec.Version
+	}
+	return m.dn.WarningsForSpec(tn, field.NewPath("spec").Child("tn"))
 }
 
 func (m *matrixOneClusterValidator) ValidateDelete(_ context.Context, _ runtime.Object) (warnings admission.Warnings, err error) {
@@ -172,6 +189,9 @@ func (m *matrixOneClusterValidator) validateMutateCommon(moc *v1alpha1.MatrixOne
 		}
 		groups[cn.Name] = true
 	}
+	if moc.Spec.Proxy != nil {
+		errs = append(errs, m.proxy.ValidateSpec(moc.Spec.Proxy, field.NewPath("spec").Child("proxy"))...)
+	}
 	if moc.Spec.Version == "" {
 		errs = append(errs, field.Invalid(field.NewPath("spec").Child("version"), "", "version must be set"))
 	}
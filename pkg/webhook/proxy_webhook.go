@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -62,12 +63,36 @@ type proxySetValidator struct{}
 
 var _ webhook.CustomValidator = &proxySetValidator{}
 
-func (p proxySetValidator) ValidateCreate(_ context.Context, _ runtime.Object) (warnings admission.Warnings, err error) {
-	return nil, nil
+func (p proxySetValidator) ValidateCreate(_ context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
+	proxySet, ok := obj.(*v1alpha1.ProxySet)
+	if !ok {
+		return nil, unexpectedKindError("ProxySet", obj)
+	}
+	return nil, invalidOrNil(p.ValidateSpec(&proxySet.Spec, field.NewPath("spec")), proxySet)
 }
 
-func (p proxySetValidator) ValidateUpdate(_ context.Context, _, _ runtime.Object) (warnings admission.Warnings, err error) {
-	return nil, nil
+func (p proxySetValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (warnings admission.Warnings, err error) {
+	proxySet, ok := newObj.(*v1alpha1.ProxySet)
+	if !ok {
+		return nil, unexpectedKindError("ProxySet", newObj)
+	}
+	return nil, invalidOrNil(p.ValidateSpec(&proxySet.Spec, field.NewPath("spec")), proxySet)
+}
+
+func (p proxySetValidator) ValidateSpec(spec *v1alpha1.ProxySetSpec, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if as := spec.Autoscaling; as != nil && as.MaxReplicas < as.MinReplicas {
+		errs = append(errs, field.Invalid(path.Child("autoscaling", "maxReplicas"), as.MaxReplicas, "maxReplicas must not be less than minReplicas"))
+	}
+	if plugin := spec.Plugin; plugin != nil {
+		if plugin.Image == "" {
+			errs = append(errs, field.Required(path.Child("plugin", "image"), "plugin image must be set"))
+		}
+		if spec.WaitPluginAddr != nil {
+			errs = append(errs, field.Invalid(path.Child("waitPluginAddr"), *spec.WaitPluginAddr, "waitPluginAddr cannot be set when plugin is set"))
+		}
+	}
+	return errs
 }
 
 func (p proxySetValidator) ValidateDelete(_ context.Context, _ runtime.Object) (warnings admission.Warnings, err error) {
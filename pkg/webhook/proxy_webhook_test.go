@@ -48,4 +48,34 @@ var _ = Describe("ProxySet Webhook", func() {
 		}
 		Expect(k8sClient.Create(context.TODO(), ps)).To(Succeed())
 	})
+
+	It("should reject invalid autoscaling", func() {
+		ps := &v1alpha1.ProxySet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "proxy-" + randomString(5),
+				Namespace: "default",
+			},
+			Spec: v1alpha1.ProxySetSpec{
+				PodSet: v1alpha1.PodSet{
+					Replicas: 2,
+					MainContainer: v1alpha1.MainContainer{
+						Image: "test:v1.2.3",
+					},
+				},
+				Autoscaling: &v1alpha1.ProxyAutoscaling{
+					MinReplicas:                 3,
+					MaxReplicas:                 2,
+					TargetConnectionsPerReplica: 100,
+				},
+			},
+			Deps: v1alpha1.ProxySetDeps{
+				LogSetRef: v1alpha1.LogSetRef{
+					ExternalLogSet: &v1alpha1.ExternalLogSet{HAKeeperEndpoint: "test"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), ps)).ToNot(Succeed())
+		ps.Spec.Autoscaling.MaxReplicas = 5
+		Expect(k8sClient.Create(context.TODO(), ps)).To(Succeed())
+	})
 })
@@ -273,6 +273,67 @@ var _ = Describe("Matrix BucketClaim test", func() {
 		Expect(exist).Should(BeFalse())
 	})
 
+	It("Should use and clean up a generic S3 compatible storage with path-style addressing", func() {
+		By("create logset cluster with minio accessed as a generic S3 compatible storage")
+		minioSecret := e2eutil.MinioSecret(env.Namespace)
+		Expect(kubeCli.Create(ctx, minioSecret)).To(Succeed())
+
+		provider := e2eutil.MinioShareStorage(minioSecret.Name)
+		awsType := v1alpha1.S3ProviderTypeAWS
+		policyDelete := v1alpha1.PVCRetentionPolicyDelete
+		provider.S3.Type = &awsType
+		provider.S3.Region = "us-east-1"
+		provider.S3.ForcePathStyle = true
+		provider.S3.S3RetentionPolicy = &policyDelete
+		ls := e2eutil.NewLogSetTpl(env.Namespace, fmt.Sprintf("%s:%s", moImageRepo, moVersion))
+		ls.Spec.SharedStorage = provider
+		Expect(kubeCli.Create(ctx, ls)).To(Succeed())
+
+		var bucket *v1alpha1.BucketClaim
+		var err error
+		Eventually(func() error {
+			bucket, err = v1alpha1.ClaimedBucket(kubeCli, provider.S3)
+			if err != nil || bucket == nil {
+				return fmt.Errorf("wait bucket creating for logset %v, %v", client.ObjectKeyFromObject(ls), err)
+			}
+			if bucket.Status.State != v1alpha1.StatusInUse {
+				return fmt.Errorf("bucket status is not inuse, current %v", bucket.Status)
+			}
+			return nil
+		}, waitBucketStatusTimeout, time.Second*2).Should(Succeed())
+
+		By("wait logset available on the path-style storage")
+		Eventually(func() error {
+			if err := kubeCli.Get(ctx, client.ObjectKeyFromObject(ls), ls); err != nil {
+				return err
+			}
+			if len(ls.Status.AvailableStores) > 0 {
+				return nil
+			}
+			return fmt.Errorf("wait logset pod in running state")
+		}, createLogSetTimeout, pollInterval).Should(Succeed())
+		object, err := e2eminio.PutObject(provider.S3.Path)
+		Expect(err).Should(BeNil())
+
+		By("tear down logset cluster")
+		Expect(kubeCli.Delete(ctx, ls)).To(Succeed())
+		Eventually(func() error {
+			return waitLogSetDeleted(ls)
+		}, teardownClusterTimeout, pollInterval).Should(Succeed())
+
+		By("bucket should be cleaned up with path-style addressing")
+		Eventually(func() error {
+			err := kubeCli.Get(ctx, client.ObjectKeyFromObject(bucket), bucket)
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("bucket should be deleted")
+		}, waitBucketStatusTimeout, time.Second*2).Should(Succeed())
+		exist, err := e2eminio.IsObjectExist(object)
+		Expect(err).Should(BeNil())
+		Expect(exist).Should(BeFalse())
+	})
+
 	It("Should not delete a bucket which is in use", func() {
 		By("create logset cluster with minio provider")
 		minioSecret := e2eutil.MinioSecret(env.Namespace)